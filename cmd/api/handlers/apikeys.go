@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/apikeys"
+	"github.com/waydevs/sections-api/internal/designpatters"
+)
+
+const (
+	apiKeysGroup   = "apikeys"
+	apiKeyIDParam  = "id"
+	noStoreControl = "no-store"
+)
+
+// APIKeysHandler lets admins issue, rotate and revoke the API keys of machine clients.
+type APIKeysHandler struct {
+	service APIKeyService
+}
+
+// NewAPIKeysHandler creates an APIKeysHandler.
+func NewAPIKeysHandler(service APIKeyService) APIKeysHandler {
+	return APIKeysHandler{service: service}
+}
+
+// ListAPIKeys lists every API key, without their secrets.
+func (h APIKeysHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.service.List(c.Request.Context())
+	if err != nil {
+		respondError(c, apiKeyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    keys,
+	})
+}
+
+// IssueAPIKey issues an API key. Its secret is only ever sent in this response, so it must
+// not be cached.
+func (h APIKeysHandler) IssueAPIKey(c *gin.Context) {
+	var request apikeys.IssueParams
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	issued, err := h.service.Issue(c.Request.Context(), request)
+	if err != nil {
+		respondError(c, apiKeyError(err))
+		return
+	}
+
+	respondIssued(c, issued, "API key issued, store its secret as it won't be shown again")
+}
+
+// RotateAPIKey issues a new secret for an API key. The previous one keeps working for the
+// rotation grace period, so clients can switch to the new one without downtime.
+func (h APIKeysHandler) RotateAPIKey(c *gin.Context) {
+	issued, err := h.service.Rotate(c.Request.Context(), c.Param(apiKeyIDParam))
+	if err != nil {
+		respondError(c, apiKeyError(err))
+		return
+	}
+
+	respondIssued(c, issued, "API key rotated, store its secret as it won't be shown again")
+}
+
+// RevokeAPIKey revokes an API key, so its secret stops working right away.
+func (h APIKeysHandler) RevokeAPIKey(c *gin.Context) {
+	err := h.service.Revoke(c.Request.Context(), c.Param(apiKeyIDParam))
+	if err != nil {
+		respondError(c, apiKeyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "API key revoked",
+		Data:    nil,
+	})
+}
+
+func respondIssued(c *gin.Context, issued apikeys.Issued, message string) {
+	c.Header("Cache-Control", noStoreControl)
+	c.Header("Location", fmt.Sprintf("/%s/%s", apiKeysGroup, issued.ID))
+	c.JSON(http.StatusCreated, Response{
+		Status:  http.StatusCreated,
+		Message: message,
+		Data:    issued,
+	})
+}
+
+// apiKeyError maps the errors of the API key service to the codes of the problem details.
+func apiKeyError(err error) error {
+	switch {
+	case errors.Is(err, apikeys.ErrKeyNotFound):
+		return requestError{code: designpatters.CodeNotFound, error: err}
+	case errors.Is(err, apikeys.ErrInvalidID):
+		return requestError{code: designpatters.CodeInvalidID, error: err}
+	case errors.Is(err, apikeys.ErrMissingName), errors.Is(err, apikeys.ErrNameTooLong),
+		errors.Is(err, apikeys.ErrMissingScopes), errors.Is(err, apikeys.ErrInvalidScope),
+		errors.Is(err, apikeys.ErrPastExpiry):
+		return badRequestError(err)
+	case errors.Is(err, apikeys.ErrKeyRevoked), errors.Is(err, apikeys.ErrKeyExpired):
+		return requestError{code: designpatters.CodeConflict, error: err}
+	case errors.Is(err, apikeys.ErrUnavailable):
+		return designpatters.ErrUnavailable
+	}
+
+	return err
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/apikeys"
+)
+
+const someAPIKeyID = "5f9f1c5b9b9b9b9b9b9b9b9b"
+
+type apiKeyServiceMock struct{}
+
+func (m *apiKeyServiceMock) List(_ context.Context) ([]apikeys.Key, error) {
+	return []apikeys.Key{someAPIKey("static-site")}, nil
+}
+
+func (m *apiKeyServiceMock) Issue(_ context.Context, params apikeys.IssueParams) (apikeys.Issued, error) {
+	if params.Name == "" {
+		return apikeys.Issued{}, apikeys.ErrMissingName
+	}
+	if len(params.Scopes) == 0 {
+		return apikeys.Issued{}, apikeys.ErrMissingScopes
+	}
+
+	return apikeys.Issued{Key: someAPIKey(params.Name), Secret: "sak_some-secret"}, nil
+}
+
+func (m *apiKeyServiceMock) Rotate(_ context.Context, id string) (apikeys.Issued, error) {
+	switch id {
+	case someAPIKeyID:
+		return apikeys.Issued{Key: someAPIKey("static-site"), Secret: "sak_some-secret"}, nil
+	case "revoked":
+		return apikeys.Issued{}, apikeys.ErrKeyRevoked
+	default:
+		return apikeys.Issued{}, apikeys.ErrInvalidID
+	}
+}
+
+func (m *apiKeyServiceMock) Revoke(_ context.Context, id string) error {
+	switch id {
+	case someAPIKeyID:
+		return nil
+	case "unavailable":
+		return apikeys.ErrUnavailable
+	default:
+		return apikeys.ErrKeyNotFound
+	}
+}
+
+func someAPIKey(name string) apikeys.Key {
+	return apikeys.Key{
+		ID:        someAPIKeyID,
+		Name:      name,
+		Prefix:    "sak_some-sec",
+		Scopes:    []string{"read:drafts"},
+		CreatedBy: "ada",
+		CreatedAt: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAPIKeysHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		method               string
+		path                 string
+		body                 string
+		authorization        string
+		expectedStatus       int
+		expectedCacheControl string
+		expectedResponse     string
+	}{
+		{
+			name:             "Ok - List API keys",
+			method:           http.MethodGet,
+			authorization:    "Bearer admin",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"5f9f1c5b9b9b9b9b9b9b9b9b","name":"static-site","prefix":"sak_some-sec","scopes":["read:drafts"],"createdBy":"ada","createdAt":"2022-11-01T00:00:00Z"}]}`,
+		},
+		{
+			name:                 "Created - Issue API key",
+			method:               http.MethodPost,
+			body:                 `{"name":"ci","scopes":["read:drafts"]}`,
+			authorization:        "Bearer admin",
+			expectedStatus:       201,
+			expectedCacheControl: "no-store",
+			expectedResponse:     `{"status":201,"message":"API key issued, store its secret as it won't be shown again","data":{"id":"5f9f1c5b9b9b9b9b9b9b9b9b","name":"ci","prefix":"sak_some-sec","scopes":["read:drafts"],"createdBy":"ada","createdAt":"2022-11-01T00:00:00Z","secret":"sak_some-secret"}}`,
+		},
+		{
+			name:             "Bad Request - Issue API key without scopes",
+			method:           http.MethodPost,
+			body:             `{"name":"ci"}`,
+			authorization:    "Bearer admin",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"API key scopes are required","instance":"/apikeys","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Bad Request - Issue API key with invalid body",
+			method:           http.MethodPost,
+			body:             `{"name":`,
+			authorization:    "Bearer admin",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"unexpected EOF","instance":"/apikeys","code":"invalid_argument"}`,
+		},
+		{
+			name:                 "Created - Rotate API key",
+			method:               http.MethodPost,
+			path:                 "/" + someAPIKeyID + "/rotate",
+			authorization:        "Bearer admin",
+			expectedStatus:       201,
+			expectedCacheControl: "no-store",
+			expectedResponse:     `{"status":201,"message":"API key rotated, store its secret as it won't be shown again","data":{"id":"5f9f1c5b9b9b9b9b9b9b9b9b","name":"static-site","prefix":"sak_some-sec","scopes":["read:drafts"],"createdBy":"ada","createdAt":"2022-11-01T00:00:00Z","secret":"sak_some-secret"}}`,
+		},
+		{
+			name:             "Conflict - Rotate revoked API key",
+			method:           http.MethodPost,
+			path:             "/revoked/rotate",
+			authorization:    "Bearer admin",
+			expectedStatus:   409,
+			expectedResponse: `{"type":"about:blank","title":"Conflict","status":409,"detail":"API key is revoked","instance":"/apikeys/revoked/rotate","code":"conflict"}`,
+		},
+		{
+			name:             "Ok - Revoke API key",
+			method:           http.MethodDelete,
+			path:             "/" + someAPIKeyID,
+			authorization:    "Bearer admin",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"API key revoked","data":null}`,
+		},
+		{
+			name:             "Not Found - Revoke API key",
+			method:           http.MethodDelete,
+			path:             "/5f9f1c5b9b9b9b9b9b9b9b00",
+			authorization:    "Bearer admin",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"API key not found","instance":"/apikeys/5f9f1c5b9b9b9b9b9b9b9b00","code":"not_found"}`,
+		},
+		{
+			name:             "Service Unavailable - Revoke API key",
+			method:           http.MethodDelete,
+			path:             "/unavailable",
+			authorization:    "Bearer admin",
+			expectedStatus:   503,
+			expectedResponse: `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"Service temporarily unavailable","instance":"/apikeys/unavailable","code":"unavailable"}`,
+		},
+		{
+			name:             "Forbidden - Issue API key as editor",
+			method:           http.MethodPost,
+			body:             `{"name":"ci","scopes":["read:drafts"]}`,
+			authorization:    "Bearer editor",
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission manage:apikeys","instance":"/apikeys","code":"forbidden","permission":"manage:apikeys"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = APIKeyRoutes(app, &apiKeyServiceMock{}, testGuard(t))
+
+			r, err := http.NewRequest(tt.method, fmt.Sprintf("/%s%s", apiKeysGroup, tt.path), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			r.Header.Set("Authorization", tt.authorization)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedCacheControl, resp.Header.Get("Cache-Control"))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
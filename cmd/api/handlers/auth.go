@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/apikeys"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+)
+
+const (
+	apiKeyPrefix = "ApiKey "
+
+	// authenticationKey keeps the authentication of a request in its gin context, so its
+	// credentials are verified once even when several middlewares need its principal.
+	authenticationKey = "authentication"
+)
+
+var (
+	errMissingToken = requestError{
+		code:  codeUnauthenticated,
+		error: errors.New("Authentication required, send a bearer token"),
+	}
+	errInvalidToken = requestError{
+		code:  codeUnauthenticated,
+		error: errors.New("Invalid bearer token"),
+	}
+	errTokenExpired = requestError{
+		code:  codeUnauthenticated,
+		error: errors.New("Bearer token expired"),
+	}
+	errInvalidAPIKey = requestError{
+		code:  codeUnauthenticated,
+		error: apikeys.ErrInvalidKey,
+	}
+	errAPIKeyExpired = requestError{
+		code:  codeUnauthenticated,
+		error: apikeys.ErrKeyExpired,
+	}
+)
+
+// authentication is the outcome of authenticating a request: its principal, or the error to
+// respond with, along with the challenge of the WWW-Authenticate header and why credentials
+// were rejected, which is only logged.
+type authentication struct {
+	principal auth.Principal
+	err       error
+	challenge string
+	cause     error
+}
+
+// permissionError is returned when the principal of a request lacks the permission an action
+// requires.
+type permissionError struct {
+	permission auth.Permission
+}
+
+func (e permissionError) Error() string {
+	return fmt.Sprintf("Missing permission %s", e.permission)
+}
+
+func forbiddenError(permission auth.Permission) error {
+	return requestError{code: codeForbidden, error: permissionError{permission: permission}}
+}
+
+// TokenVerifier verifies bearer tokens and returns their claims.
+type TokenVerifier interface {
+	Verify(token string) (auth.Claims, error)
+}
+
+// KeyAuthenticator authenticates API keys and returns the principal they were issued as.
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (auth.Principal, error)
+}
+
+// PermissionPolicy tells whether a principal has a permission.
+type PermissionPolicy interface {
+	Allows(principal auth.Principal, permission auth.Permission) bool
+}
+
+// Guard authenticates the requests of the routes that change content and checks that their
+// principal has the permission each route requires. A nil Guard lets every request through,
+// for deployments without authentication.
+type Guard struct {
+	verifier TokenVerifier
+	policy   PermissionPolicy
+	keys     KeyAuthenticator
+}
+
+// GuardOption configures a Guard.
+type GuardOption func(*Guard)
+
+// AcceptAPIKeys makes the Guard authenticate requests sent with an Authorization: ApiKey
+// header with keys, besides bearer tokens.
+func AcceptAPIKeys(keys KeyAuthenticator) GuardOption {
+	return func(g *Guard) {
+		g.keys = keys
+	}
+}
+
+// NewGuard creates a Guard that authenticates bearer tokens with verifier and checks
+// permissions with policy.
+func NewGuard(verifier TokenVerifier, policy PermissionPolicy, opts ...GuardOption) *Guard {
+	g := &Guard{verifier: verifier, policy: policy}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Require makes the routes it guards require an authenticated principal with permission, or
+// just an authenticated one when permission is empty. The principal is carried in the
+// request context, so changes are recorded as made by its subject.
+func (g *Guard) Require(permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if g == nil {
+			c.Next()
+			return
+		}
+
+		result := g.authenticate(c)
+		if result.err != nil {
+			result.reject(c)
+			return
+		}
+
+		if permission != "" && !g.policy.Allows(result.principal, permission) {
+			respondError(c, forbiddenError(permission))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), result.principal))
+		c.Next()
+	}
+}
+
+// Allows reports whether the request is authenticated as a principal with permission. It
+// doesn't require authentication, so reads can check optional permissions such as seeing
+// drafts. Without a Guard nobody is authenticated, so it reports false.
+func (g *Guard) Allows(c *gin.Context, permission auth.Permission) bool {
+	if g == nil {
+		return false
+	}
+
+	result := g.authenticate(c)
+	return result.err == nil && g.policy.Allows(result.principal, permission)
+}
+
+// clientKey identifies the client of a request to rate limit it: the principal it is
+// authenticated as, so each API key has its own limit, or its IP address when it isn't.
+// invalidKey reports whether the request was rejected for an invalid or expired API key.
+func (g *Guard) clientKey(c *gin.Context) (key string, invalidKey bool) {
+	if g != nil && c.GetHeader(authorizationHeader) != "" {
+		result := g.authenticate(c)
+		if result.err == nil {
+			return "principal:" + result.principal.Subject, false
+		}
+		invalidKey = result.err == errInvalidAPIKey || result.err == errAPIKeyExpired
+	}
+
+	return "ip:" + c.ClientIP(), invalidKey
+}
+
+// sendsAPIKey reports whether the request authenticates with an API key, which is looked up
+// in the store.
+func (g *Guard) sendsAPIKey(c *gin.Context) bool {
+	return g != nil && g.keys != nil && strings.HasPrefix(c.GetHeader(authorizationHeader), apiKeyPrefix)
+}
+
+// authorizeTransition returns the check of the permission moving a Design Pattern between
+// two statuses requires: reviewers approve what is in review, admins publish and unpublish
+// anything else and editors move drafts in and out of review. Scheduling a publication
+// approves it in advance, since the scheduler publishes it unchecked, so transitions that
+// are scheduled require approval too. It returns nil when there is no Guard, so every
+// transition is allowed.
+func (g *Guard) authorizeTransition(c *gin.Context, scheduled bool) func(from, to designpatters.Status) error {
+	if g == nil {
+		return nil
+	}
+
+	return func(from, to designpatters.Status) error {
+		principal, _ := auth.PrincipalFrom(c.Request.Context())
+
+		permission := auth.UpdatePatterns
+		switch {
+		case to == designpatters.StatusPublished, scheduled:
+			permission = auth.ApprovePatterns
+		case from == designpatters.StatusPublished, to == designpatters.StatusArchived:
+			permission = auth.PublishPatterns
+		}
+
+		if !g.policy.Allows(principal, permission) {
+			return forbiddenError(permission)
+		}
+
+		return nil
+	}
+}
+
+// authenticate returns the outcome of authenticating the request, verifying its credentials
+// only the first time.
+func (g *Guard) authenticate(c *gin.Context) authentication {
+	if cached, ok := c.Get(authenticationKey); ok {
+		return cached.(authentication)
+	}
+
+	result := g.verify(c)
+	c.Set(authenticationKey, result)
+	return result
+}
+
+func (g *Guard) verify(c *gin.Context) authentication {
+	if secret, ok := strings.CutPrefix(c.GetHeader(authorizationHeader), apiKeyPrefix); ok && g.keys != nil {
+		return g.verifyKey(c, strings.TrimSpace(secret))
+	}
+
+	token, ok := strings.CutPrefix(c.GetHeader(authorizationHeader), bearerPrefix)
+	if !ok || strings.TrimSpace(token) == "" {
+		return authentication{err: errMissingToken, challenge: "Bearer"}
+	}
+
+	claims, err := g.verifier.Verify(strings.TrimSpace(token))
+	if err != nil {
+		// Why the token was rejected is only logged, clients get a generic reason.
+		result := authentication{err: errInvalidToken, challenge: `Bearer error="invalid_token"`, cause: err}
+		if errors.Is(err, auth.ErrTokenExpired) {
+			result.err = errTokenExpired
+		}
+		return result
+	}
+
+	return authentication{principal: auth.Principal{Subject: claims.Subject, Roles: claims.Roles}}
+}
+
+func (g *Guard) verifyKey(c *gin.Context, secret string) authentication {
+	principal, err := g.keys.Authenticate(c.Request.Context(), secret)
+	switch {
+	case err == nil:
+		return authentication{principal: principal}
+	case errors.Is(err, apikeys.ErrInvalidKey):
+		return authentication{err: errInvalidAPIKey, challenge: `ApiKey error="invalid_key"`}
+	case errors.Is(err, apikeys.ErrKeyExpired):
+		return authentication{err: errAPIKeyExpired, challenge: `ApiKey error="invalid_key"`}
+	}
+
+	return authentication{err: apiKeyError(err)}
+}
+
+// reject responds to a request whose credentials were rejected.
+func (result authentication) reject(c *gin.Context) {
+	if result.cause != nil {
+		c.Error(result.cause)
+	}
+	if result.challenge != "" {
+		c.Header(wwwAuthenticateHeader, result.challenge)
+	}
+
+	respondError(c, result.err)
+	c.Abort()
+}
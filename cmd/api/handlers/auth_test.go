@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/apikeys"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"github.com/waydevs/sections-api/internal/sections"
+)
+
+// tokenVerifierMock accepts a token named after a role as ada with that role, and the token
+// "expired" as expired.
+type tokenVerifierMock struct{}
+
+func (v tokenVerifierMock) Verify(token string) (auth.Claims, error) {
+	switch token {
+	case "reader", "editor", "reviewer", "admin":
+		return auth.Claims{Subject: "ada", Roles: []string{token}, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	case "expired":
+		return auth.Claims{}, auth.ErrTokenExpired
+	default:
+		return auth.Claims{}, fmt.Errorf("%w: signature mismatch", auth.ErrInvalidToken)
+	}
+}
+
+// keyAuthenticatorMock accepts the key "sak_drafts" as a key with the read:drafts scope and
+// the key "sak_expired" as expired.
+type keyAuthenticatorMock struct{}
+
+func (k keyAuthenticatorMock) Authenticate(_ context.Context, secret string) (auth.Principal, error) {
+	switch secret {
+	case "sak_drafts":
+		return auth.Principal{Subject: "apikey:" + someAPIKeyID, Permissions: []auth.Permission{auth.ReadDrafts}}, nil
+	case "sak_expired":
+		return auth.Principal{}, apikeys.ErrKeyExpired
+	case "sak_unavailable":
+		return auth.Principal{}, apikeys.ErrUnavailable
+	default:
+		return auth.Principal{}, apikeys.ErrInvalidKey
+	}
+}
+
+func testGuard(t *testing.T) *Guard {
+	policy, err := auth.NewPolicy(map[string][]string{
+		"reader":   {},
+		"editor":   {"read:drafts", "create:patterns", "update:patterns", "delete:patterns", "write:sections", "upload:media"},
+		"reviewer": {"read:drafts", "approve:patterns"},
+		"admin":    {"*"},
+	})
+	require.NoError(t, err)
+
+	return NewGuard(tokenVerifierMock{}, policy, AcceptAPIKeys(keyAuthenticatorMock{}))
+}
+
+func TestDesignPatternsHandler_Guard(t *testing.T) {
+	tests := []struct {
+		name                    string
+		method                  string
+		path                    string
+		body                    string
+		headers                 map[string]string
+		expectedStatus          int
+		expectedWWWAuthenticate string
+		expectedResponse        string
+	}{
+		{
+			name:             "Ok - Reads are public",
+			method:           http.MethodGet,
+			path:             "/by-slug/singleton",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"singleton","slugAliases":["old-singleton"],"title":"Singleton","subtitle":"","contentData":null,"version":2,"status":""}}`,
+		},
+		{
+			name:             "Ok - Change made by the subject of the token",
+			method:           http.MethodPost,
+			path:             "/ok/revisions/1/rollback",
+			headers:          map[string]string{"Authorization": "Bearer editor", "X-Author": "someone-else", "X-Change-Note": "Undo"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"Design pattern rolled back to revision 1","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"ada: Undo","contentData":null,"version":1,"status":""}}`,
+		},
+		{
+			name:             "Ok - Drafts read with permission",
+			method:           http.MethodGet,
+			path:             "/draft?drafts=true",
+			headers:          map[string]string{"Authorization": "Bearer reviewer"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Draft","subtitle":"","contentData":null,"version":1,"status":"draft"}}`,
+		},
+		{
+			name:                    "Unauthorized - Drafts read without permission",
+			method:                  http.MethodGet,
+			path:                    "/draft?drafts=true",
+			headers:                 map[string]string{"Authorization": "Bearer reader"},
+			expectedStatus:          401,
+			expectedWWWAuthenticate: "Bearer",
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Drafts are only visible to editors, send a token allowed to read drafts","instance":"/designpatters/draft","code":"unauthenticated"}`,
+		},
+		{
+			name:             "Ok - Revisions of drafts read with permission",
+			method:           http.MethodGet,
+			path:             "/draft/revisions?drafts=true",
+			headers:          map[string]string{"Authorization": "Bearer reviewer"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":null,"meta":{"page":1,"limit":20,"total":0,"totalPages":0}}`,
+		},
+		{
+			name:             "Ok - Drafts read with an API key",
+			method:           http.MethodGet,
+			path:             "/draft?drafts=true",
+			headers:          map[string]string{"Authorization": "ApiKey sak_drafts"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Draft","subtitle":"","contentData":null,"version":1,"status":"draft"}}`,
+		},
+		{
+			name:             "Forbidden - API key without scope",
+			method:           http.MethodDelete,
+			path:             "/ok",
+			headers:          map[string]string{"Authorization": "ApiKey sak_drafts"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission delete:patterns","instance":"/designpatters/ok","code":"forbidden","permission":"delete:patterns"}`,
+		},
+		{
+			name:                    "Unauthorized - Invalid API key",
+			method:                  http.MethodPost,
+			path:                    "",
+			headers:                 map[string]string{"Authorization": "ApiKey sak_forged"},
+			expectedStatus:          401,
+			expectedWWWAuthenticate: `ApiKey error="invalid_key"`,
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Invalid API key","instance":"/designpatters","code":"unauthenticated"}`,
+		},
+		{
+			name:                    "Unauthorized - Expired API key",
+			method:                  http.MethodPost,
+			path:                    "",
+			headers:                 map[string]string{"Authorization": "ApiKey sak_expired"},
+			expectedStatus:          401,
+			expectedWWWAuthenticate: `ApiKey error="invalid_key"`,
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"API key expired","instance":"/designpatters","code":"unauthenticated"}`,
+		},
+		{
+			name:             "Service Unavailable - API key",
+			method:           http.MethodPost,
+			path:             "",
+			headers:          map[string]string{"Authorization": "ApiKey sak_unavailable"},
+			expectedStatus:   503,
+			expectedResponse: `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"Service temporarily unavailable","instance":"/designpatters","code":"unavailable"}`,
+		},
+		{
+			name:                    "Unauthorized - Missing token",
+			method:                  http.MethodDelete,
+			path:                    "/ok",
+			expectedStatus:          401,
+			expectedWWWAuthenticate: "Bearer",
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Authentication required, send a bearer token","instance":"/designpatters/ok","code":"unauthenticated"}`,
+		},
+		{
+			name:                    "Unauthorized - Invalid token",
+			method:                  http.MethodPut,
+			path:                    "/ok/status",
+			headers:                 map[string]string{"Authorization": "Bearer forged"},
+			expectedStatus:          401,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Invalid bearer token","instance":"/designpatters/ok/status","code":"unauthenticated"}`,
+		},
+		{
+			name:                    "Unauthorized - Expired token",
+			method:                  http.MethodPost,
+			path:                    "",
+			headers:                 map[string]string{"Authorization": "Bearer expired"},
+			expectedStatus:          401,
+			expectedWWWAuthenticate: `Bearer error="invalid_token"`,
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Bearer token expired","instance":"/designpatters","code":"unauthenticated"}`,
+		},
+		{
+			name:             "Forbidden - Reader creates",
+			method:           http.MethodPost,
+			path:             "",
+			headers:          map[string]string{"Authorization": "Bearer reader"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission create:patterns","instance":"/designpatters","code":"forbidden","permission":"create:patterns"}`,
+		},
+		{
+			name:                    "Unauthorized - Trash read without token",
+			method:                  http.MethodGet,
+			path:                    "/trash",
+			expectedStatus:          401,
+			expectedWWWAuthenticate: "Bearer",
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Authentication required, send a bearer token","instance":"/designpatters/trash","code":"unauthenticated"}`,
+		},
+		{
+			name:             "Forbidden - Reader reads the trash",
+			method:           http.MethodGet,
+			path:             "/trash",
+			headers:          map[string]string{"Authorization": "Bearer reader"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission delete:patterns","instance":"/designpatters/trash","code":"forbidden","permission":"delete:patterns"}`,
+		},
+		{
+			name:                    "Unauthorized - Cache stats read without token",
+			method:                  http.MethodGet,
+			path:                    "/cache",
+			expectedStatus:          401,
+			expectedWWWAuthenticate: "Bearer",
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Authentication required, send a bearer token","instance":"/designpatters/cache","code":"unauthenticated"}`,
+		},
+		{
+			name:             "Forbidden - Editor reads the cache stats",
+			method:           http.MethodGet,
+			path:             "/cache",
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission read:stats","instance":"/designpatters/cache","code":"forbidden","permission":"read:stats"}`,
+		},
+		{
+			name:             "Ok - Admin reads the cache stats",
+			method:           http.MethodGet,
+			path:             "/cache",
+			headers:          map[string]string{"Authorization": "Bearer admin"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"hits":40,"misses":2,"coalesced":1,"entries":2}}`,
+		},
+		{
+			name:             "Ok - Editor reads the trash",
+			method:           http.MethodGet,
+			path:             "/trash",
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":0,"deletedAt":"2023-01-02T03:04:05Z","status":""}],"meta":{"page":1,"limit":20,"total":1,"totalPages":1}}`,
+		},
+		{
+			name:             "Forbidden - Editor purges",
+			method:           http.MethodDelete,
+			path:             "/trash/ok",
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission purge:patterns","instance":"/designpatters/trash/ok","code":"forbidden","permission":"purge:patterns"}`,
+		},
+		{
+			name:             "Ok - Admin purges",
+			method:           http.MethodDelete,
+			path:             "/trash/ok",
+			headers:          map[string]string{"Authorization": "Bearer admin"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"Design pattern purged permanently","data":null}`,
+		},
+		{
+			name:             "Ok - Reviewer approves",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"published"}`,
+			headers:          map[string]string{"Authorization": "Bearer reviewer"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":4,"status":"published"}}`,
+		},
+		{
+			name:             "Forbidden - Editor approves",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"published"}`,
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission approve:patterns","instance":"/designpatters/ok/status","code":"forbidden","permission":"approve:patterns"}`,
+		},
+		{
+			name:             "Ok - Editor submits for review",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"in_review"}`,
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":4,"status":"in_review"}}`,
+		},
+		{
+			name:             "Forbidden - Editor schedules publication",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"in_review","publishAt":"2099-01-02T03:04:05Z"}`,
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission approve:patterns","instance":"/designpatters/ok/status","code":"forbidden","permission":"approve:patterns"}`,
+		},
+		{
+			name:             "Ok - Reviewer schedules publication",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"in_review","publishAt":"2099-01-02T03:04:05Z"}`,
+			headers:          map[string]string{"Authorization": "Bearer reviewer"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":4,"status":"in_review","publishAt":"2099-01-02T03:04:05Z"}}`,
+		},
+		{
+			name:             "Ok - Editor withdraws from review",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"draft"}`,
+			headers:          map[string]string{"Authorization": "Bearer editor"},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":4,"status":"draft"}}`,
+		},
+		{
+			name:             "Forbidden - Reviewer archives",
+			method:           http.MethodPut,
+			path:             "/ok/status",
+			body:             `{"status":"archived"}`,
+			headers:          map[string]string{"Authorization": "Bearer reviewer"},
+			expectedStatus:   403,
+			expectedResponse: `{"type":"about:blank","title":"Forbidden","status":403,"detail":"Missing permission publish:patterns","instance":"/designpatters/ok/status","code":"forbidden","permission":"publish:patterns"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{}, Guarded(testGuard(t)))
+
+			r, err := http.NewRequest(tt.method, fmt.Sprintf("/%s%s", designPattersGroup, tt.path), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedWWWAuthenticate, resp.Header.Get(wwwAuthenticateHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSectionRoutes_Guard(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "Unauthorized - Create section", method: http.MethodPost, expectedStatus: 401},
+		{name: "Forbidden - Update section", method: http.MethodPut, authorization: "Bearer reviewer", expectedStatus: 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = SectionRoutes(app, testGuard(t), &sectionServiceMock{kind: sections.Algorithms})
+
+			r, err := http.NewRequest(tt.method, "/algorithms", nil)
+			require.NoError(t, err)
+			r.Header.Set("Authorization", tt.authorization)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCacheStats returns how many reads of design patterns and lists the cache served, how
+// many it missed and how many misses waited for a concurrent read, along with how many
+// entries it keeps.
+func (s DesignPatternsHandler) GetCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    s.service.CacheStats(),
+	})
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesignPatternsHandler_GetCacheStats(t *testing.T) {
+	app := gin.Default()
+	app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+	r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/cache", designPattersGroup), nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, r)
+
+	resp := rr.Result()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `{"status":200,"message":"","data":{"hits":40,"misses":2,"coalesced":1,"entries":2}}`, string(body))
+
+	err = resp.Body.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/platform/configs"
+)
+
+// exposedHeaders are the response headers browsers let cross-origin clients read.
+var exposedHeaders = strings.Join([]string{
+	etagHeader,
+	requestIDHeader,
+	rateLimitLimitHeader,
+	rateLimitRemainingHeader,
+	rateLimitResetHeader,
+	retryAfterHeader,
+}, ", ")
+
+// CORS returns a middleware that adds the Cross-Origin Resource Sharing headers for the
+// configured origins and answers preflight requests. It does nothing when no origins are
+// allowed.
+func CORS(cfg configs.CORSConfig) gin.HandlerFunc {
+	allowAny := false
+	allowedOrigins := map[string]bool{}
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+		}
+		allowedOrigins[origin] = true
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || (!allowAny && !allowedOrigins[origin]) {
+			c.Next()
+			return
+		}
+
+		c.Header("Vary", "Origin")
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", exposedHeaders)
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/configs"
+)
+
+func TestCORS(t *testing.T) {
+	cfg := configs.CORSConfig{
+		AllowedOrigins: []string{"https://waydevs.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         time.Hour,
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		preflight       bool
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "Allowed origin",
+			method:         http.MethodGet,
+			origin:         "https://waydevs.com",
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "https://waydevs.com",
+				"Access-Control-Allow-Methods":  "",
+				"Access-Control-Expose-Headers": "ETag, X-Request-ID, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After",
+			},
+		},
+		{
+			name:           "Disallowed origin",
+			method:         http.MethodGet,
+			origin:         "https://evil.com",
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		{
+			name:           "Preflight",
+			method:         http.MethodOptions,
+			origin:         "https://waydevs.com",
+			preflight:      true,
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://waydevs.com",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Content-Type",
+				"Access-Control-Max-Age":       "3600",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.New()
+			app.Use(CORS(cfg))
+			app.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			r, err := http.NewRequest(tt.method, "/ping", nil)
+			require.NoError(t, err)
+			r.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			for header, value := range tt.expectedHeaders {
+				require.Equal(t, value, rr.Header().Get(header), header)
+			}
+		})
+	}
+}
@@ -1,15 +1,33 @@
 package handlers
 
 import (
-	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/ratelimit"
 )
 
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+	acceptPatch           = mergePatchContentType + ", " + jsonPatchContentType
+)
+
+var patchTypes = map[string]designpatters.PatchType{
+	mergePatchContentType: designpatters.MergePatch,
+	jsonPatchContentType:  designpatters.JSONPatch,
+}
+
 type DesignPatternsHandler struct {
-	service DesignPatternService
+	service        DesignPatternService
+	requireIfMatch bool
+	guard          *Guard
+	readLimiter    *ratelimit.Limiter
+	writeLimiter   *ratelimit.Limiter
 }
 
 func NewDesignPatternsHandler(service DesignPatternService) DesignPatternsHandler {
@@ -22,23 +40,21 @@ func (s DesignPatternsHandler) GetPatternByID(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param(desingPatternIDParam)
 
-	response, err := s.service.GetByID(ctx, id)
-
+	opts, err := s.readOptions(c)
 	if err != nil {
-		httpCode := http.StatusInternalServerError
+		respondError(c, err)
+		return
+	}
 
-		if errors.Is(err, designpatters.ErrDesignPatternNotFound) {
-			httpCode = http.StatusNotFound
-		}
+	response, err := s.service.GetByID(ctx, id, opts)
 
-		c.JSON(httpCode, Response{
-			Status:  httpCode,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
+	c.Header(contentLanguageHeader, response.Locale)
+	c.Header(etagHeader, etag(response.Version))
 	c.JSON(http.StatusOK, Response{
 		Status:  http.StatusOK,
 		Message: "",
@@ -46,30 +62,86 @@ func (s DesignPatternsHandler) GetPatternByID(c *gin.Context) {
 	})
 }
 
-func (s DesignPatternsHandler) CreatePattern(c *gin.Context) {
+func (s DesignPatternsHandler) ListPatterns(c *gin.Context) {
 	ctx := c.Request.Context()
 
+	params, err := listParamsFromQuery(c)
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	params.IncludeDrafts = opts.IncludeDrafts
+	params.Locales = opts.Locales
+	params.RenderHTML = opts.RenderHTML
+
+	result, err := s.service.List(ctx, params)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    result.Items,
+		Meta:    newMeta(result.Page, result.Limit, result.Total),
+	})
+}
+
+func (s DesignPatternsHandler) SearchPatterns(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	c.Writer.Header().Add(varyHeader, acceptLanguageHeader)
+	params := designpatters.SearchParams{Query: c.Query("q"), Locales: requestLocales(c)}
+
+	var err error
+	if params.Page, err = intQuery(c, "page"); err == nil {
+		params.Limit, err = intQuery(c, "limit")
+	}
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	result, err := s.service.Search(ctx, params)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    result.Items,
+		Meta:    newMeta(result.Page, result.Limit, result.Total),
+	})
+}
+
+func (s DesignPatternsHandler) CreatePattern(c *gin.Context) {
+	ctx := changeContext(c)
+
 	var request designpatters.DesignPattern
-	if err := c.BindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
 		return
 	}
 
 	response, err := s.service.Create(ctx, request)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			Status:  http.StatusInternalServerError,
-			Message: err.Error(),
-			Data:    nil,
-		})
+		respondError(c, err)
 		return
 	}
 
+	c.Header(etagHeader, etag(response.Version))
 	c.JSON(http.StatusCreated, Response{
 		Status:  http.StatusCreated,
 		Message: "",
@@ -81,20 +153,16 @@ func (s DesignPatternsHandler) DeletePattern(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param(desingPatternIDParam)
 
-	err := s.service.Delete(ctx, id)
-
+	version, err := ifMatchVersion(c, s.requireIfMatch)
 	if err != nil {
-		httpCode := http.StatusInternalServerError
+		respondError(c, err)
+		return
+	}
 
-		if errors.Is(err, designpatters.ErrDesignPatternNotFound) {
-			httpCode = http.StatusNotFound
-		}
+	err = s.service.Delete(ctx, id, version)
 
-		c.JSON(httpCode, Response{
-			Status:  httpCode,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -106,38 +174,199 @@ func (s DesignPatternsHandler) DeletePattern(c *gin.Context) {
 }
 
 func (s DesignPatternsHandler) UpdatePattern(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx := changeContext(c)
+
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
 	var request designpatters.DesignPattern
-	if err := c.BindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-			Data:    nil,
-		})
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
 		return
 	}
+	request.Version = version
 
 	response, err := s.service.Update(ctx, request)
 
 	if err != nil {
-		httpCode := http.StatusInternalServerError
+		respondError(c, err)
+		return
+	}
 
-		if errors.Is(err, designpatters.ErrDesignPatternNotFound) {
-			httpCode = http.StatusNotFound
-		}
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+// PatchPattern applies a JSON Merge Patch or a JSON Patch, chosen by the Content-Type of the
+// request, to a DesignPattern.
+func (s DesignPatternsHandler) PatchPattern(c *gin.Context) {
+	ctx := changeContext(c)
+	id := c.Param(desingPatternIDParam)
 
-		c.JSON(httpCode, Response{
-			Status:  httpCode,
-			Message: err.Error(),
-			Data:    nil,
+	patchType, ok := patchTypes[c.ContentType()]
+	if !ok {
+		c.Header("Accept-Patch", acceptPatch)
+		respondError(c, requestError{
+			code:  codeUnsupportedMediaType,
+			error: fmt.Errorf("Unsupported Content-Type, use one of %s", acceptPatch),
 		})
 		return
 	}
 
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	document, err := c.GetRawData()
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	response, err := s.service.Patch(ctx, id, designpatters.Patch{Type: patchType, Document: document, Version: version})
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(response.Version))
 	c.JSON(http.StatusOK, Response{
 		Status:  http.StatusOK,
 		Message: "",
 		Data:    response,
 	})
 }
+
+// ListTrash lists the design patterns in the trash, most recently deleted first.
+func (s DesignPatternsHandler) ListTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var params designpatters.TrashParams
+	var err error
+	if params.Page, err = intQuery(c, "page"); err == nil {
+		params.Limit, err = intQuery(c, "limit")
+	}
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	result, err := s.service.ListTrash(ctx, params)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    result.Items,
+		Meta:    newMeta(result.Page, result.Limit, result.Total),
+	})
+}
+
+// RestorePattern takes a design pattern out of the trash.
+func (s DesignPatternsHandler) RestorePattern(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	response, err := s.service.Restore(ctx, id)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "Design pattern restored successfully",
+		Data:    response,
+	})
+}
+
+// PurgePattern permanently removes a design pattern in the trash. It is meant for admins
+// that can't wait for the retention period to expire.
+func (s DesignPatternsHandler) PurgePattern(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	err := s.service.Purge(ctx, id)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "Design pattern purged permanently",
+		Data:    nil,
+	})
+}
+
+func listParamsFromQuery(c *gin.Context) (designpatters.ListParams, error) {
+	params := designpatters.ListParams{
+		Sort:     c.Query("sort"),
+		Title:    c.Query("title"),
+		Subtitle: c.Query("subtitle"),
+		Category: designpatters.Category(c.Query("category")),
+		AnyTags:  listQuery(c, "anyTags"),
+		AllTags:  listQuery(c, "tags"),
+	}
+
+	var err error
+	if params.Page, err = intQuery(c, "page"); err != nil {
+		return designpatters.ListParams{}, err
+	}
+	if params.Limit, err = intQuery(c, "limit"); err != nil {
+		return designpatters.ListParams{}, err
+	}
+	if params.CreatedAfter, err = timeQuery(c, "createdAfter"); err != nil {
+		return designpatters.ListParams{}, err
+	}
+	if params.CreatedBefore, err = timeQuery(c, "createdBefore"); err != nil {
+		return designpatters.ListParams{}, err
+	}
+
+	return params, nil
+}
+
+func intQuery(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("Invalid %s, it must be a non-negative integer", key)
+	}
+
+	return number, nil
+}
+
+func timeQuery(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid %s, it must be an RFC 3339 timestamp", key)
+	}
+
+	return t, nil
+}
@@ -10,42 +10,142 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/repository"
 )
 
 type designPatternServiceMock struct{}
 
-func (s *designPatternServiceMock) GetByID(ctx context.Context, id string) (designpatters.DesignPattern, error) {
+func (s *designPatternServiceMock) GetByID(ctx context.Context, id string, opts designpatters.ReadOptions) (designpatters.DesignPattern, error) {
 	switch id {
 	case "ok":
 		return designpatters.DesignPattern{
-			Title: "Design Pattern",
+			Title:   "Design Pattern",
+			Version: 3,
+		}, nil
+
+	case "draft":
+		if !opts.IncludeDrafts {
+			return designpatters.DesignPattern{}, designpatters.ErrDesignPatternNotFound
+		}
+		return designpatters.DesignPattern{
+			Title:   "Draft",
+			Version: 1,
+			Status:  designpatters.StatusDraft,
 		}, nil
 
+	case "translated":
+		for _, locale := range opts.Locales {
+			if locale == "en" {
+				return designpatters.DesignPattern{Title: "Singleton", Version: 2, Locale: "en"}, nil
+			}
+		}
+		return designpatters.DesignPattern{Title: "Único", Version: 2, Locale: "es"}, nil
+
+	case "rendered":
+		return renderedDesignPattern(opts.RenderHTML), nil
+
 	case "not_found":
 		return designpatters.DesignPattern{}, designpatters.ErrDesignPatternNotFound
 
+	case "invalid_id":
+		return designpatters.DesignPattern{}, designpatters.ErrInvalidID
+
+	case "unavailable":
+		return designpatters.DesignPattern{}, designpatters.ErrUnavailable
+
 	default:
 		return designpatters.DesignPattern{}, errors.New("unexpected error")
 	}
 }
 
+func (s *designPatternServiceMock) List(ctx context.Context, params designpatters.ListParams) (designpatters.ListResult, error) {
+	switch params.Title {
+	case "ok":
+		title := "Design Pattern"
+		if params.IncludeDrafts {
+			title = "Draft"
+		}
+		return designpatters.ListResult{
+			Items: []designpatters.DesignPattern{
+				{Title: title},
+			},
+			Page:  params.Page,
+			Limit: params.Limit,
+			Total: 3,
+		}, nil
+	case "tagged":
+		return designpatters.ListResult{
+			Items: []designpatters.DesignPattern{
+				{Title: "Tagged", Category: params.Category, Tags: append(params.AllTags, params.AnyTags...)},
+			},
+			Page:  1,
+			Limit: 20,
+			Total: 1,
+		}, nil
+	case "rendered":
+		return designpatters.ListResult{
+			Items: []designpatters.DesignPattern{renderedDesignPattern(params.RenderHTML)},
+			Page:  1,
+			Limit: 20,
+			Total: 1,
+		}, nil
+	case "invalid_sort":
+		return designpatters.ListResult{}, designpatters.ErrInvalidSort
+	case "invalid_category":
+		return designpatters.ListResult{}, designpatters.ErrInvalidCategory
+	default:
+		return designpatters.ListResult{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) Search(ctx context.Context, params designpatters.SearchParams) (designpatters.SearchResult, error) {
+	switch params.Query {
+	case "ok":
+		return designpatters.SearchResult{
+			Items: []designpatters.SearchHit{
+				{
+					DesignPattern: designpatters.DesignPattern{Title: "Design Pattern"},
+					Score:         1.5,
+					Highlights:    []designpatters.Highlight{{Field: "title", Snippet: "<mark>Design</mark> Pattern"}},
+				},
+			},
+			Page:  1,
+			Limit: 20,
+			Total: 1,
+		}, nil
+	case "":
+		return designpatters.SearchResult{}, designpatters.ErrInvalidSearchQuery
+	default:
+		return designpatters.SearchResult{}, errors.New("unexpected error")
+	}
+}
+
 func (s *designPatternServiceMock) Create(ctx context.Context, designPattern designpatters.DesignPattern) (designpatters.DesignPattern, error) {
 	switch designPattern.Title {
 	case "ok":
 		return designpatters.DesignPattern{
 			Title: "Design Pattern",
 		}, nil
+	case "invalid":
+		return designpatters.DesignPattern{}, invalidDesignPatternError
+	case "conflict":
+		return designpatters.DesignPattern{}, designpatters.ErrConflict
 	default:
 		return designpatters.DesignPattern{}, errors.New("unexpected error")
 	}
 }
 
-func (s *designPatternServiceMock) Delete(ctx context.Context, id string) error {
+func (s *designPatternServiceMock) Delete(ctx context.Context, id string, version int64) error {
+	if version == staleVersion {
+		return designpatters.ErrVersionMismatch
+	}
+
 	switch id {
 	case "ok":
 		return nil
@@ -57,11 +157,187 @@ func (s *designPatternServiceMock) Delete(ctx context.Context, id string) error
 }
 
 func (s *designPatternServiceMock) Update(ctx context.Context, designPattern designpatters.DesignPattern) (designpatters.DesignPattern, error) {
+	if designPattern.Version == staleVersion {
+		return designpatters.DesignPattern{}, designpatters.ErrVersionMismatch
+	}
+
 	switch designPattern.Title {
 	case "ok":
 		return designpatters.DesignPattern{
-			Title: "Design Pattern",
+			Title:   "Design Pattern",
+			Version: designPattern.Version + 1,
+		}, nil
+	case "not_found":
+		return designpatters.DesignPattern{}, designpatters.ErrDesignPatternNotFound
+	case "invalid":
+		return designpatters.DesignPattern{}, invalidDesignPatternError
+	default:
+		return designpatters.DesignPattern{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) Patch(ctx context.Context, id string, patch designpatters.Patch) (designpatters.DesignPattern, error) {
+	if patch.Version == staleVersion {
+		return designpatters.DesignPattern{}, designpatters.ErrVersionMismatch
+	}
+
+	switch id {
+	case "ok":
+		return designpatters.DesignPattern{
+			Title:    "Design Pattern",
+			Subtitle: string(patch.Type),
+			Version:  patch.Version + 1,
+		}, nil
+	case "not_found":
+		return designpatters.DesignPattern{}, designpatters.ErrDesignPatternNotFound
+	case "invalid":
+		return designpatters.DesignPattern{}, invalidDesignPatternError
+	default:
+		return designpatters.DesignPattern{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) ListTrash(ctx context.Context, params designpatters.TrashParams) (designpatters.ListResult, error) {
+	switch params.Page {
+	case 0, 1:
+		return designpatters.ListResult{
+			Items: []designpatters.DesignPattern{
+				{Title: "Design Pattern", DeletedAt: &deletedAt},
+			},
+			Page:  1,
+			Limit: 20,
+			Total: 1,
+		}, nil
+	default:
+		return designpatters.ListResult{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) Restore(ctx context.Context, id string) (designpatters.DesignPattern, error) {
+	switch id {
+	case "ok":
+		return designpatters.DesignPattern{
+			Title:   "Design Pattern",
+			Version: 4,
 		}, nil
+	case "not_in_trash":
+		return designpatters.DesignPattern{}, designpatters.ErrNotInTrash
+	default:
+		return designpatters.DesignPattern{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) Purge(ctx context.Context, id string) error {
+	switch id {
+	case "ok":
+		return nil
+	case "not_in_trash":
+		return designpatters.ErrNotInTrash
+	default:
+		return errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) ListRevisions(ctx context.Context, id string, params designpatters.RevisionsParams, opts designpatters.ReadOptions) (designpatters.RevisionsResult, error) {
+	if id == "draft" && !opts.IncludeDrafts {
+		return designpatters.RevisionsResult{}, designpatters.ErrDesignPatternNotFound
+	}
+
+	switch id {
+	case "draft":
+		return designpatters.RevisionsResult{Page: 1, Limit: 20, Total: 0}, nil
+	case "ok":
+		return designpatters.RevisionsResult{
+			Items: []designpatters.Revision{
+				{Number: 2, Snapshot: designpatters.DesignPattern{Title: "Design Pattern", Version: 2}, Author: "ana", Note: "Typo", CreatedAt: deletedAt},
+			},
+			Page:  1,
+			Limit: 20,
+			Total: 2,
+		}, nil
+	case "invalid_id":
+		return designpatters.RevisionsResult{}, designpatters.ErrInvalidID
+	default:
+		return designpatters.RevisionsResult{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) GetRevision(ctx context.Context, id string, number int64, opts designpatters.ReadOptions) (designpatters.Revision, error) {
+	if id == "draft" && !opts.IncludeDrafts {
+		return designpatters.Revision{}, designpatters.ErrDesignPatternNotFound
+	}
+
+	switch number {
+	case 1:
+		return designpatters.Revision{Number: 1, Snapshot: designpatters.DesignPattern{Title: "Design Pattern", Version: 1}, Author: "ana", CreatedAt: deletedAt}, nil
+	case 9:
+		return designpatters.Revision{}, designpatters.ErrRevisionNotFound
+	default:
+		return designpatters.Revision{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) DiffRevisions(ctx context.Context, id string, from, to int64, opts designpatters.ReadOptions) (designpatters.Diff, error) {
+	if id == "draft" && !opts.IncludeDrafts {
+		return designpatters.Diff{}, designpatters.ErrDesignPatternNotFound
+	}
+	if to == 9 {
+		return designpatters.Diff{}, designpatters.ErrRevisionNotFound
+	}
+
+	return designpatters.Diff{
+		From:   from,
+		To:     to,
+		Fields: []designpatters.FieldChange{{Field: "title", From: "Design Pattern", To: "Singleton"}},
+		Blocks: []designpatters.BlockChange{{Index: 0, Change: designpatters.BlockRemoved, From: &repository.Content{Type: repository.BlockMarkdown, Title: "Uso", Text: "Una instancia"}}},
+	}, nil
+}
+
+// Rollback reports the change carried by ctx in the subtitle of the DesignPattern.
+func (s *designPatternServiceMock) Rollback(ctx context.Context, id string, number, version int64) (designpatters.DesignPattern, error) {
+	if version == staleVersion {
+		return designpatters.DesignPattern{}, designpatters.ErrVersionMismatch
+	}
+
+	switch number {
+	case 1:
+		change := designpatters.ChangeFrom(ctx)
+		return designpatters.DesignPattern{
+			Title:    "Design Pattern",
+			Subtitle: change.Author + ": " + change.Note,
+			Version:  version + 1,
+		}, nil
+	case 9:
+		return designpatters.DesignPattern{}, designpatters.ErrRevisionNotFound
+	default:
+		return designpatters.DesignPattern{}, errors.New("unexpected error")
+	}
+}
+
+// Transition authorizes every transition as made from in review.
+func (s *designPatternServiceMock) Transition(ctx context.Context, id string, transition designpatters.Transition) (designpatters.DesignPattern, error) {
+	if transition.Authorize != nil {
+		if err := transition.Authorize(designpatters.StatusInReview, transition.Status); err != nil {
+			return designpatters.DesignPattern{}, err
+		}
+	}
+	if transition.Version == staleVersion {
+		return designpatters.DesignPattern{}, designpatters.ErrVersionMismatch
+	}
+
+	switch id {
+	case "ok":
+		return designpatters.DesignPattern{
+			Title:     "Design Pattern",
+			Version:   4,
+			Status:    transition.Status,
+			PublishAt: transition.PublishAt,
+		}, nil
+	case "conflict":
+		return designpatters.DesignPattern{}, &designpatters.Error{
+			Code:    designpatters.CodeConflict,
+			Message: "Design Pattern can't move from draft to published",
+		}
 	case "not_found":
 		return designpatters.DesignPattern{}, designpatters.ErrDesignPatternNotFound
 	default:
@@ -69,6 +345,147 @@ func (s *designPatternServiceMock) Update(ctx context.Context, designPattern des
 	}
 }
 
+func (s *designPatternServiceMock) GetBySlug(ctx context.Context, slug string, opts designpatters.ReadOptions) (designpatters.DesignPattern, error) {
+	switch slug {
+	case "singleton", "old-singleton":
+		return designpatters.DesignPattern{
+			Slug:        "singleton",
+			SlugAliases: []string{"old-singleton"},
+			Title:       "Singleton",
+			Version:     2,
+		}, nil
+	case "not_found":
+		return designpatters.DesignPattern{}, designpatters.ErrDesignPatternNotFound
+	default:
+		return designpatters.DesignPattern{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) RenameSlug(ctx context.Context, id, slug string, version int64) (designpatters.DesignPattern, error) {
+	if version == staleVersion {
+		return designpatters.DesignPattern{}, designpatters.ErrVersionMismatch
+	}
+
+	switch slug {
+	case "singleton-pattern":
+		return designpatters.DesignPattern{
+			Slug:        slug,
+			SlugAliases: []string{"singleton"},
+			Title:       "Singleton",
+			Version:     3,
+		}, nil
+	case "taken":
+		return designpatters.DesignPattern{}, designpatters.ErrSlugTaken
+	default:
+		return designpatters.DesignPattern{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) GetTranslation(ctx context.Context, id, locale string, opts designpatters.ReadOptions) (designpatters.Translation, error) {
+	if id == "draft" && !opts.IncludeDrafts {
+		return designpatters.Translation{}, designpatters.ErrDesignPatternNotFound
+	}
+
+	switch locale {
+	case "en":
+		return designpatters.Translation{
+			Locale:    "en",
+			Title:     "Singleton",
+			Status:    designpatters.TranslationCurrent,
+			UpdatedAt: deletedAt,
+			Version:   2,
+		}, nil
+	case "fr":
+		return designpatters.Translation{}, designpatters.ErrTranslationNotFound
+	case "de":
+		return designpatters.Translation{}, &designpatters.Error{Code: designpatters.CodeInvalidArgument, Message: "Unsupported locale, use one of en, fr"}
+	default:
+		return designpatters.Translation{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) PutTranslation(ctx context.Context, id string, translation designpatters.Translation, version int64) (designpatters.Translation, error) {
+	if version == staleVersion {
+		return designpatters.Translation{}, designpatters.ErrVersionMismatch
+	}
+
+	switch translation.Title {
+	case "":
+		return designpatters.Translation{}, &designpatters.ValidationError{Fields: []designpatters.FieldError{{Field: "title", Message: "is required"}}}
+	case "error":
+		return designpatters.Translation{}, errors.New("unexpected error")
+	default:
+		translation.Status = designpatters.TranslationCurrent
+		translation.UpdatedAt = deletedAt
+		translation.Version = 3
+		return translation, nil
+	}
+}
+
+func (s *designPatternServiceMock) DeleteTranslation(ctx context.Context, id, locale string, version int64) error {
+	if version == staleVersion {
+		return designpatters.ErrVersionMismatch
+	}
+
+	switch locale {
+	case "en":
+		return nil
+	case "fr":
+		return designpatters.ErrTranslationNotFound
+	default:
+		return errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) TranslationReport(ctx context.Context, id string, opts designpatters.ReadOptions) (designpatters.TranslationReport, error) {
+	switch id {
+	case "ok":
+		return designpatters.TranslationReport{
+			SourceLocale: "es",
+			Locales: []designpatters.LocaleStatus{
+				{Locale: "en", Status: designpatters.TranslationOutdated, UpdatedAt: &deletedAt},
+				{Locale: "fr", Status: designpatters.TranslationMissing},
+			},
+		}, nil
+	case "not_found", "draft":
+		return designpatters.TranslationReport{}, designpatters.ErrDesignPatternNotFound
+	default:
+		return designpatters.TranslationReport{}, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) Tags(ctx context.Context, params designpatters.TagsParams) ([]designpatters.TagCount, error) {
+	switch params.Category {
+	case "":
+		counts := []designpatters.TagCount{{Tag: "concurrency", Count: 2}}
+		if params.IncludeDrafts {
+			counts = append(counts, designpatters.TagCount{Tag: "work in progress", Count: 1})
+		}
+		return counts, nil
+	case "functional":
+		return nil, designpatters.ErrInvalidCategory
+	default:
+		return nil, errors.New("unexpected error")
+	}
+}
+
+func (s *designPatternServiceMock) CacheStats() designpatters.CacheStats {
+	return designpatters.CacheStats{Hits: 40, Misses: 2, Coalesced: 1, Entries: 2}
+}
+
+// deletedAt is when the design patterns in the trash of the service mock were deleted.
+var deletedAt = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// staleVersion is a version the service mock always reports as mismatching.
+const staleVersion = 7
+
+var invalidDesignPatternError = &designpatters.ValidationError{
+	Fields: []designpatters.FieldError{
+		{Field: "title", Message: "is required"},
+		{Field: "contentData[0].image[0]", Message: "must be an absolute http or https URL"},
+	},
+}
+
 func TestNewDesignPatternsHandler(t *testing.T) {
 	service := &designPatternServiceMock{}
 	handler := NewDesignPatternsHandler(service)
@@ -89,21 +506,35 @@ func TestDesignPatternsHandler_GetPatternByID(t *testing.T) {
 			id:               "ok",
 			service:          &designPatternServiceMock{},
 			expectedStatus:   200,
-			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null}}",
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"slug\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null,\"version\":3,\"status\":\"\"}}",
 		},
 		{
 			name:             "Not Found - Get Design Pattern by ID",
 			id:               "not_found",
 			service:          &designPatternServiceMock{},
 			expectedStatus:   404,
-			expectedResponse: "{\"status\":404,\"message\":\"Design Pattern not found\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/not_found","code":"not_found"}`,
+		},
+		{
+			name:             "Bad Request - Get Design Pattern by invalid ID",
+			id:               "invalid_id",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid Design Pattern id","instance":"/designpatters/invalid_id","code":"invalid_id"}`,
+		},
+		{
+			name:             "Service Unavailable - Get Design Pattern by ID",
+			id:               "unavailable",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   503,
+			expectedResponse: `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"Service temporarily unavailable","instance":"/designpatters/unavailable","code":"unavailable"}`,
 		},
 		{
 			name:             "Internal Server Error - Get Design Pattern by ID",
 			id:               "unexpected_error",
 			service:          &designPatternServiceMock{},
 			expectedStatus:   500,
-			expectedResponse: "{\"status\":500,\"message\":\"unexpected error\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/unexpected_error","code":"internal"}`,
 		},
 	}
 
@@ -124,6 +555,160 @@ func TestDesignPatternsHandler_GetPatternByID(t *testing.T) {
 
 			require.Equal(t, test.expectedStatus, resp.StatusCode)
 			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode >= http.StatusBadRequest {
+				require.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			}
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_ListPatterns(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		service          DesignPatternService
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - List Design Patterns",
+			query:            "title=ok&page=2&limit=1",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":[{\"id\":\"\",\"slug\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null,\"version\":0,\"status\":\"\"}],\"meta\":{\"page\":2,\"limit\":1,\"total\":3,\"totalPages\":3}}",
+		},
+		{
+			name:             "Bad Request - Invalid page",
+			query:            "title=ok&page=first",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid page, it must be a non-negative integer","instance":"/designpatters","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Bad Request - Invalid creation date",
+			query:            "title=ok&createdAfter=yesterday",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid createdAfter, it must be an RFC 3339 timestamp","instance":"/designpatters","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Bad Request - Invalid sort",
+			query:            "title=invalid_sort&sort=subtitle",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid sort, use title or createdAt optionally prefixed with -","instance":"/designpatters","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Ok - List Design Patterns by category and tags",
+			query:            "title=tagged&category=creational&tags=gof,go%20idioms&anyTags=concurrency",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"","slug":"","title":"Tagged","subtitle":"","contentData":null,"category":"creational","tags":["gof","go idioms","concurrency"],"version":0,"status":""}],"meta":{"page":1,"limit":20,"total":1,"totalPages":1}}`,
+		},
+		{
+			name:             "Bad Request - Invalid category",
+			query:            "title=invalid_category&category=functional",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid category, use creational, structural or behavioral","instance":"/designpatters","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Internal Server Error - List Design Patterns",
+			query:            "title=unexpected_error",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, tt.service)
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s?%s", designPattersGroup, tt.query), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode >= http.StatusBadRequest {
+				require.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			}
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_SearchPatterns(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		service          DesignPatternService
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Search Design Patterns",
+			query:            "q=ok",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":0,"status":"","score":1.5,"highlights":[{"field":"title","snippet":"\u003cmark\u003eDesign\u003c/mark\u003e Pattern"}]}],"meta":{"page":1,"limit":20,"total":1,"totalPages":1}}`,
+		},
+		{
+			name:             "Bad Request - Missing query",
+			query:            "",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid search query, it must have between 1 and 200 characters","instance":"/designpatters/search","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Bad Request - Invalid limit",
+			query:            "q=ok&limit=-1",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid limit, it must be a non-negative integer","instance":"/designpatters/search","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Internal Server Error - Search Design Patterns",
+			query:            "q=unexpected_error",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/search","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, tt.service)
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/search?%s", designPattersGroup, tt.query), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode >= http.StatusBadRequest {
+				require.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			}
 
 			err = resp.Body.Close()
 			require.NoError(t, err)
@@ -144,14 +729,28 @@ func TestDesignPatternsHandler_CreatePattern(t *testing.T) {
 			service:          &designPatternServiceMock{},
 			bodyPost:         designpatters.DesignPattern{Title: "ok"},
 			expectedStatus:   201,
-			expectedResponse: "{\"status\":201,\"message\":\"\",\"data\":{\"id\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null}}",
+			expectedResponse: "{\"status\":201,\"message\":\"\",\"data\":{\"id\":\"\",\"slug\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null,\"version\":0,\"status\":\"\"}}",
+		},
+		{
+			name:             "Unprocessable Entity - Create Design Pattern",
+			service:          &designPatternServiceMock{},
+			bodyPost:         designpatters.DesignPattern{Title: "invalid"},
+			expectedStatus:   422,
+			expectedResponse: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"Invalid Design Pattern","instance":"/designpatters","code":"validation_failed","errors":[{"field":"title","message":"is required"},{"field":"contentData[0].image[0]","message":"must be an absolute http or https URL"}]}`,
+		},
+		{
+			name:             "Conflict - Create Design Pattern",
+			service:          &designPatternServiceMock{},
+			bodyPost:         designpatters.DesignPattern{Title: "conflict"},
+			expectedStatus:   409,
+			expectedResponse: `{"type":"about:blank","title":"Conflict","status":409,"detail":"Design Pattern conflicts with an existing one","instance":"/designpatters","code":"conflict"}`,
 		},
 		{
 			name:             "Internal Server Error - Create Design Pattern",
 			service:          &designPatternServiceMock{},
 			bodyPost:         designpatters.DesignPattern{Title: "unexpected_error"},
 			expectedStatus:   500,
-			expectedResponse: "{\"status\":500,\"message\":\"unexpected error\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters","code":"internal"}`,
 		},
 	}
 
@@ -175,6 +774,9 @@ func TestDesignPatternsHandler_CreatePattern(t *testing.T) {
 
 			require.Equal(t, test.expectedStatus, resp.StatusCode)
 			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode >= http.StatusBadRequest {
+				require.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			}
 
 			err = resp.Body.Close()
 			require.NoError(t, err)
@@ -202,14 +804,14 @@ func TestDesignPatternsHandler_DeletePattern(t *testing.T) {
 			id:               "not_found",
 			service:          &designPatternServiceMock{},
 			expectedStatus:   404,
-			expectedResponse: "{\"status\":404,\"message\":\"Design Pattern not found\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/not_found","code":"not_found"}`,
 		},
 		{
 			name:             "Internal Server Error - Delete Design Pattern",
 			id:               "unexpected_error",
 			service:          &designPatternServiceMock{},
 			expectedStatus:   500,
-			expectedResponse: "{\"status\":500,\"message\":\"unexpected error\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/unexpected_error","code":"internal"}`,
 		},
 	}
 
@@ -230,6 +832,9 @@ func TestDesignPatternsHandler_DeletePattern(t *testing.T) {
 
 			require.Equal(t, test.expectedStatus, resp.StatusCode)
 			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode >= http.StatusBadRequest {
+				require.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			}
 
 			err = resp.Body.Close()
 			require.NoError(t, err)
@@ -252,7 +857,7 @@ func TestDesignPatternsHandler_UpdatePattern(t *testing.T) {
 			service:          &designPatternServiceMock{},
 			bodyPost:         designpatters.DesignPattern{Title: "ok"},
 			expectedStatus:   200,
-			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null}}",
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"slug\":\"\",\"title\":\"Design Pattern\",\"subtitle\":\"\",\"contentData\":null,\"version\":1,\"status\":\"\"}}",
 		},
 		{
 			name:             "Not Found - Update Design Pattern",
@@ -260,7 +865,15 @@ func TestDesignPatternsHandler_UpdatePattern(t *testing.T) {
 			service:          &designPatternServiceMock{},
 			bodyPost:         designpatters.DesignPattern{Title: "not_found"},
 			expectedStatus:   404,
-			expectedResponse: "{\"status\":404,\"message\":\"Design Pattern not found\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters","code":"not_found"}`,
+		},
+		{
+			name:             "Unprocessable Entity - Update Design Pattern",
+			id:               "invalid",
+			service:          &designPatternServiceMock{},
+			bodyPost:         designpatters.DesignPattern{Title: "invalid"},
+			expectedStatus:   422,
+			expectedResponse: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"Invalid Design Pattern","instance":"/designpatters","code":"validation_failed","errors":[{"field":"title","message":"is required"},{"field":"contentData[0].image[0]","message":"must be an absolute http or https URL"}]}`,
 		},
 		{
 			name:             "Internal Server Error - Update Design Pattern",
@@ -268,7 +881,7 @@ func TestDesignPatternsHandler_UpdatePattern(t *testing.T) {
 			service:          &designPatternServiceMock{},
 			bodyPost:         designpatters.DesignPattern{Title: "unexpected_error"},
 			expectedStatus:   500,
-			expectedResponse: "{\"status\":500,\"message\":\"unexpected error\",\"data\":null}",
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters","code":"internal"}`,
 		},
 	}
 
@@ -292,6 +905,241 @@ func TestDesignPatternsHandler_UpdatePattern(t *testing.T) {
 
 			require.Equal(t, test.expectedStatus, resp.StatusCode)
 			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode >= http.StatusBadRequest {
+				require.Equal(t, problemContentType, resp.Header.Get("Content-Type"))
+			}
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_PatchPattern(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		contentType      string
+		service          DesignPatternService
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Merge Patch Design Pattern",
+			id:               "ok",
+			contentType:      "application/merge-patch+json",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"merge-patch","contentData":null,"version":1,"status":""}}`,
+		},
+		{
+			name:             "Ok - JSON Patch Design Pattern",
+			id:               "ok",
+			contentType:      "application/json-patch+json; charset=utf-8",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"json-patch","contentData":null,"version":1,"status":""}}`,
+		},
+		{
+			name:             "Unsupported Media Type - Patch Design Pattern",
+			id:               "ok",
+			contentType:      "application/json",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   415,
+			expectedResponse: `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"Unsupported Content-Type, use one of application/merge-patch+json, application/json-patch+json","instance":"/designpatters/ok","code":"unsupported_media_type"}`,
+		},
+		{
+			name:             "Not Found - Patch Design Pattern",
+			id:               "not_found",
+			contentType:      "application/merge-patch+json",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/not_found","code":"not_found"}`,
+		},
+		{
+			name:             "Internal Server Error - Patch Design Pattern",
+			id:               "unexpected_error",
+			contentType:      "application/merge-patch+json",
+			service:          &designPatternServiceMock{},
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/unexpected_error","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, tt.service)
+
+			r, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("/%s/%s", designPattersGroup, tt.id), bytes.NewReader([]byte(`{}`)))
+			require.NoError(t, err)
+			r.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+			if resp.StatusCode == http.StatusUnsupportedMediaType {
+				require.Equal(t, acceptPatch, resp.Header.Get("Accept-Patch"))
+			}
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_ListTrash(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - List Trash",
+			query:            "",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":0,"deletedAt":"2023-01-02T03:04:05Z","status":""}],"meta":{"page":1,"limit":20,"total":1,"totalPages":1}}`,
+		},
+		{
+			name:             "Bad Request - List Trash",
+			query:            "?page=first",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid page, it must be a non-negative integer","instance":"/designpatters/trash","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Internal Server Error - List Trash",
+			query:            "?page=2",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/trash","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/trash%s", designPattersGroup, tt.query), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_RestorePattern(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Restore Design Pattern",
+			id:               "ok",
+			expectedStatus:   200,
+			expectedETag:     `"4"`,
+			expectedResponse: `{"status":200,"message":"Design pattern restored successfully","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":4,"status":""}}`,
+		},
+		{
+			name:             "Not Found - Restore Design Pattern",
+			id:               "not_in_trash",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found in the trash","instance":"/designpatters/not_in_trash/restore","code":"not_found"}`,
+		},
+		{
+			name:             "Internal Server Error - Restore Design Pattern",
+			id:               "unexpected_error",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/unexpected_error/restore","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/%s/%s/restore", designPattersGroup, tt.id), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_PurgePattern(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Purge Design Pattern",
+			id:               "ok",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"Design pattern purged permanently","data":null}`,
+		},
+		{
+			name:             "Not Found - Purge Design Pattern",
+			id:               "not_in_trash",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found in the trash","instance":"/designpatters/trash/not_in_trash","code":"not_found"}`,
+		},
+		{
+			name:             "Internal Server Error - Purge Design Pattern",
+			id:               "unexpected_error",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/trash/unexpected_error","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s/trash/%s", designPattersGroup, tt.id), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
 
 			err = resp.Body.Close()
 			require.NoError(t, err)
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	etagHeader    = "ETag"
+	ifMatchHeader = "If-Match"
+)
+
+var (
+	errInvalidIfMatch = badRequestError(errors.New(`Invalid If-Match, it must be a single entity tag such as "3", or *`))
+
+	errIfMatchRequired = requestError{
+		code:  codePreconditionRequired,
+		error: errors.New("If-Match is required, send the ETag of the Design Pattern being changed"),
+	}
+)
+
+// etag returns the strong entity tag of a version.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion returns the version in the If-Match header. It returns zero, meaning any
+// version, when the header is * or missing, unless requireIfMatch is set.
+func ifMatchVersion(c *gin.Context, requireIfMatch bool) (int64, error) {
+	value := strings.TrimSpace(c.GetHeader(ifMatchHeader))
+	if value == "" {
+		if requireIfMatch {
+			return 0, errIfMatchRequired
+		}
+		return 0, nil
+	}
+
+	if value == "*" {
+		return 0, nil
+	}
+
+	// Weak entity tags never match with the strong comparison If-Match requires.
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, errInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, errInvalidIfMatch
+	}
+
+	return version, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesignPatternsHandler_ConditionalRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		ifMatch        string
+		requireIfMatch bool
+		expectedStatus int
+		expectedETag   string
+		expectedCode   string
+	}{
+		{
+			name:           "Get returns the ETag",
+			method:         http.MethodGet,
+			path:           "/ok",
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "Update with matching version",
+			method:         http.MethodPut,
+			body:           `{"title":"ok"}`,
+			ifMatch:        `"3"`,
+			requireIfMatch: true,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:           "Update with any version",
+			method:         http.MethodPut,
+			body:           `{"title":"ok"}`,
+			ifMatch:        "*",
+			requireIfMatch: true,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:           "Update with stale version",
+			method:         http.MethodPut,
+			body:           `{"title":"ok"}`,
+			ifMatch:        fmt.Sprintf(`"%d"`, staleVersion),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "version_mismatch",
+		},
+		{
+			name:           "Update without If-Match when required",
+			method:         http.MethodPut,
+			body:           `{"title":"ok"}`,
+			requireIfMatch: true,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedCode:   "precondition_required",
+		},
+		{
+			name:           "Update with weak ETag",
+			method:         http.MethodPut,
+			body:           `{"title":"ok"}`,
+			ifMatch:        `W/"3"`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_argument",
+		},
+		{
+			name:           "Patch with matching version",
+			method:         http.MethodPatch,
+			path:           "/ok",
+			contentType:    mergePatchContentType,
+			body:           `{}`,
+			ifMatch:        `"3"`,
+			requireIfMatch: true,
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:           "Patch without If-Match when required",
+			method:         http.MethodPatch,
+			path:           "/ok",
+			contentType:    mergePatchContentType,
+			body:           `{}`,
+			requireIfMatch: true,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedCode:   "precondition_required",
+		},
+		{
+			name:           "Delete with stale version",
+			method:         http.MethodDelete,
+			path:           "/ok",
+			ifMatch:        fmt.Sprintf(`"%d"`, staleVersion),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "version_mismatch",
+		},
+		{
+			name:           "Delete without If-Match",
+			method:         http.MethodDelete,
+			path:           "/ok",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{}, RequireIfMatch(tt.requireIfMatch))
+
+			r, err := http.NewRequest(tt.method, fmt.Sprintf("/%s%s", designPattersGroup, tt.path), bytes.NewReader([]byte(tt.body)))
+			require.NoError(t, err)
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.ifMatch != "" {
+				r.Header.Set(ifMatchHeader, tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			if tt.expectedCode != "" {
+				require.Contains(t, string(body), fmt.Sprintf(`"code":%q`, tt.expectedCode))
+			}
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/platform/health"
+)
+
+type HealthHandler struct {
+	checker HealthChecker
+}
+
+func NewHealthHandler(checker HealthChecker) HealthHandler {
+	return HealthHandler{
+		checker: checker,
+	}
+}
+
+// Liveness reports the process is up. It never checks dependencies, so an unreachable
+// database doesn't get the service restarted.
+func (s HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    health.Report{Status: health.StatusUp, Checks: map[string]health.CheckResult{}},
+	})
+}
+
+// Readiness reports whether every dependency is reachable, with the status and latency of
+// each one.
+func (s HealthHandler) Readiness(c *gin.Context) {
+	report := s.checker.Check(c.Request.Context())
+
+	httpCode := http.StatusOK
+	message := ""
+	if report.Status != health.StatusUp {
+		httpCode = http.StatusServiceUnavailable
+		message = "Service not ready"
+	}
+
+	c.JSON(httpCode, Response{
+		Status:  httpCode,
+		Message: message,
+		Data:    report,
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/health"
+)
+
+type healthCheckerMock struct {
+	report health.Report
+}
+
+func (h *healthCheckerMock) Check(ctx context.Context) health.Report {
+	return h.report
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		report           health.Report
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Liveness ignores dependencies",
+			path:             "/healthz",
+			report:           health.Report{Status: health.StatusDown},
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"status\":\"up\",\"checks\":{}}}",
+		},
+		{
+			name: "Ok - Readiness",
+			path: "/readyz",
+			report: health.Report{
+				Status: health.StatusUp,
+				Checks: map[string]health.CheckResult{"mongo": {Status: health.StatusUp, LatencyMS: 1.5}},
+			},
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"status\":\"up\",\"checks\":{\"mongo\":{\"status\":\"up\",\"latencyMs\":1.5}}}}",
+		},
+		{
+			name: "Service Unavailable - Readiness",
+			path: "/readyz",
+			report: health.Report{
+				Status: health.StatusDown,
+				Checks: map[string]health.CheckResult{"mongo": {Status: health.StatusDown, LatencyMS: 2000, Error: "unavailable"}},
+			},
+			expectedStatus:   503,
+			expectedResponse: "{\"status\":503,\"message\":\"Service not ready\",\"data\":{\"status\":\"down\",\"checks\":{\"mongo\":{\"status\":\"down\",\"latencyMs\":2000,\"error\":\"unavailable\"}}}}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = HealthRoutes(app, &healthCheckerMock{report: tt.report})
+
+			r, err := http.NewRequest(http.MethodGet, tt.path, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			require.Equal(t, tt.expectedResponse, rr.Body.String())
+		})
+	}
+}
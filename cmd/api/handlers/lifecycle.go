@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+)
+
+const (
+	draftsQuery = "drafts"
+
+	authorizationHeader   = "Authorization"
+	wwwAuthenticateHeader = "WWW-Authenticate"
+	bearerPrefix          = "Bearer "
+)
+
+var (
+	errInvalidDrafts = badRequestError(errors.New("Invalid drafts, it must be true or false"))
+
+	errUnauthenticated = requestError{
+		code:  codeUnauthenticated,
+		error: errors.New("Drafts are only visible to editors, send a token allowed to read drafts"),
+	}
+)
+
+// transitionRequest is the body of a request moving a DesignPattern to another status.
+type transitionRequest struct {
+	Status    designpatters.Status `json:"status" binding:"required"`
+	PublishAt *time.Time           `json:"publishAt"`
+}
+
+// readOptions returns which DesignPatterns the request can read, the locales it prefers them
+// in and whether it wants them rendered to HTML. Drafts are only returned to principals with
+// the read:drafts permission asking for them with ?drafts=true.
+func (s DesignPatternsHandler) readOptions(c *gin.Context) (designpatters.ReadOptions, error) {
+	c.Writer.Header().Add(varyHeader, acceptLanguageHeader)
+
+	render, err := renderRequested(c)
+	if err != nil {
+		return designpatters.ReadOptions{}, err
+	}
+	opts := designpatters.ReadOptions{Locales: requestLocales(c), RenderHTML: render}
+
+	value := c.Query(draftsQuery)
+	if value == "" {
+		return opts, nil
+	}
+
+	drafts, err := strconv.ParseBool(value)
+	if err != nil {
+		return designpatters.ReadOptions{}, errInvalidDrafts
+	}
+
+	if drafts && !s.guard.Allows(c, auth.ReadDrafts) {
+		c.Header(wwwAuthenticateHeader, "Bearer")
+		return designpatters.ReadOptions{}, errUnauthenticated
+	}
+
+	opts.IncludeDrafts = drafts
+	return opts, nil
+}
+
+// TransitionPattern moves a design pattern to another status of its lifecycle, optionally
+// scheduling its publication.
+func (s DesignPatternsHandler) TransitionPattern(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var request transitionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	response, err := s.service.Transition(ctx, id, designpatters.Transition{
+		Status:    request.Status,
+		PublishAt: request.PublishAt,
+		Version:   version,
+		Authorize: s.guard.authorizeTransition(c, request.PublishAt != nil),
+	})
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
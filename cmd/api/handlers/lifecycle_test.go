@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesignPatternsHandler_Drafts(t *testing.T) {
+	tests := []struct {
+		name                    string
+		path                    string
+		authorization           string
+		expectedStatus          int
+		expectedWWWAuthenticate string
+		expectedResponse        string
+	}{
+		{
+			name:             "Ok - Get Draft",
+			path:             "/draft?drafts=true",
+			authorization:    "Bearer editor",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Draft","subtitle":"","contentData":null,"version":1,"status":"draft"}}`,
+		},
+		{
+			name:             "Ok - List Drafts",
+			path:             "?title=ok&drafts=true",
+			authorization:    "Bearer editor",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"","slug":"","title":"Draft","subtitle":"","contentData":null,"version":0,"status":""}],"meta":{"page":0,"limit":0,"total":3,"totalPages":0}}`,
+		},
+		{
+			name:             "Not Found - Get Draft without drafts",
+			path:             "/draft",
+			authorization:    "Bearer editor",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/draft","code":"not_found"}`,
+		},
+		{
+			name:                    "Unauthorized - Get Draft with a wrong token",
+			path:                    "/draft?drafts=true",
+			authorization:           "Bearer guess",
+			expectedStatus:          401,
+			expectedWWWAuthenticate: "Bearer",
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Drafts are only visible to editors, send a token allowed to read drafts","instance":"/designpatters/draft","code":"unauthenticated"}`,
+		},
+		{
+			name:                    "Unauthorized - List Drafts without a token",
+			path:                    "?title=ok&drafts=true",
+			authorization:           "Bearer ",
+			expectedStatus:          401,
+			expectedWWWAuthenticate: "Bearer",
+			expectedResponse:        `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"Drafts are only visible to editors, send a token allowed to read drafts","instance":"/designpatters","code":"unauthenticated"}`,
+		},
+		{
+			name:             "Bad Request - Invalid drafts",
+			path:             "/draft?drafts=maybe",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid drafts, it must be true or false","instance":"/designpatters/draft","code":"invalid_argument"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{}, Guarded(testGuard(t)))
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s%s", designPattersGroup, tt.path), nil)
+			require.NoError(t, err)
+			if tt.authorization != "" {
+				r.Header.Set(authorizationHeader, tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedWWWAuthenticate, resp.Header.Get(wwwAuthenticateHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_TransitionPattern(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		body             string
+		ifMatch          string
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Schedule Design Pattern",
+			id:               "ok",
+			body:             `{"status":"in_review","publishAt":"2030-01-02T03:04:05Z"}`,
+			ifMatch:          `"3"`,
+			expectedStatus:   200,
+			expectedETag:     `"4"`,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":4,"status":"in_review","publishAt":"2030-01-02T03:04:05Z"}}`,
+		},
+		{
+			name:             "Bad Request - Missing status",
+			id:               "ok",
+			body:             `{}`,
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Key: 'transitionRequest.Status' Error:Field validation for 'Status' failed on the 'required' tag","instance":"/designpatters/ok/status","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Conflict - Transition not allowed",
+			id:               "conflict",
+			body:             `{"status":"published"}`,
+			expectedStatus:   409,
+			expectedResponse: `{"type":"about:blank","title":"Conflict","status":409,"detail":"Design Pattern can't move from draft to published","instance":"/designpatters/conflict/status","code":"conflict"}`,
+		},
+		{
+			name:             "Precondition Failed - Transition",
+			id:               "ok",
+			body:             `{"status":"archived"}`,
+			ifMatch:          fmt.Sprintf(`"%d"`, staleVersion),
+			expectedStatus:   412,
+			expectedResponse: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"Design Pattern was modified, fetch it again and retry","instance":"/designpatters/ok/status","code":"version_mismatch"}`,
+		},
+		{
+			name:             "Not Found - Transition",
+			id:               "not_found",
+			body:             `{"status":"archived"}`,
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/not_found/status","code":"not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/%s/%s/status", designPattersGroup, tt.id), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			r.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				r.Header.Set(ifMatchHeader, tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
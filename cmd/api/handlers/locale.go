@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	langQuery = "lang"
+
+	acceptLanguageHeader  = "Accept-Language"
+	contentLanguageHeader = "Content-Language"
+	varyHeader            = "Vary"
+)
+
+// requestLocales returns the locales the request prefers, most preferred first: the one in
+// ?lang= followed by those in the Accept-Language header, ordered by their quality. Wildcards
+// and locales with quality 0 are left out, since the source locale is the fallback anyway.
+func requestLocales(c *gin.Context) []string {
+	var locales []string
+	if lang := strings.TrimSpace(c.Query(langQuery)); lang != "" {
+		locales = append(locales, lang)
+	}
+
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var accepted []weighted
+	for _, part := range strings.Split(c.GetHeader(acceptLanguageHeader), ",") {
+		locale, params, _ := strings.Cut(part, ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		accepted = append(accepted, weighted{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+	for _, a := range accepted {
+		locales = append(locales, a.locale)
+	}
+
+	return locales
+}
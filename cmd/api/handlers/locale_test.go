@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLocales(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		expected       []string
+	}{
+		{name: "none", expected: nil},
+		{name: "lang query", query: "?lang=en", expected: []string{"en"}},
+		{name: "accept language by quality", acceptLanguage: "fr;q=0.5, en-US, es;q=0.8", expected: []string{"en-US", "es", "fr"}},
+		{name: "lang query first", query: "?lang=pt", acceptLanguage: "en", expected: []string{"pt", "en"}},
+		{name: "wildcard and rejected skipped", acceptLanguage: "*, de;q=0, en;q=0.1, fr;q=invalid", expected: []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var locales []string
+			app := gin.Default()
+			app.GET("/", func(c *gin.Context) {
+				locales = requestLocales(c)
+			})
+
+			r, err := http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			require.NoError(t, err)
+			if tt.acceptLanguage != "" {
+				r.Header.Set(acceptLanguageHeader, tt.acceptLanguage)
+			}
+			app.ServeHTTP(httptest.NewRecorder(), r)
+
+			require.Equal(t, tt.expected, locales)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_GetPatternByID_Locale(t *testing.T) {
+	tests := []struct {
+		name                    string
+		query                   string
+		acceptLanguage          string
+		expectedContentLanguage string
+		expectedResponse        string
+	}{
+		{
+			name:                    "Ok - Source locale",
+			expectedContentLanguage: "es",
+			expectedResponse:        `{"status":200,"message":"","data":{"id":"","slug":"","title":"Único","subtitle":"","contentData":null,"version":2,"status":"","locale":"es"}}`,
+		},
+		{
+			name:                    "Ok - Accept-Language",
+			acceptLanguage:          "fr, en;q=0.8",
+			expectedContentLanguage: "en",
+			expectedResponse:        `{"status":200,"message":"","data":{"id":"","slug":"","title":"Singleton","subtitle":"","contentData":null,"version":2,"status":"","locale":"en"}}`,
+		},
+		{
+			name:                    "Ok - Lang query",
+			query:                   "?lang=en",
+			acceptLanguage:          "es",
+			expectedContentLanguage: "en",
+			expectedResponse:        `{"status":200,"message":"","data":{"id":"","slug":"","title":"Singleton","subtitle":"","contentData":null,"version":2,"status":"","locale":"en"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/translated%s", designPattersGroup, tt.query), nil)
+			require.NoError(t, err)
+			if tt.acceptLanguage != "" {
+				r.Header.Set(acceptLanguageHeader, tt.acceptLanguage)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, tt.expectedContentLanguage, resp.Header.Get(contentLanguageHeader))
+			require.Equal(t, acceptLanguageHeader, resp.Header.Get(varyHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
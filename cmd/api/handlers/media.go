@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/media"
+)
+
+const (
+	mediaGroup     = "media"
+	mediaIDParam   = "id"
+	mediaFileField = "file"
+)
+
+var (
+	errNotMultipart = requestError{
+		code:  codeUnsupportedMediaType,
+		error: errors.New("Unsupported Content-Type, upload the file as multipart/form-data"),
+	}
+	errMissingFile      = badRequestError(fmt.Errorf("Missing file, upload it in the %s field", mediaFileField))
+	errInvalidMultipart = badRequestError(errors.New("Invalid multipart/form-data body"))
+)
+
+// MediaHandler handles the uploads of media and serves their content.
+type MediaHandler struct {
+	service     MediaService
+	cacheMaxAge time.Duration
+}
+
+// NewMediaHandler creates a MediaHandler that lets clients cache media for cacheMaxAge.
+func NewMediaHandler(service MediaService, cacheMaxAge time.Duration) MediaHandler {
+	return MediaHandler{
+		service:     service,
+		cacheMaxAge: cacheMaxAge,
+	}
+}
+
+// UploadMedia stores the file sent in the file field of a multipart/form-data body. It
+// responds with 201 Created when the file is new and with 200 OK when the same content was
+// already uploaded, along with the Location of the content either way.
+func (h MediaHandler) UploadMedia(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			respondError(c, errNotMultipart)
+			return
+		}
+		respondError(c, errInvalidMultipart)
+		return
+	}
+
+	part, err := filePart(reader)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer part.Close()
+
+	uploaded, created, err := h.service.Upload(ctx, part.FileName(), part)
+	if err != nil {
+		respondError(c, mediaError(err))
+		return
+	}
+
+	status, message := http.StatusOK, "Media already uploaded"
+	if created {
+		status, message = http.StatusCreated, "Media uploaded successfully"
+	}
+
+	c.Header("Location", mediaPath(uploaded.ID))
+	c.JSON(status, Response{
+		Status:  status,
+		Message: message,
+		Data:    uploaded,
+	})
+}
+
+// GetMedia serves the content of a media. The content of a media never changes, so it can be
+// cached for as long as configured and revalidated with its hash as entity tag. Range
+// requests are supported.
+func (h MediaHandler) GetMedia(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(mediaIDParam)
+
+	stored, content, err := h.service.Open(ctx, id)
+	if err != nil {
+		respondError(c, mediaError(err))
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Type", stored.ContentType)
+	c.Header(etagHeader, fmt.Sprintf("%q", stored.Hash))
+	c.Header("Cache-Control", h.cacheControl())
+	// Browsers must not guess another type, nor run anything the file may carry.
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "default-src 'none'")
+
+	http.ServeContent(c.Writer, c.Request, "", stored.CreatedAt, content)
+}
+
+func (h MediaHandler) cacheControl() string {
+	if h.cacheMaxAge <= 0 {
+		return "no-cache"
+	}
+
+	return fmt.Sprintf("public, max-age=%d, immutable", int64(h.cacheMaxAge.Seconds()))
+}
+
+// filePart returns the part of a multipart body holding the file, skipping any other field.
+func filePart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errMissingFile
+		}
+		if err != nil {
+			return nil, errInvalidMultipart
+		}
+
+		if part.FormName() == mediaFileField {
+			return part, nil
+		}
+	}
+}
+
+// mediaError maps the errors of the media service to the codes of the problem details.
+func mediaError(err error) error {
+	switch {
+	case errors.Is(err, media.ErrMediaNotFound):
+		return requestError{code: designpatters.CodeNotFound, error: err}
+	case errors.Is(err, media.ErrInvalidID):
+		return requestError{code: designpatters.CodeInvalidID, error: err}
+	case errors.Is(err, media.ErrEmpty), errors.Is(err, media.ErrUnreadable):
+		return badRequestError(err)
+	case errors.Is(err, media.ErrTooLarge):
+		return requestError{code: codePayloadTooLarge, error: err}
+	case errors.Is(err, media.ErrUnsupportedType):
+		return requestError{code: codeUnsupportedMediaType, error: err}
+	case errors.Is(err, media.ErrUnavailable):
+		return designpatters.ErrUnavailable
+	}
+
+	return err
+}
+
+func mediaPath(id string) string {
+	return fmt.Sprintf("/%s/%s", mediaGroup, id)
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/media"
+)
+
+const someMediaID = "5f9f1c5b9b9b9b9b9b9b9b9b"
+
+type mediaServiceMock struct{}
+
+func (m *mediaServiceMock) Upload(_ context.Context, filename string, content io.Reader) (media.Media, bool, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return media.Media{}, false, err
+	}
+
+	switch string(data) {
+	case "new":
+		return someMedia(filename), true, nil
+	case "existing":
+		return someMedia("first.png"), false, nil
+	case "too-large":
+		return media.Media{}, false, fmt.Errorf("%w, the limit is 4 bytes", media.ErrTooLarge)
+	case "svg":
+		return media.Media{}, false, media.ErrUnsupportedType
+	default:
+		return media.Media{}, false, media.ErrSomethingWentWrong
+	}
+}
+
+func (m *mediaServiceMock) Open(_ context.Context, id string) (media.Media, io.ReadSeekCloser, error) {
+	switch id {
+	case someMediaID:
+		return someMedia("singleton.png"), nopReadSeekCloser{strings.NewReader("some-image")}, nil
+	case "not_found":
+		return media.Media{}, nil, media.ErrMediaNotFound
+	case "unavailable":
+		return media.Media{}, nil, media.ErrUnavailable
+	default:
+		return media.Media{}, nil, media.ErrInvalidID
+	}
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error {
+	return nil
+}
+
+func someMedia(filename string) media.Media {
+	return media.Media{
+		ID:          someMediaID,
+		ContentType: "image/png",
+		Size:        10,
+		Hash:        "some-hash",
+		Filename:    filename,
+		CreatedAt:   time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// multipartBody returns a multipart/form-data body with content in field, along with its
+// Content-Type.
+func multipartBody(t *testing.T, field, content string) (io.Reader, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("note", "ignored"))
+	part, err := writer.CreateFormFile(field, "singleton.png")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+func TestMediaHandler_UploadMedia(t *testing.T) {
+	tests := []struct {
+		name             string
+		field            string
+		content          string
+		contentType      string
+		expectedStatus   int
+		expectedLocation string
+		expectedResponse string
+	}{
+		{
+			name:             "Created - Upload media",
+			field:            "file",
+			content:          "new",
+			expectedStatus:   201,
+			expectedLocation: "/media/" + someMediaID,
+			expectedResponse: `{"status":201,"message":"Media uploaded successfully","data":{"id":"5f9f1c5b9b9b9b9b9b9b9b9b","contentType":"image/png","size":10,"hash":"some-hash","filename":"singleton.png","createdAt":"2022-11-01T00:00:00Z"}}`,
+		},
+		{
+			name:             "Ok - Upload media already uploaded",
+			field:            "file",
+			content:          "existing",
+			expectedStatus:   200,
+			expectedLocation: "/media/" + someMediaID,
+			expectedResponse: `{"status":200,"message":"Media already uploaded","data":{"id":"5f9f1c5b9b9b9b9b9b9b9b9b","contentType":"image/png","size":10,"hash":"some-hash","filename":"first.png","createdAt":"2022-11-01T00:00:00Z"}}`,
+		},
+		{
+			name:             "Bad Request - Upload media without file",
+			field:            "image",
+			content:          "new",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Missing file, upload it in the file field","instance":"/media","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Unsupported Media Type - Upload media as JSON",
+			contentType:      "application/json",
+			expectedStatus:   415,
+			expectedResponse: `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"Unsupported Content-Type, upload the file as multipart/form-data","instance":"/media","code":"unsupported_media_type"}`,
+		},
+		{
+			name:             "Unsupported Media Type - Upload SVG",
+			field:            "file",
+			content:          "svg",
+			expectedStatus:   415,
+			expectedResponse: `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"Unsupported media type, upload a PNG, JPEG, GIF or WebP image","instance":"/media","code":"unsupported_media_type"}`,
+		},
+		{
+			name:             "Request Entity Too Large - Upload media",
+			field:            "file",
+			content:          "too-large",
+			expectedStatus:   413,
+			expectedResponse: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"Media is too large, the limit is 4 bytes","instance":"/media","code":"payload_too_large"}`,
+		},
+		{
+			name:             "Internal Server Error - Upload media",
+			field:            "file",
+			content:          "unexpected",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/media","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = MediaRoutes(app, &mediaServiceMock{}, time.Hour, nil)
+
+			body, contentType := multipartBody(t, tt.field, tt.content)
+			if tt.contentType != "" {
+				body, contentType = strings.NewReader("{}"), tt.contentType
+			}
+
+			r, err := http.NewRequest(http.MethodPost, "/"+mediaGroup, body)
+			require.NoError(t, err)
+			r.Header.Set("Content-Type", contentType)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedLocation, resp.Header.Get("Location"))
+			require.Equal(t, tt.expectedResponse, string(respBody))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMediaHandler_GetMedia(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		headers          map[string]string
+		cacheMaxAge      time.Duration
+		expectedStatus   int
+		expectedHeaders  map[string]string
+		expectedResponse string
+	}{
+		{
+			name:           "Ok - Get media",
+			id:             someMediaID,
+			cacheMaxAge:    365 * 24 * time.Hour,
+			expectedStatus: 200,
+			expectedHeaders: map[string]string{
+				"Content-Type":           "image/png",
+				"ETag":                   `"some-hash"`,
+				"Cache-Control":          "public, max-age=31536000, immutable",
+				"X-Content-Type-Options": "nosniff",
+				"Last-Modified":          "Tue, 01 Nov 2022 00:00:00 GMT",
+			},
+			expectedResponse: "some-image",
+		},
+		{
+			name:           "Ok - Get media without caching",
+			id:             someMediaID,
+			expectedStatus: 200,
+			expectedHeaders: map[string]string{
+				"Cache-Control": "no-cache",
+			},
+			expectedResponse: "some-image",
+		},
+		{
+			name:           "Not Modified - Get media with its ETag",
+			id:             someMediaID,
+			headers:        map[string]string{"If-None-Match": `"some-hash"`},
+			cacheMaxAge:    time.Hour,
+			expectedStatus: 304,
+			expectedHeaders: map[string]string{
+				"ETag":          `"some-hash"`,
+				"Cache-Control": "public, max-age=3600, immutable",
+			},
+			expectedResponse: "",
+		},
+		{
+			name:           "Partial Content - Get a range of media",
+			id:             someMediaID,
+			headers:        map[string]string{"Range": "bytes=5-"},
+			expectedStatus: 206,
+			expectedHeaders: map[string]string{
+				"Content-Range": "bytes 5-9/10",
+			},
+			expectedResponse: "image",
+		},
+		{
+			name:             "Not Found - Get media",
+			id:               "not_found",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Media not found","instance":"/media/not_found","code":"not_found"}`,
+		},
+		{
+			name:             "Bad Request - Get media with invalid id",
+			id:               "aaaa",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid media id","instance":"/media/aaaa","code":"invalid_id"}`,
+		},
+		{
+			name:             "Service Unavailable - Get media",
+			id:               "unavailable",
+			expectedStatus:   503,
+			expectedResponse: `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"Service temporarily unavailable","instance":"/media/unavailable","code":"unavailable"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = MediaRoutes(app, &mediaServiceMock{}, tt.cacheMaxAge, nil)
+
+			r, err := http.NewRequest(http.MethodGet, mediaPath(tt.id), nil)
+			require.NoError(t, err)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			for name, value := range tt.expectedHeaders {
+				require.Equal(t, value, resp.Header.Get(name), name)
+			}
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
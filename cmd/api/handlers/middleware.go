@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+const (
+	requestIDHeader    = "X-Request-ID"
+	maxRequestIDLength = 128
+)
+
+// RequestID takes the request ID from the X-Request-ID header, or generates one, echoes it
+// in the response and carries it in the request context so every log line includes it.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Header(requestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// AccessLog adds the method and route to the request context, so they are included in
+// every log line of the request, and logs a line once the request completes with its
+// status, latency and errors.
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		ctx := logging.WithAttrs(c.Request.Context(),
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("client_ip", c.ClientIP()),
+		}
+
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(ctx, level, "request completed", attrs...)
+	}
+}
+
+// Recovery turns panics into a 500 response and logs them with their stack trace.
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.ErrorContext(c.Request.Context(), "panic recovered",
+			"panic", recovered,
+			"stack", string(debug.Stack()),
+		)
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, Response{
+			Status:  http.StatusInternalServerError,
+			Message: "Something went wrong",
+			Data:    nil,
+		})
+	})
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		isAlphanumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlphanumeric && r != '-' && r != '_' && r != '.' && r != ':' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		incoming   string
+		expectSame bool
+	}{
+		{
+			name:       "Incoming request ID is kept",
+			incoming:   "some-request-id",
+			expectSame: true,
+		},
+		{
+			name:       "Missing request ID is generated",
+			incoming:   "",
+			expectSame: false,
+		},
+		{
+			name:       "Invalid request ID is replaced",
+			incoming:   "<script>",
+			expectSame: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+
+			app := gin.New()
+			app.Use(RequestID())
+			app.GET("/ping", func(c *gin.Context) {
+				fromContext = logging.RequestID(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			r, err := http.NewRequest(http.MethodGet, "/ping", nil)
+			require.NoError(t, err)
+			r.Header.Set(requestIDHeader, tt.incoming)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			returned := rr.Header().Get(requestIDHeader)
+			require.NotEmpty(t, returned)
+			require.Equal(t, returned, fromContext)
+			require.Equal(t, tt.expectSame, returned == tt.incoming)
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := logging.New("info", &buffer)
+
+	app := gin.New()
+	app.Use(RequestID(), AccessLog(logger), Recovery(logger))
+	app.GET("/designpatters/:id", func(c *gin.Context) {
+		logger.InfoContext(c.Request.Context(), "inside handler")
+		c.Error(errors.New("some-error"))
+		c.Status(http.StatusNotFound)
+	})
+	app.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	r, err := http.NewRequest(http.MethodGet, "/designpatters/abc", nil)
+	require.NoError(t, err)
+	r.Header.Set(requestIDHeader, "some-request-id")
+	app.ServeHTTP(httptest.NewRecorder(), r)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var handlerLine, accessLine map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &handlerLine))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &accessLine))
+
+	require.Equal(t, "some-request-id", handlerLine["request_id"])
+	require.Equal(t, "/designpatters/:id", handlerLine["route"])
+
+	require.Equal(t, "WARN", accessLine["level"])
+	require.Equal(t, "request completed", accessLine["msg"])
+	require.Equal(t, "some-request-id", accessLine["request_id"])
+	require.Equal(t, "GET", accessLine["method"])
+	require.Equal(t, "/designpatters/:id", accessLine["route"])
+	require.Equal(t, float64(http.StatusNotFound), accessLine["status"])
+	require.Contains(t, accessLine, "latency_ms")
+	require.Contains(t, accessLine["error"], "some-error")
+
+	buffer.Reset()
+	r, err = http.NewRequest(http.MethodGet, "/panic", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, r)
+
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.Contains(t, buffer.String(), `"msg":"panic recovered"`)
+	require.Contains(t, buffer.String(), `"level":"ERROR","msg":"request completed"`)
+}
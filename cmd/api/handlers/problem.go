@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+// problemContentType is the media type of RFC 7807 problem details.
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response. Code is an extension member that
+// clients can switch on, as it doesn't change along with the wording of Detail. Permission
+// names the permission a forbidden request lacks.
+type Problem struct {
+	Type       string       `json:"type"`
+	Title      string       `json:"title"`
+	Status     int          `json:"status"`
+	Detail     string       `json:"detail,omitempty"`
+	Instance   string       `json:"instance,omitempty"`
+	Code       string       `json:"code"`
+	RequestID  string       `json:"requestId,omitempty"`
+	Permission string       `json:"permission,omitempty"`
+	Errors     []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a field of the request is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Codes of errors found by the handlers, before calling a service.
+const (
+	codeUnsupportedMediaType designpatters.Code = "unsupported_media_type"
+	codePreconditionRequired designpatters.Code = "precondition_required"
+	codeUnauthenticated      designpatters.Code = "unauthenticated"
+	codePayloadTooLarge      designpatters.Code = "payload_too_large"
+	codeForbidden            designpatters.Code = "forbidden"
+	codeRateLimited          designpatters.Code = "rate_limited"
+)
+
+var codeStatuses = map[designpatters.Code]int{
+	designpatters.CodeNotFound:        http.StatusNotFound,
+	designpatters.CodeInvalidID:       http.StatusBadRequest,
+	designpatters.CodeInvalidArgument: http.StatusBadRequest,
+	designpatters.CodeConflict:        http.StatusConflict,
+	designpatters.CodeVersionMismatch: http.StatusPreconditionFailed,
+	designpatters.CodeValidation:      http.StatusUnprocessableEntity,
+	designpatters.CodeUnavailable:     http.StatusServiceUnavailable,
+	designpatters.CodeInternal:        http.StatusInternalServerError,
+	codeUnsupportedMediaType:          http.StatusUnsupportedMediaType,
+	codePreconditionRequired:          http.StatusPreconditionRequired,
+	codeUnauthenticated:               http.StatusUnauthorized,
+	codePayloadTooLarge:               http.StatusRequestEntityTooLarge,
+	codeForbidden:                     http.StatusForbidden,
+	codeRateLimited:                   http.StatusTooManyRequests,
+}
+
+// requestError is an error in the request itself, found before calling the service or
+// reported by a service without codes of its own.
+type requestError struct {
+	code designpatters.Code
+	error
+}
+
+func (e requestError) Unwrap() error {
+	return e.error
+}
+
+func badRequestError(err error) error {
+	return requestError{code: designpatters.CodeInvalidArgument, error: err}
+}
+
+// respondError records err on the context and responds with the problem details it maps to.
+func respondError(c *gin.Context, err error) {
+	c.Error(err)
+
+	problem := newProblem(c, err)
+	c.Header("Content-Type", problemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+func newProblem(c *gin.Context, err error) Problem {
+	code := designpatters.CodeOf(err)
+	detail := err.Error()
+
+	var requestErr requestError
+	if errors.As(err, &requestErr) {
+		code = requestErr.code
+	} else if code == designpatters.CodeInternal {
+		// Unexpected errors may carry details that are not meant for clients.
+		detail = designpatters.ErrSomethingWentWrong.Error()
+	}
+
+	status, ok := codeStatuses[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      string(code),
+		RequestID: logging.RequestID(c.Request.Context()),
+	}
+
+	var permissionErr permissionError
+	if errors.As(err, &permissionErr) {
+		problem.Permission = string(permissionErr.permission)
+	}
+
+	var validationErr *designpatters.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, field := range validationErr.Fields {
+			problem.Errors = append(problem.Errors, FieldError{Field: field.Field, Message: field.Message})
+		}
+	}
+
+	return problem
+}
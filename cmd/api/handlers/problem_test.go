@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/designpatters"
+)
+
+func TestRespondError(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedProblem Problem
+	}{
+		{
+			name: "Service error",
+			err:  fmt.Errorf("wrapped: %w", designpatters.ErrDesignPatternNotFound),
+			expectedProblem: Problem{
+				Type:      "about:blank",
+				Title:     "Not Found",
+				Status:    http.StatusNotFound,
+				Detail:    "wrapped: Design Pattern not found",
+				Instance:  "/problem",
+				Code:      "not_found",
+				RequestID: "some-request-id",
+			},
+		},
+		{
+			name: "Bad request",
+			err:  badRequestError(errors.New("Invalid page")),
+			expectedProblem: Problem{
+				Type:      "about:blank",
+				Title:     "Bad Request",
+				Status:    http.StatusBadRequest,
+				Detail:    "Invalid page",
+				Instance:  "/problem",
+				Code:      "invalid_argument",
+				RequestID: "some-request-id",
+			},
+		},
+		{
+			name: "Unexpected error hides its details",
+			err:  errors.New("connection refused by 10.0.0.1"),
+			expectedProblem: Problem{
+				Type:      "about:blank",
+				Title:     "Internal Server Error",
+				Status:    http.StatusInternalServerError,
+				Detail:    "Something went wrong",
+				Instance:  "/problem",
+				Code:      "internal",
+				RequestID: "some-request-id",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.New()
+			app.Use(RequestID())
+			app.GET("/problem", func(c *gin.Context) {
+				respondError(c, tt.err)
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/problem", nil)
+			r.Header.Set(requestIDHeader, "some-request-id")
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+			require.Equal(t, tt.expectedProblem, problem)
+			require.Equal(t, tt.expectedProblem.Status, rr.Code)
+			require.Equal(t, problemContentType, rr.Header().Get("Content-Type"))
+		})
+	}
+}
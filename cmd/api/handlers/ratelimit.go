@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/platform/ratelimit"
+)
+
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// rateLimit limits the requests of each client, reads with read and writes with write, and
+// tells clients how many requests they have left in RateLimit headers. Clients are told apart
+// by the principal they are authenticated as, or by IP address. API keys are looked up in the
+// store, so the invalid ones of each IP address are limited in a bucket of their own, and no
+// more keys sent from an address are looked up while it is empty. Nil limiters don't limit.
+func rateLimit(guard *Guard, read, write *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			limiter = read
+		}
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		invalidKeys := "invalid-keys:ip:" + c.ClientIP()
+		if guard.sendsAPIKey(c) {
+			if decision := limiter.Peek(invalidKeys); !decision.Allowed {
+				tooManyRequests(c, decision)
+				return
+			}
+		}
+
+		key, invalidKey := guard.clientKey(c)
+		if invalidKey {
+			limiter.Allow(invalidKeys)
+		}
+
+		decision := limiter.Allow(key)
+		if !decision.Allowed {
+			tooManyRequests(c, decision)
+			return
+		}
+		setRateLimitHeaders(c, decision)
+
+		c.Next()
+	}
+}
+
+// tooManyRequests rejects a request over the limit, telling the client when to retry.
+func tooManyRequests(c *gin.Context, decision ratelimit.Decision) {
+	setRateLimitHeaders(c, decision)
+	c.Header(retryAfterHeader, seconds(decision.RetryAfter))
+	respondError(c, requestError{
+		code:  codeRateLimited,
+		error: fmt.Errorf("Too many requests, retry in %s seconds", seconds(decision.RetryAfter)),
+	})
+	c.Abort()
+}
+
+func setRateLimitHeaders(c *gin.Context, decision ratelimit.Decision) {
+	c.Header(rateLimitLimitHeader, strconv.Itoa(decision.Limit))
+	c.Header(rateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+	c.Header(rateLimitResetHeader, seconds(decision.Reset))
+}
+
+// seconds formats d as whole seconds, rounded up so clients never retry too early.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
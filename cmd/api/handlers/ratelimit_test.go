@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/apikeys"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"github.com/waydevs/sections-api/internal/platform/ratelimit"
+)
+
+func TestDesignPatternRoutes_RateLimited(t *testing.T) {
+	// The steps share the limiters, so each one sees the requests of the previous ones.
+	steps := []struct {
+		name               string
+		method             string
+		authorization      string
+		expectedStatus     int
+		expectedLimit      string
+		expectedRemaining  string
+		expectedReset      string
+		expectedRetryAfter string
+		expectedResponse   string
+	}{
+		{
+			name:              "Ok - Read",
+			method:            http.MethodGet,
+			expectedStatus:    200,
+			expectedLimit:     "2",
+			expectedRemaining: "1",
+			expectedReset:     "1800",
+		},
+		{
+			name:              "Ok - Last read",
+			method:            http.MethodGet,
+			expectedStatus:    200,
+			expectedLimit:     "2",
+			expectedRemaining: "0",
+			expectedReset:     "3600",
+		},
+		{
+			name:               "Too Many Requests - Read",
+			method:             http.MethodGet,
+			expectedStatus:     429,
+			expectedLimit:      "2",
+			expectedRemaining:  "0",
+			expectedReset:      "3600",
+			expectedRetryAfter: "1800",
+			expectedResponse:   `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"Too many requests, retry in 1800 seconds","instance":"/designpatters/ok","code":"rate_limited"}`,
+		},
+		{
+			name:              "Ok - Read as a principal",
+			method:            http.MethodGet,
+			authorization:     "Bearer reader",
+			expectedStatus:    200,
+			expectedLimit:     "2",
+			expectedRemaining: "1",
+			expectedReset:     "1800",
+		},
+		{
+			name:               "Too Many Requests - Read with an invalid token",
+			method:             http.MethodGet,
+			authorization:      "Bearer forged",
+			expectedStatus:     429,
+			expectedLimit:      "2",
+			expectedRemaining:  "0",
+			expectedReset:      "3600",
+			expectedRetryAfter: "1800",
+			expectedResponse:   `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"Too many requests, retry in 1800 seconds","instance":"/designpatters/ok","code":"rate_limited"}`,
+		},
+		{
+			// API keys have a limit of their own, apart from the IP address they come from.
+			name:              "Ok - Read with an API key",
+			method:            http.MethodGet,
+			authorization:     "ApiKey sak_drafts",
+			expectedStatus:    200,
+			expectedLimit:     "2",
+			expectedRemaining: "1",
+			expectedReset:     "1800",
+		},
+		{
+			name:              "Ok - Write",
+			method:            http.MethodDelete,
+			authorization:     "Bearer admin",
+			expectedStatus:    200,
+			expectedLimit:     "1",
+			expectedRemaining: "0",
+			expectedReset:     "3600",
+		},
+		{
+			name:               "Too Many Requests - Write",
+			method:             http.MethodDelete,
+			authorization:      "Bearer admin",
+			expectedStatus:     429,
+			expectedLimit:      "1",
+			expectedRemaining:  "0",
+			expectedReset:      "3600",
+			expectedRetryAfter: "3600",
+			expectedResponse:   `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"Too many requests, retry in 3600 seconds","instance":"/designpatters/ok","code":"rate_limited"}`,
+		},
+	}
+
+	app := gin.Default()
+	app = DesignPatternRoutes(app, &designPatternServiceMock{},
+		Guarded(testGuard(t)),
+		RateLimited(
+			ratelimit.NewLimiter(ratelimit.Limit{Requests: 2, Period: time.Hour}),
+			ratelimit.NewLimiter(ratelimit.Limit{Requests: 1, Period: time.Hour}),
+		),
+	)
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			r := httptest.NewRequest(step.method, "/"+designPattersGroup+"/ok", nil)
+			if step.authorization != "" {
+				r.Header.Set("Authorization", step.authorization)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, step.expectedStatus, resp.StatusCode)
+			require.Equal(t, step.expectedLimit, resp.Header.Get(rateLimitLimitHeader))
+			require.Equal(t, step.expectedRemaining, resp.Header.Get(rateLimitRemainingHeader))
+			require.Equal(t, step.expectedReset, resp.Header.Get(rateLimitResetHeader))
+			require.Equal(t, step.expectedRetryAfter, resp.Header.Get(retryAfterHeader))
+			if step.expectedResponse != "" {
+				require.Equal(t, step.expectedResponse, string(body))
+			}
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+// keyCounterMock authenticates the keys "sak_a" and "sak_b", counting how many keys it looks
+// up.
+type keyCounterMock struct {
+	lookups *atomic.Int64
+}
+
+func (k keyCounterMock) Authenticate(_ context.Context, secret string) (auth.Principal, error) {
+	k.lookups.Add(1)
+	switch secret {
+	case "sak_a", "sak_b":
+		return auth.Principal{Subject: "apikey:" + secret}, nil
+	default:
+		return auth.Principal{}, apikeys.ErrInvalidKey
+	}
+}
+
+func TestDesignPatternRoutes_RateLimitedAPIKeys(t *testing.T) {
+	// The steps come from the same IP address and share the limiter.
+	steps := []struct {
+		name            string
+		authorization   string
+		expectedStatus  int
+		expectedLookups int64
+	}{
+		{name: "Ok - First key", authorization: "ApiKey sak_a", expectedStatus: 200, expectedLookups: 1},
+		{name: "Too Many Requests - First key again", authorization: "ApiKey sak_a", expectedStatus: 429, expectedLookups: 2},
+		{name: "Ok - Second key", authorization: "ApiKey sak_b", expectedStatus: 200, expectedLookups: 3},
+		{name: "Ok - Invalid key read anonymously", authorization: "ApiKey sak_guess", expectedStatus: 200, expectedLookups: 4},
+		{name: "Too Many Requests - Invalid key not looked up", authorization: "ApiKey sak_other", expectedStatus: 429, expectedLookups: 4},
+	}
+
+	lookups := &atomic.Int64{}
+	guard := NewGuard(tokenVerifierMock{}, &auth.Policy{}, AcceptAPIKeys(keyCounterMock{lookups: lookups}))
+	app := gin.Default()
+	app = DesignPatternRoutes(app, &designPatternServiceMock{},
+		Guarded(guard),
+		RateLimited(ratelimit.NewLimiter(ratelimit.Limit{Requests: 1, Period: time.Hour}), nil),
+	)
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/"+designPattersGroup+"/ok", nil)
+			r.Header.Set("Authorization", step.authorization)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			require.Equal(t, step.expectedStatus, rr.Code)
+			require.Equal(t, step.expectedLookups, lookups.Load())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	renderQuery = "render"
+	renderHTML  = "html"
+)
+
+var errInvalidRender = badRequestError(errors.New("Invalid render, use html"))
+
+// renderRequested reports whether the request asks for the markdown of the content rendered to
+// HTML with ?render=html.
+func renderRequested(c *gin.Context) (bool, error) {
+	switch c.Query(renderQuery) {
+	case "":
+		return false, nil
+	case renderHTML:
+		return true, nil
+	}
+
+	return false, errInvalidRender
+}
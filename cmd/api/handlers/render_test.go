@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/markdown"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// renderedDesignPattern is a DesignPattern with a markdown block, rendered when html is true.
+func renderedDesignPattern(html bool) designpatters.DesignPattern {
+	designPattern := designpatters.DesignPattern{
+		Title:       "Singleton",
+		ContentData: []repository.Content{{Type: repository.BlockMarkdown, Text: "## Uso"}},
+		Version:     2,
+	}
+	if html {
+		designPattern.ContentData[0].HTML = `<h2 id="uso">Uso</h2>`
+		designPattern.TableOfContents = []markdown.Heading{{Level: 2, ID: "uso", Text: "Uso"}}
+	}
+
+	return designPattern
+}
+
+func TestDesignPatternsHandler_Render(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Get rendered Design Pattern",
+			path:             "/rendered?render=html",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Singleton","subtitle":"","contentData":[{"type":"markdown","text":"## Uso","html":"\u003ch2 id=\"uso\"\u003eUso\u003c/h2\u003e"}],"tableOfContents":[{"level":2,"id":"uso","text":"Uso"}],"version":2,"status":""}}`,
+		},
+		{
+			name:             "Ok - Get Design Pattern without rendering",
+			path:             "/rendered",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"","title":"Singleton","subtitle":"","contentData":[{"type":"markdown","text":"## Uso"}],"version":2,"status":""}}`,
+		},
+		{
+			name:             "Ok - List rendered Design Patterns",
+			path:             "?title=rendered&render=html",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"id":"","slug":"","title":"Singleton","subtitle":"","contentData":[{"type":"markdown","text":"## Uso","html":"\u003ch2 id=\"uso\"\u003eUso\u003c/h2\u003e"}],"tableOfContents":[{"level":2,"id":"uso","text":"Uso"}],"version":2,"status":""}],"meta":{"page":1,"limit":20,"total":1,"totalPages":1}}`,
+		},
+		{
+			name:             "Bad Request - Invalid render",
+			path:             "/rendered?render=pdf",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid render, use html","instance":"/designpatters/rendered","code":"invalid_argument"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s%s", designPattersGroup, tt.path), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+			require.NoError(t, resp.Body.Close())
+		})
+	}
+}
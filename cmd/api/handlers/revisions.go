@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+)
+
+const (
+	revisionNumberParam = "number"
+
+	authorHeader     = "X-Author"
+	changeNoteHeader = "X-Change-Note"
+
+	// anonymousAuthor is the author of unauthenticated changes made without an X-Author
+	// header.
+	anonymousAuthor = "anonymous"
+)
+
+// changeContext returns the context of the request carrying the author and the note of the
+// change it makes, taken from the X-Author and X-Change-Note headers. X-Author is not
+// authenticated, so it only names the author of unauthenticated requests:
+// designpatters.ChangeFrom replaces it with the subject of authenticated ones.
+func changeContext(c *gin.Context) context.Context {
+	change := designpatters.Change{
+		Author: strings.TrimSpace(c.GetHeader(authorHeader)),
+		Note:   strings.TrimSpace(c.GetHeader(changeNoteHeader)),
+	}
+	if change.Author == "" {
+		change.Author = anonymousAuthor
+	}
+
+	return designpatters.WithChange(c.Request.Context(), change)
+}
+
+func (s DesignPatternsHandler) ListRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	var params designpatters.RevisionsParams
+	var err error
+	if params.Page, err = intQuery(c, "page"); err == nil {
+		params.Limit, err = intQuery(c, "limit")
+	}
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result, err := s.service.ListRevisions(ctx, id, params, opts)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    result.Items,
+		Meta:    newMeta(result.Page, result.Limit, result.Total),
+	})
+}
+
+func (s DesignPatternsHandler) GetRevision(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	number, err := revisionNumber(c.Param(revisionNumberParam), revisionNumberParam)
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.service.GetRevision(ctx, id, number, opts)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+// DiffRevisions shows what changed between the revisions in the from and to query
+// parameters.
+func (s DesignPatternsHandler) DiffRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	from, err := revisionNumber(c.Query("from"), "from")
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+	to, err := revisionNumber(c.Query("to"), "to")
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.service.DiffRevisions(ctx, id, from, to, opts)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+// RollbackRevision restores the content of a previous revision as a new revision.
+func (s DesignPatternsHandler) RollbackRevision(c *gin.Context) {
+	ctx := changeContext(c)
+	id := c.Param(desingPatternIDParam)
+
+	number, err := revisionNumber(c.Param(revisionNumberParam), revisionNumberParam)
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.service.Rollback(ctx, id, number, version)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: fmt.Sprintf("Design pattern rolled back to revision %d", number),
+		Data:    response,
+	})
+}
+
+func revisionNumber(value, key string) (int64, error) {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 1 {
+		return 0, fmt.Errorf("Invalid %s, it must be a revision number", key)
+	}
+
+	return number, nil
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/designpatters"
+)
+
+func TestChangeContext(t *testing.T) {
+	tt := []struct {
+		name           string
+		headers        map[string]string
+		expectedChange designpatters.Change
+	}{
+		{
+			name:           "Without headers",
+			headers:        map[string]string{},
+			expectedChange: designpatters.Change{Author: "anonymous"},
+		},
+		{
+			name:           "With headers",
+			headers:        map[string]string{"X-Author": " ana ", "X-Change-Note": "Fix typo"},
+			expectedChange: designpatters.Change{Author: "ana", Note: "Fix typo"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			for key, value := range tc.headers {
+				c.Request.Header.Set(key, value)
+			}
+
+			require.Equal(t, tc.expectedChange, designpatters.ChangeFrom(changeContext(c)))
+		})
+	}
+}
+
+func TestDesignPatternsHandler_Revisions(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		headers          map[string]string
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - List Revisions",
+			method:           http.MethodGet,
+			path:             "/ok/revisions",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"number":2,"snapshot":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":2,"status":""},"author":"ana","note":"Typo","createdAt":"2023-01-02T03:04:05Z"}],"meta":{"page":1,"limit":20,"total":2,"totalPages":1}}`,
+		},
+		{
+			name:             "Bad Request - List Revisions",
+			method:           http.MethodGet,
+			path:             "/ok/revisions?limit=-1",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid limit, it must be a non-negative integer","instance":"/designpatters/ok/revisions","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Invalid ID - List Revisions",
+			method:           http.MethodGet,
+			path:             "/invalid_id/revisions",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid Design Pattern id","instance":"/designpatters/invalid_id/revisions","code":"invalid_id"}`,
+		},
+		{
+			name:             "Not Found - List Revisions of a draft",
+			method:           http.MethodGet,
+			path:             "/draft/revisions",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/draft/revisions","code":"not_found"}`,
+		},
+		{
+			name:             "Ok - Get Revision",
+			method:           http.MethodGet,
+			path:             "/ok/revisions/1",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"number":1,"snapshot":{"id":"","slug":"","title":"Design Pattern","subtitle":"","contentData":null,"version":1,"status":""},"author":"ana","note":"","createdAt":"2023-01-02T03:04:05Z"}}`,
+		},
+		{
+			name:             "Bad Request - Get Revision",
+			method:           http.MethodGet,
+			path:             "/ok/revisions/0",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid number, it must be a revision number","instance":"/designpatters/ok/revisions/0","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Not Found - Get Revision",
+			method:           http.MethodGet,
+			path:             "/ok/revisions/9",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Revision not found","instance":"/designpatters/ok/revisions/9","code":"not_found"}`,
+		},
+		{
+			name:             "Not Found - Get Revision of a draft",
+			method:           http.MethodGet,
+			path:             "/draft/revisions/1",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/draft/revisions/1","code":"not_found"}`,
+		},
+		{
+			name:             "Ok - Diff Revisions",
+			method:           http.MethodGet,
+			path:             "/ok/revisions/diff?from=1&to=2",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"from":1,"to":2,"fields":[{"field":"title","from":"Design Pattern","to":"Singleton"}],"blocks":[{"index":0,"change":"removed","from":{"type":"markdown","title":"Uso","text":"Una instancia"}}]}}`,
+		},
+		{
+			name:             "Bad Request - Diff Revisions",
+			method:           http.MethodGet,
+			path:             "/ok/revisions/diff?from=1",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid to, it must be a revision number","instance":"/designpatters/ok/revisions/diff","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Not Found - Diff Revisions",
+			method:           http.MethodGet,
+			path:             "/ok/revisions/diff?from=1&to=9",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Revision not found","instance":"/designpatters/ok/revisions/diff","code":"not_found"}`,
+		},
+		{
+			name:             "Not Found - Diff Revisions of a draft",
+			method:           http.MethodGet,
+			path:             "/draft/revisions/diff?from=1&to=2",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/draft/revisions/diff","code":"not_found"}`,
+		},
+		{
+			name:             "Ok - Rollback",
+			method:           http.MethodPost,
+			path:             "/ok/revisions/1/rollback",
+			headers:          map[string]string{"X-Author": "ana", "X-Change-Note": "Undo", "If-Match": `"3"`},
+			expectedStatus:   200,
+			expectedETag:     `"4"`,
+			expectedResponse: `{"status":200,"message":"Design pattern rolled back to revision 1","data":{"id":"","slug":"","title":"Design Pattern","subtitle":"ana: Undo","contentData":null,"version":4,"status":""}}`,
+		},
+		{
+			name:             "Precondition Failed - Rollback",
+			method:           http.MethodPost,
+			path:             "/ok/revisions/1/rollback",
+			headers:          map[string]string{"If-Match": fmt.Sprintf(`"%d"`, staleVersion)},
+			expectedStatus:   412,
+			expectedResponse: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"Design Pattern was modified, fetch it again and retry","instance":"/designpatters/ok/revisions/1/rollback","code":"version_mismatch"}`,
+		},
+		{
+			name:             "Not Found - Rollback",
+			method:           http.MethodPost,
+			path:             "/ok/revisions/9/rollback",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Revision not found","instance":"/designpatters/ok/revisions/9/rollback","code":"not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(tt.method, fmt.Sprintf("/%s%s", designPattersGroup, tt.path), nil)
+			require.NoError(t, err)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
@@ -3,31 +3,198 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/apikeys"
 	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/media"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"github.com/waydevs/sections-api/internal/platform/health"
+	"github.com/waydevs/sections-api/internal/platform/ratelimit"
+	"github.com/waydevs/sections-api/internal/sections"
 )
 
 const (
 	designPattersGroup   = "designpatters"
 	desingPatternIDParam = "id"
+	slugParam            = "slug"
+	sectionIDParam       = "id"
 )
 
 type DesignPatternService interface {
-	GetByID(ctx context.Context, id string) (designpatters.DesignPattern, error)
+	GetByID(ctx context.Context, id string, opts designpatters.ReadOptions) (designpatters.DesignPattern, error)
+	List(ctx context.Context, params designpatters.ListParams) (designpatters.ListResult, error)
+	Search(ctx context.Context, params designpatters.SearchParams) (designpatters.SearchResult, error)
 	Create(ctx context.Context, designPattern designpatters.DesignPattern) (designpatters.DesignPattern, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, version int64) error
 	Update(ctx context.Context, designPattern designpatters.DesignPattern) (designpatters.DesignPattern, error)
+	Patch(ctx context.Context, id string, patch designpatters.Patch) (designpatters.DesignPattern, error)
+	ListTrash(ctx context.Context, params designpatters.TrashParams) (designpatters.ListResult, error)
+	Restore(ctx context.Context, id string) (designpatters.DesignPattern, error)
+	Purge(ctx context.Context, id string) error
+	ListRevisions(ctx context.Context, id string, params designpatters.RevisionsParams, opts designpatters.ReadOptions) (designpatters.RevisionsResult, error)
+	GetRevision(ctx context.Context, id string, number int64, opts designpatters.ReadOptions) (designpatters.Revision, error)
+	DiffRevisions(ctx context.Context, id string, from, to int64, opts designpatters.ReadOptions) (designpatters.Diff, error)
+	Rollback(ctx context.Context, id string, number, version int64) (designpatters.DesignPattern, error)
+	Transition(ctx context.Context, id string, transition designpatters.Transition) (designpatters.DesignPattern, error)
+	GetBySlug(ctx context.Context, slug string, opts designpatters.ReadOptions) (designpatters.DesignPattern, error)
+	RenameSlug(ctx context.Context, id, slug string, version int64) (designpatters.DesignPattern, error)
+	GetTranslation(ctx context.Context, id, locale string, opts designpatters.ReadOptions) (designpatters.Translation, error)
+	PutTranslation(ctx context.Context, id string, translation designpatters.Translation, version int64) (designpatters.Translation, error)
+	DeleteTranslation(ctx context.Context, id, locale string, version int64) error
+	TranslationReport(ctx context.Context, id string, opts designpatters.ReadOptions) (designpatters.TranslationReport, error)
+	Tags(ctx context.Context, params designpatters.TagsParams) ([]designpatters.TagCount, error)
+	CacheStats() designpatters.CacheStats
+}
+
+type SectionService interface {
+	Kind() sections.Kind
+	GetByID(ctx context.Context, id string) (sections.Section, error)
+	List(ctx context.Context, params sections.ListParams) (sections.ListResult, error)
+	Create(ctx context.Context, section sections.Section) (sections.Section, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, section sections.Section) (sections.Section, error)
+}
+
+type MediaService interface {
+	Upload(ctx context.Context, filename string, content io.Reader) (media.Media, bool, error)
+	Open(ctx context.Context, id string) (media.Media, io.ReadSeekCloser, error)
+}
+
+type APIKeyService interface {
+	List(ctx context.Context) ([]apikeys.Key, error)
+	Issue(ctx context.Context, params apikeys.IssueParams) (apikeys.Issued, error)
+	Rotate(ctx context.Context, id string) (apikeys.Issued, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type HealthChecker interface {
+	Check(ctx context.Context) health.Report
 }
 
-func DesignPatternRoutes(router *gin.Engine, service DesignPatternService) *gin.Engine {
-	group := router.Group(designPattersGroup)
+// RouteOption configures the routes registered by DesignPatternRoutes.
+type RouteOption func(*DesignPatternsHandler)
 
+// RequireIfMatch makes updates and deletes without an If-Match header fail with 428
+// Precondition Required, instead of overwriting whatever version is stored.
+func RequireIfMatch(require bool) RouteOption {
+	return func(handler *DesignPatternsHandler) {
+		handler.requireIfMatch = require
+	}
+}
+
+// Guarded makes the routes that change Design Patterns, the trash and the cache stats
+// require a principal authenticated by guard with the permission of each action. Other reads
+// stay public.
+func Guarded(guard *Guard) RouteOption {
+	return func(handler *DesignPatternsHandler) {
+		handler.guard = guard
+	}
+}
+
+// RateLimited limits the requests each client makes to the Design Pattern routes, reads with
+// read and writes with write. Nil limiters don't limit.
+func RateLimited(read, write *ratelimit.Limiter) RouteOption {
+	return func(handler *DesignPatternsHandler) {
+		handler.readLimiter = read
+		handler.writeLimiter = write
+	}
+}
+
+func DesignPatternRoutes(router *gin.Engine, service DesignPatternService, opts ...RouteOption) *gin.Engine {
 	handler := NewDesignPatternsHandler(service)
+	for _, opt := range opts {
+		opt(&handler)
+	}
+	require := handler.guard.Require
+
+	group := router.Group(designPattersGroup, rateLimit(handler.guard, handler.readLimiter, handler.writeLimiter))
+
+	group.GET("", handler.ListPatterns)
+	group.GET("/search", handler.SearchPatterns)
+	group.GET("/trash", require(auth.DeletePatterns), handler.ListTrash)
+	group.GET("/tags", handler.ListTags)
+	group.GET("/cache", require(auth.ReadStats), handler.GetCacheStats)
+	group.GET(fmt.Sprintf("/by-slug/:%s", slugParam), handler.GetPatternBySlug)
 	group.GET(fmt.Sprintf("/:%s", desingPatternIDParam), handler.GetPatternByID)
-	group.POST("", handler.CreatePattern)
-	group.DELETE(fmt.Sprintf("/:%s", desingPatternIDParam), handler.DeletePattern)
-	group.PUT("", handler.UpdatePattern)
+	group.POST("", require(auth.CreatePatterns), handler.CreatePattern)
+	group.DELETE(fmt.Sprintf("/:%s", desingPatternIDParam), require(auth.DeletePatterns), handler.DeletePattern)
+	group.PUT("", require(auth.UpdatePatterns), handler.UpdatePattern)
+	group.PATCH(fmt.Sprintf("/:%s", desingPatternIDParam), require(auth.UpdatePatterns), handler.PatchPattern)
+	group.POST(fmt.Sprintf("/:%s/restore", desingPatternIDParam), require(auth.DeletePatterns), handler.RestorePattern)
+	group.DELETE(fmt.Sprintf("/trash/:%s", desingPatternIDParam), require(auth.PurgePatterns), handler.PurgePattern)
+	group.PUT(fmt.Sprintf("/:%s/status", desingPatternIDParam), require(""), handler.TransitionPattern)
+	group.PUT(fmt.Sprintf("/:%s/slug", desingPatternIDParam), require(auth.UpdatePatterns), handler.RenameSlug)
+
+	revisions := group.Group(fmt.Sprintf("/:%s/revisions", desingPatternIDParam))
+	revisions.GET("", handler.ListRevisions)
+	revisions.GET("/diff", handler.DiffRevisions)
+	revisions.GET(fmt.Sprintf("/:%s", revisionNumberParam), handler.GetRevision)
+	revisions.POST(fmt.Sprintf("/:%s/rollback", revisionNumberParam), require(auth.UpdatePatterns), handler.RollbackRevision)
+
+	translations := group.Group(fmt.Sprintf("/:%s/translations", desingPatternIDParam))
+	translations.GET("", handler.GetTranslationReport)
+	translations.GET(fmt.Sprintf("/:%s", localeParam), handler.GetTranslation)
+	translations.PUT(fmt.Sprintf("/:%s", localeParam), require(auth.UpdatePatterns), handler.PutTranslation)
+	translations.DELETE(fmt.Sprintf("/:%s", localeParam), require(auth.UpdatePatterns), handler.DeleteTranslation)
+
+	return router
+}
+
+// SectionRoutes registers the CRUD routes of every section kind under a group named after
+// the kind, e.g. /algorithms. Writes require the write:sections permission from guard, unless
+// it is nil.
+func SectionRoutes(router *gin.Engine, guard *Guard, services ...SectionService) *gin.Engine {
+	write := guard.Require(auth.WriteSections)
+	for _, service := range services {
+		group := router.Group(service.Kind().Name)
+
+		handler := NewSectionsHandler(service)
+		group.GET("", handler.ListSections)
+		group.GET(fmt.Sprintf("/:%s", sectionIDParam), handler.GetSectionByID)
+		group.POST("", write, handler.CreateSection)
+		group.DELETE(fmt.Sprintf("/:%s", sectionIDParam), write, handler.DeleteSection)
+		group.PUT("", write, handler.UpdateSection)
+	}
+
+	return router
+}
+
+// MediaRoutes registers the upload of media and the routes serving their content, which
+// clients may cache for cacheMaxAge. Uploads require the upload:media permission from guard,
+// unless it is nil.
+func MediaRoutes(router *gin.Engine, service MediaService, cacheMaxAge time.Duration, guard *Guard) *gin.Engine {
+	group := router.Group(mediaGroup)
+
+	handler := NewMediaHandler(service, cacheMaxAge)
+	group.POST("", guard.Require(auth.UploadMedia), handler.UploadMedia)
+	group.GET(fmt.Sprintf("/:%s", mediaIDParam), handler.GetMedia)
+	group.HEAD(fmt.Sprintf("/:%s", mediaIDParam), handler.GetMedia)
+
+	return router
+}
+
+// APIKeyRoutes registers the routes admins manage API keys with, which require the
+// manage:apikeys permission from guard.
+func APIKeyRoutes(router *gin.Engine, service APIKeyService, guard *Guard) *gin.Engine {
+	group := router.Group(apiKeysGroup, guard.Require(auth.ManageAPIKeys))
+
+	handler := NewAPIKeysHandler(service)
+	group.GET("", handler.ListAPIKeys)
+	group.POST("", handler.IssueAPIKey)
+	group.POST(fmt.Sprintf("/:%s/rotate", apiKeyIDParam), handler.RotateAPIKey)
+	group.DELETE(fmt.Sprintf("/:%s", apiKeyIDParam), handler.RevokeAPIKey)
+
+	return router
+}
+
+// HealthRoutes registers the liveness (/healthz) and readiness (/readyz) probes.
+func HealthRoutes(router *gin.Engine, checker HealthChecker) *gin.Engine {
+	handler := NewHealthHandler(checker)
+	router.GET("/healthz", handler.Liveness)
+	router.GET("/readyz", handler.Readiness)
 
 	return router
 }
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/sections"
+)
+
+type SectionsHandler struct {
+	service SectionService
+}
+
+func NewSectionsHandler(service SectionService) SectionsHandler {
+	return SectionsHandler{
+		service: service,
+	}
+}
+
+func (s SectionsHandler) GetSectionByID(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(sectionIDParam)
+
+	response, err := s.service.GetByID(ctx, id)
+
+	if err != nil {
+		respondError(c, sectionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+func (s SectionsHandler) ListSections(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	params := sections.ListParams{
+		Sort:  c.Query("sort"),
+		Title: c.Query("title"),
+	}
+
+	var err error
+	if params.Page, err = intQuery(c, "page"); err == nil {
+		params.Limit, err = intQuery(c, "limit")
+	}
+	if err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	result, err := s.service.List(ctx, params)
+
+	if err != nil {
+		respondError(c, sectionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    result.Items,
+		Meta:    newMeta(result.Page, result.Limit, result.Total),
+	})
+}
+
+func (s SectionsHandler) CreateSection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request sections.Section
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	response, err := s.service.Create(ctx, request)
+
+	if err != nil {
+		respondError(c, sectionError(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, Response{
+		Status:  http.StatusCreated,
+		Message: "",
+		Data:    response,
+	})
+}
+
+func (s SectionsHandler) DeleteSection(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(sectionIDParam)
+
+	err := s.service.Delete(ctx, id)
+
+	if err != nil {
+		respondError(c, sectionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "Section deleted successfully",
+		Data:    nil,
+	})
+}
+
+func (s SectionsHandler) UpdateSection(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request sections.Section
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	response, err := s.service.Update(ctx, request)
+
+	if err != nil {
+		respondError(c, sectionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+// sectionError maps the errors of the Section services to the codes of the problem details.
+func sectionError(err error) error {
+	switch {
+	case errors.Is(err, sections.ErrSectionNotFound):
+		return requestError{code: designpatters.CodeNotFound, error: err}
+	case errors.Is(err, sections.ErrInvalidID):
+		return requestError{code: designpatters.CodeInvalidID, error: err}
+	case errors.Is(err, sections.ErrInvalidSort):
+		return badRequestError(err)
+	}
+
+	return err
+}
@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/sections"
+)
+
+type sectionServiceMock struct {
+	kind sections.Kind
+}
+
+func (s *sectionServiceMock) Kind() sections.Kind {
+	return s.kind
+}
+
+func (s *sectionServiceMock) GetByID(ctx context.Context, id string) (sections.Section, error) {
+	switch id {
+	case "ok":
+		return sections.Section{
+			Kind:  s.kind.Name,
+			Title: "Section",
+		}, nil
+
+	case "not_found":
+		return sections.Section{}, sections.ErrSectionNotFound
+
+	case "invalid_id":
+		return sections.Section{}, sections.ErrInvalidID
+
+	default:
+		return sections.Section{}, errors.New("unexpected error")
+	}
+}
+
+func (s *sectionServiceMock) List(ctx context.Context, params sections.ListParams) (sections.ListResult, error) {
+	switch params.Title {
+	case "ok":
+		return sections.ListResult{
+			Items: []sections.Section{
+				{Kind: s.kind.Name, Title: "Section"},
+			},
+			Page:  1,
+			Limit: 20,
+			Total: 1,
+		}, nil
+	case "invalid_sort":
+		return sections.ListResult{}, sections.ErrInvalidSort
+	default:
+		return sections.ListResult{}, errors.New("unexpected error")
+	}
+}
+
+func (s *sectionServiceMock) Create(ctx context.Context, section sections.Section) (sections.Section, error) {
+	switch section.Title {
+	case "ok":
+		return sections.Section{
+			Kind:  s.kind.Name,
+			Title: "Section",
+		}, nil
+	default:
+		return sections.Section{}, errors.New("unexpected error")
+	}
+}
+
+func (s *sectionServiceMock) Delete(ctx context.Context, id string) error {
+	switch id {
+	case "ok":
+		return nil
+	case "not_found":
+		return sections.ErrSectionNotFound
+	case "invalid_id":
+		return sections.ErrInvalidID
+	default:
+		return errors.New("unexpected error")
+	}
+}
+
+func (s *sectionServiceMock) Update(ctx context.Context, section sections.Section) (sections.Section, error) {
+	switch section.Title {
+	case "ok":
+		return sections.Section{
+			Kind:  s.kind.Name,
+			Title: "Section",
+		}, nil
+	case "not_found":
+		return sections.Section{}, sections.ErrSectionNotFound
+	case "invalid_id":
+		return sections.Section{}, sections.ErrInvalidID
+	default:
+		return sections.Section{}, errors.New("unexpected error")
+	}
+}
+
+func TestNewSectionsHandler(t *testing.T) {
+	service := &sectionServiceMock{kind: sections.Algorithms}
+	handler := NewSectionsHandler(service)
+
+	assert.NotNil(t, handler)
+}
+
+func TestSectionRoutes(t *testing.T) {
+	app := gin.Default()
+	app = SectionRoutes(app, nil,
+		&sectionServiceMock{kind: sections.Algorithms},
+		&sectionServiceMock{kind: sections.AntiPatterns},
+	)
+
+	tests := []struct {
+		path             string
+		expectedResponse string
+	}{
+		{
+			path:             "/algorithms/ok",
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"kind\":\"algorithms\",\"title\":\"Section\",\"subtitle\":\"\",\"contentData\":null}}",
+		},
+		{
+			path:             "/anti-patterns/ok",
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"kind\":\"anti-patterns\",\"title\":\"Section\",\"subtitle\":\"\",\"contentData\":null}}",
+		},
+	}
+
+	for _, tt := range tests {
+		r, err := http.NewRequest(http.MethodGet, tt.path, nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		app.ServeHTTP(rr, r)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, tt.expectedResponse, rr.Body.String())
+	}
+}
+
+func TestSectionsHandler_GetSectionByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Get Section by ID",
+			id:               "ok",
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"kind\":\"algorithms\",\"title\":\"Section\",\"subtitle\":\"\",\"contentData\":null}}",
+		},
+		{
+			name:             "Not Found - Get Section by ID",
+			id:               "not_found",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Section not found","instance":"/algorithms/not_found","code":"not_found"}`,
+		},
+		{
+			name:             "Bad Request - Invalid ID",
+			id:               "invalid_id",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid section id","instance":"/algorithms/invalid_id","code":"invalid_id"}`,
+		},
+		{
+			name:             "Internal Server Error - Get Section by ID",
+			id:               "unexpected_error",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/algorithms/unexpected_error","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := serveSectionRequest(t, http.MethodGet, fmt.Sprintf("/algorithms/%s", tt.id), nil)
+
+			require.Equal(t, test.expectedStatus, resp.Code)
+			require.Equal(t, tt.expectedResponse, resp.Body.String())
+		})
+	}
+}
+
+func TestSectionsHandler_ListSections(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - List Sections",
+			query:            "title=ok",
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":[{\"id\":\"\",\"kind\":\"algorithms\",\"title\":\"Section\",\"subtitle\":\"\",\"contentData\":null}],\"meta\":{\"page\":1,\"limit\":20,\"total\":1,\"totalPages\":1}}",
+		},
+		{
+			name:             "Bad Request - Invalid page",
+			query:            "title=ok&page=x",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid page, it must be a non-negative integer","instance":"/algorithms","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Bad Request - Invalid sort",
+			query:            "title=invalid_sort",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid sort, use title or createdAt optionally prefixed with -","instance":"/algorithms","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Internal Server Error - List Sections",
+			query:            "title=unexpected_error",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/algorithms","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := serveSectionRequest(t, http.MethodGet, fmt.Sprintf("/algorithms?%s", tt.query), nil)
+
+			require.Equal(t, test.expectedStatus, resp.Code)
+			require.Equal(t, tt.expectedResponse, resp.Body.String())
+		})
+	}
+}
+
+func TestSectionsHandler_CreateSection(t *testing.T) {
+	tests := []struct {
+		name             string
+		bodyPost         sections.Section
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Create Section",
+			bodyPost:         sections.Section{Title: "ok"},
+			expectedStatus:   201,
+			expectedResponse: "{\"status\":201,\"message\":\"\",\"data\":{\"id\":\"\",\"kind\":\"algorithms\",\"title\":\"Section\",\"subtitle\":\"\",\"contentData\":null}}",
+		},
+		{
+			name:             "Internal Server Error - Create Section",
+			bodyPost:         sections.Section{Title: "unexpected_error"},
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/algorithms","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := serveSectionRequest(t, http.MethodPost, "/algorithms", tt.bodyPost)
+
+			require.Equal(t, test.expectedStatus, resp.Code)
+			require.Equal(t, tt.expectedResponse, resp.Body.String())
+		})
+	}
+}
+
+func TestSectionsHandler_DeleteSection(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Delete Section",
+			id:               "ok",
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"Section deleted successfully\",\"data\":null}",
+		},
+		{
+			name:             "Not Found - Delete Section",
+			id:               "not_found",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Section not found","instance":"/algorithms/not_found","code":"not_found"}`,
+		},
+		{
+			name:             "Bad Request - Invalid ID",
+			id:               "invalid_id",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid section id","instance":"/algorithms/invalid_id","code":"invalid_id"}`,
+		},
+		{
+			name:             "Internal Server Error - Delete Section",
+			id:               "unexpected_error",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/algorithms/unexpected_error","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := serveSectionRequest(t, http.MethodDelete, fmt.Sprintf("/algorithms/%s", tt.id), nil)
+
+			require.Equal(t, test.expectedStatus, resp.Code)
+			require.Equal(t, tt.expectedResponse, resp.Body.String())
+		})
+	}
+}
+
+func TestSectionsHandler_UpdateSection(t *testing.T) {
+	tests := []struct {
+		name             string
+		bodyPost         sections.Section
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Update Section",
+			bodyPost:         sections.Section{Title: "ok"},
+			expectedStatus:   200,
+			expectedResponse: "{\"status\":200,\"message\":\"\",\"data\":{\"id\":\"\",\"kind\":\"algorithms\",\"title\":\"Section\",\"subtitle\":\"\",\"contentData\":null}}",
+		},
+		{
+			name:             "Not Found - Update Section",
+			bodyPost:         sections.Section{Title: "not_found"},
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Section not found","instance":"/algorithms","code":"not_found"}`,
+		},
+		{
+			name:             "Bad Request - Invalid ID",
+			bodyPost:         sections.Section{Title: "invalid_id"},
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid section id","instance":"/algorithms","code":"invalid_id"}`,
+		},
+		{
+			name:             "Internal Server Error - Update Section",
+			bodyPost:         sections.Section{Title: "unexpected_error"},
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/algorithms","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := serveSectionRequest(t, http.MethodPut, "/algorithms", tt.bodyPost)
+
+			require.Equal(t, test.expectedStatus, resp.Code)
+			require.Equal(t, tt.expectedResponse, resp.Body.String())
+		})
+	}
+}
+
+func serveSectionRequest(t *testing.T, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	app := gin.Default()
+	app = SectionRoutes(app, nil, &sectionServiceMock{kind: sections.Algorithms})
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+
+	r, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	app.ServeHTTP(rr, r)
+
+	return rr
+}
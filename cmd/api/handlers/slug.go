@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// slugRequest is the body of a request renaming the slug of a DesignPattern.
+type slugRequest struct {
+	Slug string `json:"slug" binding:"required"`
+}
+
+// GetPatternBySlug returns a design pattern by its slug. Previous slugs redirect to the
+// current one with 301 Moved Permanently, so old links keep working.
+func (s DesignPatternsHandler) GetPatternBySlug(c *gin.Context) {
+	ctx := c.Request.Context()
+	slug := c.Param(slugParam)
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.service.GetBySlug(ctx, slug, opts)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if response.Slug != slug {
+		location := fmt.Sprintf("/%s/by-slug/%s", designPattersGroup, response.Slug)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	c.Header(contentLanguageHeader, response.Locale)
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+// RenameSlug changes the slug of a design pattern, keeping the previous one as an alias.
+func (s DesignPatternsHandler) RenameSlug(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var request slugRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	response, err := s.service.RenameSlug(ctx, id, request.Slug, version)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesignPatternsHandler_GetPatternBySlug(t *testing.T) {
+	tests := []struct {
+		name             string
+		path             string
+		expectedStatus   int
+		expectedLocation string
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Get Design Pattern by slug",
+			path:             "/by-slug/singleton",
+			expectedStatus:   200,
+			expectedETag:     `"2"`,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"singleton","slugAliases":["old-singleton"],"title":"Singleton","subtitle":"","contentData":null,"version":2,"status":""}}`,
+		},
+		{
+			name:             "Moved Permanently - Get Design Pattern by previous slug",
+			path:             "/by-slug/old-singleton?drafts=false",
+			expectedStatus:   301,
+			expectedLocation: "/designpatters/by-slug/singleton?drafts=false",
+			expectedResponse: "<a href=\"/designpatters/by-slug/singleton?drafts=false\">Moved Permanently</a>.\n\n",
+		},
+		{
+			name:             "Not Found - Get Design Pattern by slug",
+			path:             "/by-slug/not_found",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/by-slug/not_found","code":"not_found"}`,
+		},
+		{
+			name:             "Internal Server Error - Get Design Pattern by slug",
+			path:             "/by-slug/unexpected_error",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/by-slug/unexpected_error","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s%s", designPattersGroup, tt.path), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedLocation, resp.Header.Get("Location"))
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_RenameSlug(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		ifMatch          string
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Rename slug",
+			body:             `{"slug":"singleton-pattern"}`,
+			ifMatch:          `"2"`,
+			expectedStatus:   200,
+			expectedETag:     `"3"`,
+			expectedResponse: `{"status":200,"message":"","data":{"id":"","slug":"singleton-pattern","slugAliases":["singleton"],"title":"Singleton","subtitle":"","contentData":null,"version":3,"status":""}}`,
+		},
+		{
+			name:             "Bad Request - Missing slug",
+			body:             `{}`,
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Key: 'slugRequest.Slug' Error:Field validation for 'Slug' failed on the 'required' tag","instance":"/designpatters/ok/slug","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Conflict - Slug taken",
+			body:             `{"slug":"taken"}`,
+			expectedStatus:   409,
+			expectedResponse: `{"type":"about:blank","title":"Conflict","status":409,"detail":"Slug is already used by another Design Pattern","instance":"/designpatters/ok/slug","code":"conflict"}`,
+		},
+		{
+			name:             "Precondition Failed - Rename slug",
+			body:             `{"slug":"singleton-pattern"}`,
+			ifMatch:          fmt.Sprintf(`"%d"`, staleVersion),
+			expectedStatus:   412,
+			expectedResponse: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"Design Pattern was modified, fetch it again and retry","instance":"/designpatters/ok/slug","code":"version_mismatch"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/%s/ok/slug", designPattersGroup), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			r.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				r.Header.Set(ifMatchHeader, tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+)
+
+// ListTags returns the tags in use along with how many design patterns are tagged with each,
+// optionally only those in the category given by ?category=.
+func (s DesignPatternsHandler) ListTags(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result, err := s.service.Tags(ctx, designpatters.TagsParams{
+		Category:      designpatters.Category(c.Query("category")),
+		IncludeDrafts: opts.IncludeDrafts,
+	})
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    result,
+	})
+}
+
+// listQuery returns the comma-separated values of a query parameter, or nil when it is not
+// set.
+func listQuery(c *gin.Context, key string) []string {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
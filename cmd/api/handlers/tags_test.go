@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesignPatternsHandler_ListTags(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		authorization    string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - List tags",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"tag":"concurrency","count":2}]}`,
+		},
+		{
+			name:             "Ok - List tags with drafts",
+			query:            "?drafts=true",
+			authorization:    "Bearer editor",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":[{"tag":"concurrency","count":2},{"tag":"work in progress","count":1}]}`,
+		},
+		{
+			name:             "Bad Request - List tags by invalid category",
+			query:            "?category=functional",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid category, use creational, structural or behavioral","instance":"/designpatters/tags","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Internal Server Error - List tags",
+			query:            "?category=structural",
+			expectedStatus:   500,
+			expectedResponse: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/designpatters/tags","code":"internal"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{}, Guarded(testGuard(t)))
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/tags%s", designPattersGroup, tt.query), nil)
+			require.NoError(t, err)
+			if tt.authorization != "" {
+				r.Header.Set(authorizationHeader, tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
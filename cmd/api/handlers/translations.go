@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+const localeParam = "locale"
+
+// translationRequest is the body of a request creating or replacing a translation.
+type translationRequest struct {
+	Title       string               `json:"title"`
+	Subtitle    string               `json:"subtitle"`
+	ContentData []repository.Content `json:"contentData"`
+}
+
+// GetTranslationReport returns which locales a design pattern is missing or has out of date
+// relative to its source locale.
+func (s DesignPatternsHandler) GetTranslationReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.service.TranslationReport(ctx, id, opts)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+func (s DesignPatternsHandler) GetTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	opts, err := s.readOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.service.GetTranslation(ctx, id, c.Param(localeParam), opts)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(contentLanguageHeader, response.Locale)
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+// PutTranslation creates or replaces the translation of a design pattern to a locale.
+func (s DesignPatternsHandler) PutTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var request translationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, badRequestError(err))
+		return
+	}
+
+	response, err := s.service.PutTranslation(ctx, id, designpatters.Translation{
+		Locale:      c.Param(localeParam),
+		Title:       request.Title,
+		Subtitle:    request.Subtitle,
+		ContentData: request.ContentData,
+	}, version)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header(contentLanguageHeader, response.Locale)
+	c.Header(etagHeader, etag(response.Version))
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "",
+		Data:    response,
+	})
+}
+
+func (s DesignPatternsHandler) DeleteTranslation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param(desingPatternIDParam)
+
+	version, err := ifMatchVersion(c, s.requireIfMatch)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	err = s.service.DeleteTranslation(ctx, id, c.Param(localeParam), version)
+
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Status:  http.StatusOK,
+		Message: "Translation deleted successfully",
+		Data:    nil,
+	})
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesignPatternsHandler_GetTranslationReport(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Get translation report",
+			id:               "ok",
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"","data":{"sourceLocale":"es","locales":[{"locale":"en","status":"outdated","updatedAt":"2023-01-02T03:04:05Z"},{"locale":"fr","status":"missing"}]}}`,
+		},
+		{
+			name:             "Not Found - Get translation report",
+			id:               "not_found",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/not_found/translations","code":"not_found"}`,
+		},
+		{
+			name:             "Not Found - Get translation report of a draft",
+			id:               "draft",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/draft/translations","code":"not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/%s/translations", designPattersGroup, tt.id), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_GetTranslation(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		locale           string
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Get translation",
+			id:               "ok",
+			locale:           "en",
+			expectedStatus:   200,
+			expectedETag:     `"2"`,
+			expectedResponse: `{"status":200,"message":"","data":{"locale":"en","title":"Singleton","subtitle":"","contentData":null,"status":"current","updatedAt":"2023-01-02T03:04:05Z","version":2}}`,
+		},
+		{
+			name:             "Not Found - Get missing translation",
+			id:               "ok",
+			locale:           "fr",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Translation not found","instance":"/designpatters/ok/translations/fr","code":"not_found"}`,
+		},
+		{
+			name:             "Bad Request - Get translation to unsupported locale",
+			id:               "ok",
+			locale:           "de",
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Unsupported locale, use one of en, fr","instance":"/designpatters/ok/translations/de","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Not Found - Get translation of a draft",
+			id:               "draft",
+			locale:           "en",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Design Pattern not found","instance":"/designpatters/draft/translations/en","code":"not_found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/%s/%s/translations/%s", designPattersGroup, tt.id, tt.locale), nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_PutTranslation(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		ifMatch          string
+		expectedStatus   int
+		expectedETag     string
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Put translation",
+			body:             `{"title":"Singleton","subtitle":"A single instance"}`,
+			ifMatch:          `"2"`,
+			expectedStatus:   200,
+			expectedETag:     `"3"`,
+			expectedResponse: `{"status":200,"message":"","data":{"locale":"en","title":"Singleton","subtitle":"A single instance","contentData":null,"status":"current","updatedAt":"2023-01-02T03:04:05Z","version":3}}`,
+		},
+		{
+			name:             "Bad Request - Invalid body",
+			body:             `{"title":`,
+			expectedStatus:   400,
+			expectedResponse: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"unexpected EOF","instance":"/designpatters/ok/translations/en","code":"invalid_argument"}`,
+		},
+		{
+			name:             "Unprocessable Entity - Missing title",
+			body:             `{}`,
+			expectedStatus:   422,
+			expectedResponse: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"Invalid Design Pattern","instance":"/designpatters/ok/translations/en","code":"validation_failed","errors":[{"field":"title","message":"is required"}]}`,
+		},
+		{
+			name:             "Precondition Failed - Put translation",
+			body:             `{"title":"Singleton"}`,
+			ifMatch:          fmt.Sprintf(`"%d"`, staleVersion),
+			expectedStatus:   412,
+			expectedResponse: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"Design Pattern was modified, fetch it again and retry","instance":"/designpatters/ok/translations/en","code":"version_mismatch"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/%s/ok/translations/en", designPattersGroup), strings.NewReader(tt.body))
+			require.NoError(t, err)
+			r.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				r.Header.Set(ifMatchHeader, tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedETag, resp.Header.Get(etagHeader))
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDesignPatternsHandler_DeleteTranslation(t *testing.T) {
+	tests := []struct {
+		name             string
+		locale           string
+		ifMatch          string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Ok - Delete translation",
+			locale:           "en",
+			ifMatch:          `"2"`,
+			expectedStatus:   200,
+			expectedResponse: `{"status":200,"message":"Translation deleted successfully","data":null}`,
+		},
+		{
+			name:             "Not Found - Delete missing translation",
+			locale:           "fr",
+			expectedStatus:   404,
+			expectedResponse: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Translation not found","instance":"/designpatters/ok/translations/fr","code":"not_found"}`,
+		},
+		{
+			name:             "Precondition Failed - Delete translation",
+			locale:           "en",
+			ifMatch:          fmt.Sprintf(`"%d"`, staleVersion),
+			expectedStatus:   412,
+			expectedResponse: `{"type":"about:blank","title":"Precondition Failed","status":412,"detail":"Design Pattern was modified, fetch it again and retry","instance":"/designpatters/ok/translations/en","code":"version_mismatch"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := gin.Default()
+			app = DesignPatternRoutes(app, &designPatternServiceMock{})
+
+			r, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/%s/ok/translations/%s", designPattersGroup, tt.locale), nil)
+			require.NoError(t, err)
+			if tt.ifMatch != "" {
+				r.Header.Set(ifMatchHeader, tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			app.ServeHTTP(rr, r)
+
+			resp := rr.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.Equal(t, tt.expectedResponse, string(body))
+
+			err = resp.Body.Close()
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,36 +1,306 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"crypto/rsa"
+	"flag"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/waydevs/sections-api/cmd/api/handlers"
+	"github.com/waydevs/sections-api/internal/apikeys"
 	"github.com/waydevs/sections-api/internal/designpatters"
+	"github.com/waydevs/sections-api/internal/media"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"github.com/waydevs/sections-api/internal/platform/cache"
+	"github.com/waydevs/sections-api/internal/platform/configs"
+	"github.com/waydevs/sections-api/internal/platform/health"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/ratelimit"
 	"github.com/waydevs/sections-api/internal/platform/repository"
+	"github.com/waydevs/sections-api/internal/platform/storage"
+	"github.com/waydevs/sections-api/internal/sections"
 )
 
 const (
-	// Momentaneamente dejemoslo asi, pero en un futuro lo cambiaremos por una variable de entorno.
-	mongoURI = "mongodb://localhost:27017"
+	indexesTimeout = 30 * time.Second
+)
+
+// Exit codes, so the orchestrator can tell a service that never started, or was not
+// configured correctly, from one that failed to stop cleanly.
+const (
+	exitOK              = 0
+	exitStartupFailure  = 1
+	exitConfigError     = 2
+	exitShutdownFailure = 3
 )
 
 func main() {
-	r := gin.Default()
+	os.Exit(run())
+}
+
+func run() int {
+	configFile := flag.String("config", os.Getenv(configs.ConfigFileEnv), "path to a YAML or JSON configuration file")
+	flag.Parse()
 
-	dbConn, err := repository.NewClient(mongoURI)
+	cfg, err := configs.Load(*configFile)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		logging.New("info", os.Stderr).Error("loading configuration", "error", err)
+		return exitConfigError
+	}
+
+	logger := logging.New(cfg.Log.Level, os.Stdout)
+
+	if cfg.Log.Level == "debug" {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Error("setting trusted proxies", "error", err)
+		return exitStartupFailure
+	}
+	r.Use(
+		handlers.RequestID(),
+		handlers.AccessLog(logger),
+		handlers.Recovery(logger),
+		handlers.CORS(cfg.CORS),
+	)
+
+	dbConn, err := repository.NewClient(cfg.Mongo.URI, cfg.Mongo.Timeout)
+	if err != nil {
+		logger.Error("creating mongo client", "error", err)
+		return exitStartupFailure
+	}
+
+	db, err := repository.NewDatabase(dbConn, cfg.Mongo.Database)
+	if err != nil {
+		logger.Error("connecting to mongo", "error", err)
+		return exitStartupFailure
+	}
+
+	checker := health.NewChecker(cfg.Health.Timeout, logger)
+	checker.Register("mongo", dbConn.Ping)
+	r = handlers.HealthRoutes(r, checker)
+
+	desigPatternsRepositroy := repository.NewDesignPatterns(db, logger)
+	revisionsRepository := repository.NewRevisions(db, logger)
+	mediaRepository := repository.NewMediaLibrary(db, logger)
+	apiKeysRepository := repository.NewAPIKeys(db, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), indexesTimeout)
+	err = desigPatternsRepositroy.EnsureIndexes(ctx)
+	if err == nil {
+		err = revisionsRepository.EnsureIndexes(ctx)
+	}
+	if err == nil {
+		err = mediaRepository.EnsureIndexes(ctx)
+	}
+	if err == nil {
+		err = apiKeysRepository.EnsureIndexes(ctx)
+	}
+	cancel()
+	if err != nil {
+		logger.Error("creating indexes", "error", err)
+		closeClient(dbConn, logger)
+		return exitStartupFailure
+	}
+
+	mediaStorage, err := storage.NewLocal(cfg.Media.Dir)
+	if err != nil {
+		logger.Error("opening media storage", "error", err)
+		closeClient(dbConn, logger)
+		return exitStartupFailure
+	}
+
+	apiKeysService := apikeys.NewService(apiKeysRepository, logger, apikeys.WithRotationGrace(cfg.Auth.APIKeyRotationGrace))
+
+	guard, err := newGuard(cfg.Auth, apiKeysService)
+	if err != nil {
+		logger.Error("loading authentication keys", "error", err)
+		closeClient(dbConn, logger)
+		return exitStartupFailure
+	}
+	if guard == nil {
+		logger.Warn("authentication is disabled, every write is open to anyone")
+	}
+
+	designPatternsService := designpatters.NewService(desigPatternsRepositroy, revisionsRepository, logger,
+		designpatters.WithLocales(cfg.I18n.DefaultLocale, cfg.I18n.Locales),
+		designpatters.WithRenderCacheSize(cfg.DesignPatterns.RenderCacheSize),
+		designpatters.WithCache(cache.NewLRU[string, any](cfg.DesignPatterns.CacheSize, cfg.DesignPatterns.CacheTTL)),
+	)
+
+	r = handlers.DesignPatternRoutes(r, designPatternsService,
+		handlers.RequireIfMatch(cfg.DesignPatterns.RequireIfMatch),
+		handlers.Guarded(guard),
+		handlers.RateLimited(
+			newLimiter(cfg.RateLimit.ReadRequests, cfg.RateLimit.ReadPeriod),
+			newLimiter(cfg.RateLimit.WriteRequests, cfg.RateLimit.WritePeriod),
+		),
+	)
+
+	mediaService := media.NewService(mediaRepository, mediaStorage, logger, media.WithMaxSize(int64(cfg.Media.MaxSize)))
+	r = handlers.MediaRoutes(r, mediaService, cfg.Media.CacheMaxAge, guard)
+
+	sectionServices := make([]handlers.SectionService, 0, len(sections.Kinds))
+	for _, kind := range sections.Kinds {
+		sectionServices = append(sectionServices, sections.NewService(kind, repository.NewSections(db, kind.Collection, logger), logger))
+	}
+	r = handlers.SectionRoutes(r, guard, sectionServices...)
+
+	// Without authentication anybody could issue keys, and keys wouldn't be needed anyway.
+	if guard != nil {
+		r = handlers.APIKeyRoutes(r, apiKeysService, guard)
+	}
+
+	server := &http.Server{
+		Addr:         cfg.Server.Address,
+		Handler:      r,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
-	defer dbConn.Close()
 
-	db := repository.NewDatabase(dbConn)
+	signals, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	desigPatternsRepositroy := repository.NewDesignPatterns(db)
-	designPatternsService := designpatters.NewService(desigPatternsRepositroy)
+	stopPurge := startPurge(designPatternsService, cfg.DesignPatterns, logger)
+	stopScheduler := startBackground(func(ctx context.Context) {
+		designPatternsService.RunScheduler(ctx, cfg.DesignPatterns.SchedulerInterval)
+	})
 
-	r = handlers.DesignPatternRoutes(r, designPatternsService)
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- server.ListenAndServe()
+	}()
+	logger.Info("server started", "address", cfg.Server.Address)
 
-	r.Run()
+	select {
+	case err := <-serverErrors:
+		// ListenAndServe only returns before Shutdown when it can't listen.
+		logger.Error("starting server", "error", err)
+		stopPurge()
+		stopScheduler()
+		closeClient(dbConn, logger)
+		return exitStartupFailure
+
+	case <-signals.Done():
+	}
+
+	// Restore the default behavior, so a second signal kills the process right away.
+	stop()
+	stopPurge()
+	stopScheduler()
+
+	return shutdown(server, checker, dbConn, cfg.Server, logger)
+}
+
+// shutdown fails readiness, waits for the drain delay, lets in-flight requests finish
+// within the grace period and finally disconnects from Mongo.
+func shutdown(server *http.Server, checker *health.Checker, dbConn repository.ClientHelper, cfg configs.ServerConfig, logger *slog.Logger) int {
+	logger.Info("shutting down", "drain_delay", cfg.DrainDelay.String(), "grace_period", cfg.ShutdownTimeout.String())
+	code := exitOK
+
+	checker.Drain()
+	time.Sleep(cfg.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("draining in-flight requests", "error", err)
+		code = exitShutdownFailure
+	}
+
+	if err := dbConn.Close(); err != nil {
+		logger.Error("disconnecting from mongo", "error", err)
+		code = exitShutdownFailure
+	}
+
+	if code == exitOK {
+		logger.Info("shutdown completed")
+	}
+
+	return code
+}
+
+// startPurge purges expired design patterns from the trash in the background, unless the
+// retention is zero. It returns a function that stops the purge and waits for it to return.
+func startPurge(service *designpatters.Service, cfg configs.DesignPatternsConfig, logger *slog.Logger) func() {
+	if cfg.TrashRetention == 0 {
+		logger.Info("trash purge disabled")
+		return func() {}
+	}
+
+	return startBackground(func(ctx context.Context) {
+		service.RunPurge(ctx, cfg.TrashRetention, cfg.PurgeInterval)
+	})
+}
+
+// startBackground runs task in the background until the function it returns is called. That
+// function waits for task to return, so Mongo is not disconnected under a running task.
+func startBackground(task func(ctx context.Context)) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		task(ctx)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// newGuard returns the guard of writes, which verifies their tokens or API keys and checks
+// the permissions of their roles or scopes, or nil when cfg disables authentication.
+func newGuard(cfg configs.AuthConfig, apiKeys handlers.KeyAuthenticator) (*handlers.Guard, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	policy, err := auth.NewPolicy(cfg.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys map[string]*rsa.PublicKey
+	if cfg.JWKSFile != "" {
+		if keys, err = auth.LoadJWKS(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+
+	verifier := auth.NewVerifier([]byte(cfg.HMACSecret), keys,
+		auth.WithIssuer(cfg.Issuer),
+		auth.WithAudience(cfg.Audience),
+		auth.WithLeeway(cfg.Leeway),
+	)
+
+	return handlers.NewGuard(verifier, policy, handlers.AcceptAPIKeys(apiKeys)), nil
+}
+
+// newLimiter returns a limiter of requests per period, or nil when requests is zero, which
+// doesn't limit them.
+func newLimiter(requests int, period time.Duration) *ratelimit.Limiter {
+	if requests == 0 {
+		return nil
+	}
+
+	return ratelimit.NewLimiter(ratelimit.Limit{Requests: requests, Period: period})
+}
+
+func closeClient(dbConn repository.ClientHelper, logger *slog.Logger) {
+	if err := dbConn.Close(); err != nil {
+		logger.Error("disconnecting from mongo", "error", err)
+	}
 }
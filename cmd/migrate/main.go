@@ -0,0 +1,88 @@
+// Command migrate rewrites the content blocks stored before typed blocks, in the design
+// patterns and in every kind of section, so they are stored the way the API writes them, and
+// sets version 1 on the design patterns stored before versioning. It is idempotent, so
+// running it again only migrates what is left.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/configs"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"github.com/waydevs/sections-api/internal/sections"
+)
+
+const migrationTimeout = 10 * time.Minute
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	configFile := flag.String("config", os.Getenv(configs.ConfigFileEnv), "path to a YAML or JSON configuration file")
+	flag.Parse()
+
+	cfg, err := configs.Load(*configFile)
+	if err != nil {
+		logging.New("info", os.Stderr).Error("loading configuration", "error", err)
+		return exitFailure
+	}
+
+	logger := logging.New(cfg.Log.Level, os.Stdout)
+
+	dbConn, err := repository.NewClient(cfg.Mongo.URI, cfg.Mongo.Timeout)
+	if err != nil {
+		logger.Error("creating mongo client", "error", err)
+		return exitFailure
+	}
+	defer func() {
+		if err := dbConn.Close(); err != nil {
+			logger.Error("disconnecting from mongo", "error", err)
+		}
+	}()
+
+	db, err := repository.NewDatabase(dbConn, cfg.Mongo.Database)
+	if err != nil {
+		logger.Error("connecting to mongo", "error", err)
+		return exitFailure
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrationTimeout)
+	defer cancel()
+
+	designPatterns := repository.NewDesignPatterns(db, logger)
+
+	backfilled, err := designPatterns.BackfillVersions(ctx)
+	if err != nil {
+		logger.Error("backfilling design pattern versions", "error", err, "backfilled", backfilled)
+		return exitFailure
+	}
+	logger.Info("backfilled design pattern versions", "backfilled", backfilled)
+
+	migrated, err := designPatterns.MigrateBlocks(ctx)
+	if err != nil {
+		logger.Error("migrating design pattern blocks", "error", err, "migrated", migrated)
+		return exitFailure
+	}
+	logger.Info("migrated design pattern blocks", "migrated", migrated)
+
+	for _, kind := range sections.Kinds {
+		migrated, err := repository.NewSections(db, kind.Collection, logger).MigrateBlocks(ctx)
+		if err != nil {
+			logger.Error("migrating section blocks", "kind", kind.Name, "error", err, "migrated", migrated)
+			return exitFailure
+		}
+		logger.Info("migrated section blocks", "kind", kind.Name, "migrated", migrated)
+	}
+
+	return exitOK
+}
@@ -0,0 +1,31 @@
+package apikeys
+
+import "time"
+
+// Key is an API key as shown to admins. Its secret is never shown again after it is issued,
+// only its Prefix, which is enough to tell keys apart.
+type Key struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"createdBy,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Issued is a Key that was just issued, along with the Secret clients authenticate with.
+type Issued struct {
+	Key
+	Secret string `json:"secret"`
+}
+
+// IssueParams are the name of a new Key, the scopes it grants and when it expires. Keys
+// without ExpiresAt don't expire.
+type IssueParams struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
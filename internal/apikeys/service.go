@@ -0,0 +1,347 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+var (
+	// ErrSomethingWentWrong is returned when something went wrong.
+	ErrSomethingWentWrong = errors.New("Something went wrong")
+
+	// ErrKeyNotFound is returned when a Key is not found.
+	ErrKeyNotFound = errors.New("API key not found")
+
+	// ErrInvalidID is returned when an id is not a valid Key id.
+	ErrInvalidID = errors.New("Invalid API key id")
+
+	// ErrInvalidKey is returned when a secret doesn't belong to any Key, or belongs to a
+	// revoked one.
+	ErrInvalidKey = errors.New("Invalid API key")
+
+	// ErrKeyExpired is returned when the Key of a secret expired, and when rotating an expired
+	// Key.
+	ErrKeyExpired = errors.New("API key expired")
+
+	// ErrKeyRevoked is returned when rotating a revoked Key.
+	ErrKeyRevoked = errors.New("API key is revoked")
+
+	// ErrMissingName is returned when issuing a Key without a name.
+	ErrMissingName = errors.New("API key name is required")
+
+	// ErrNameTooLong is wrapped by the error returned when the name of a Key is longer than
+	// MaxNameLength.
+	ErrNameTooLong = errors.New("API key name is too long")
+
+	// ErrMissingScopes is returned when issuing a Key without scopes.
+	ErrMissingScopes = errors.New("API key scopes are required")
+
+	// ErrInvalidScope is wrapped by the error returned when issuing a Key with a scope that
+	// doesn't exist or can't be granted to keys.
+	ErrInvalidScope = errors.New("Invalid API key scope")
+
+	// ErrPastExpiry is returned when issuing a Key that would already be expired.
+	ErrPastExpiry = errors.New("API key expiry must be in the future")
+
+	// ErrUnavailable is returned when the database can't be reached, so the request may
+	// succeed if retried later.
+	ErrUnavailable = errors.New("Service temporarily unavailable")
+)
+
+const (
+	// DefaultRotationGrace is how long the previous secret of a rotated Key keeps working,
+	// unless WithRotationGrace sets another period.
+	DefaultRotationGrace = 24 * time.Hour
+
+	// MaxNameLength is the longest name of a Key, in characters.
+	MaxNameLength = 100
+
+	// SubjectPrefix starts the subject of the principals authenticated with a Key, followed
+	// by its id, so changes made with keys are told apart from changes made by users.
+	SubjectPrefix = "apikey:"
+)
+
+const (
+	// secretPrefix starts every secret, so keys are recognized in logs and by secret
+	// scanners.
+	secretPrefix = "sak_"
+	secretBytes  = 32
+
+	// shownLength is how much of a secret is kept as the Prefix of its Key.
+	shownLength = len(secretPrefix) + 8
+
+	// lastUsedResolution is how often the use of a Key is recorded at most, so requests
+	// authenticated with the same Key don't all write to the database.
+	lastUsedResolution = time.Minute
+)
+
+// KeyRepository is a repository for Keys.
+type KeyRepository interface {
+	List(ctx context.Context) ([]repository.APIKey, error)
+	GetByID(ctx context.Context, id string) (repository.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (repository.APIKey, error)
+	Create(ctx context.Context, key repository.APIKey) (repository.APIKey, error)
+	Revoke(ctx context.Context, id string, at time.Time) error
+	Expire(ctx context.Context, id string, at time.Time) error
+	Touch(ctx context.Context, id string, at time.Time) error
+}
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithRotationGrace sets how long the previous secret of a rotated Key keeps working, so
+// clients can switch to the new one without downtime. Zero revokes it right away.
+func WithRotationGrace(grace time.Duration) Option {
+	return func(s *Service) {
+		s.rotationGrace = grace
+	}
+}
+
+// Service issues, rotates and revokes the API keys of machine clients and authenticates the
+// requests made with them.
+type Service struct {
+	db            KeyRepository
+	logger        *slog.Logger
+	rotationGrace time.Duration
+	now           func() time.Time
+}
+
+// NewService creates a new API key service.
+func NewService(db KeyRepository, logger *slog.Logger, opts ...Option) *Service {
+	s := &Service{db: db, logger: logger, rotationGrace: DefaultRotationGrace, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// List returns every Key, revoked and expired ones included.
+func (s *Service) List(ctx context.Context) ([]Key, error) {
+	stored, err := s.db.List(ctx)
+	if err != nil {
+		return nil, s.repositoryError(ctx, "listing api keys", err)
+	}
+
+	keys := make([]Key, 0, len(stored))
+	for _, key := range stored {
+		keys = append(keys, repositoryModelToServiceModel(key))
+	}
+
+	return keys, nil
+}
+
+// Issue creates a Key granting the scopes of params, which are recorded as created by the
+// principal of ctx. Its secret is only returned here.
+func (s *Service) Issue(ctx context.Context, params IssueParams) (Issued, error) {
+	params.Name = strings.TrimSpace(params.Name)
+	if err := s.validate(params); err != nil {
+		return Issued{}, err
+	}
+
+	return s.issue(ctx, params)
+}
+
+// Rotate issues a new Key with the name, scopes and expiry of the Key with id, whose secret
+// keeps working for the rotation grace period.
+func (s *Service) Rotate(ctx context.Context, id string) (Issued, error) {
+	previous, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return Issued{}, s.repositoryError(ctx, "getting api key", err, "id", id)
+	}
+
+	now := s.now()
+	if previous.RevokedAt != nil {
+		return Issued{}, ErrKeyRevoked
+	}
+	if previous.ExpiresAt != nil && !now.Before(*previous.ExpiresAt) {
+		return Issued{}, ErrKeyExpired
+	}
+
+	issued, err := s.issue(ctx, IssueParams{Name: previous.Name, Scopes: previous.Scopes, ExpiresAt: previous.ExpiresAt})
+	if err != nil {
+		return Issued{}, err
+	}
+
+	if err := s.db.Expire(ctx, id, now.Add(s.rotationGrace).UTC()); err != nil {
+		return Issued{}, s.repositoryError(ctx, "expiring rotated api key", err, "id", id)
+	}
+
+	s.logger.InfoContext(ctx, "rotated api key", "id", id, "new_id", issued.ID)
+	return issued, nil
+}
+
+// Revoke revokes the Key with id, so its secret stops working right away.
+func (s *Service) Revoke(ctx context.Context, id string) error {
+	if err := s.db.Revoke(ctx, id, s.now().UTC()); err != nil {
+		return s.repositoryError(ctx, "revoking api key", err, "id", id)
+	}
+
+	s.logger.InfoContext(ctx, "revoked api key", "id", id)
+	return nil
+}
+
+// Authenticate returns the principal of the Key of secret, which is granted the permissions
+// of its scopes. Unknown, malformed and revoked secrets are all rejected with ErrInvalidKey.
+func (s *Service) Authenticate(ctx context.Context, secret string) (auth.Principal, error) {
+	if !strings.HasPrefix(secret, secretPrefix) {
+		return auth.Principal{}, ErrInvalidKey
+	}
+
+	key, err := s.db.GetByHash(ctx, hash(secret))
+	if errors.Is(err, repository.ErrNotFound) {
+		return auth.Principal{}, ErrInvalidKey
+	}
+	if err != nil {
+		return auth.Principal{}, s.repositoryError(ctx, "getting api key by hash", err)
+	}
+
+	id := key.MongoID.Hex()
+	now := s.now()
+	if key.RevokedAt != nil {
+		s.logger.WarnContext(ctx, "revoked api key used", "id", id)
+		return auth.Principal{}, ErrInvalidKey
+	}
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return auth.Principal{}, ErrKeyExpired
+	}
+
+	permissions, err := auth.ScopePermissions(key.Scopes)
+	if err != nil {
+		// Scopes are checked when keys are issued, so this is a scope that no longer exists.
+		s.logger.ErrorContext(ctx, "reading api key scopes", "id", id, "error", err)
+		return auth.Principal{}, ErrInvalidKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Failing to record the use of a key doesn't fail the request.
+		if err := s.db.Touch(ctx, id, now.UTC()); err != nil {
+			s.logger.WarnContext(ctx, "recording api key use", "id", id, "error", err)
+		}
+	}
+
+	return auth.Principal{Subject: SubjectPrefix + id, Permissions: permissions}, nil
+}
+
+func (s *Service) validate(params IssueParams) error {
+	if params.Name == "" {
+		return ErrMissingName
+	}
+	if utf8.RuneCountInString(params.Name) > MaxNameLength {
+		return fmt.Errorf("%w, the limit is %d characters", ErrNameTooLong, MaxNameLength)
+	}
+
+	if len(params.Scopes) == 0 {
+		return ErrMissingScopes
+	}
+	if _, err := auth.ScopePermissions(params.Scopes); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScope, err)
+	}
+	// Otherwise a leaked key could issue keys that outlive its own revocation.
+	if slices.Contains(params.Scopes, string(auth.ManageAPIKeys)) {
+		return fmt.Errorf("%w: %s can't be granted to API keys", ErrInvalidScope, auth.ManageAPIKeys)
+	}
+
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(s.now()) {
+		return ErrPastExpiry
+	}
+
+	return nil
+}
+
+func (s *Service) issue(ctx context.Context, params IssueParams) (Issued, error) {
+	secret, err := newSecret()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "generating api key", "error", err)
+		return Issued{}, ErrSomethingWentWrong
+	}
+
+	var createdBy string
+	if principal, ok := auth.PrincipalFrom(ctx); ok {
+		createdBy = principal.Subject
+	}
+
+	var expiresAt *time.Time
+	if params.ExpiresAt != nil {
+		utc := params.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	created, err := s.db.Create(ctx, repository.APIKey{
+		Name:      params.Name,
+		Hash:      hash(secret),
+		Prefix:    secret[:shownLength],
+		Scopes:    params.Scopes,
+		CreatedBy: createdBy,
+		CreatedAt: s.now().UTC(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return Issued{}, s.repositoryError(ctx, "creating api key", err, "name", params.Name)
+	}
+
+	s.logger.InfoContext(ctx, "issued api key", "id", created.MongoID.Hex(), "name", created.Name, "scopes", created.Scopes)
+	return Issued{Key: repositoryModelToServiceModel(created), Secret: secret}, nil
+}
+
+// repositoryError converts an error from the repository into one of the errors of the
+// Service, logging the ones callers can't do anything about.
+func (s *Service) repositoryError(ctx context.Context, msg string, err error, args ...any) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrKeyNotFound
+	case errors.Is(err, repository.ErrInvalidID):
+		return ErrInvalidID
+	}
+
+	s.logger.ErrorContext(ctx, msg, append(args, "error", err)...)
+
+	if repository.IsUnavailable(err) {
+		return ErrUnavailable
+	}
+
+	return ErrSomethingWentWrong
+}
+
+// newSecret returns a random secret. Secrets have 256 bits of entropy, so a single unsalted
+// SHA-256 is enough to store them.
+func newSecret() (string, error) {
+	data := make([]byte, secretBytes)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func repositoryModelToServiceModel(key repository.APIKey) Key {
+	return Key{
+		ID:         key.MongoID.Hex(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
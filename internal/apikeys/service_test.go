@@ -0,0 +1,438 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	someID    = "5f9f1c5b9b9b9b9b9b9b9b9b"
+	createdID = "5f9f1c5b9b9b9b9b9b9b9b01"
+	missingID = "5f9f1c5b9b9b9b9b9b9b9b00"
+
+	someSecret = "sak_c29tZS1zZWNyZXQtb2YtdGhpcnR5LXR3by1ieXRlcw"
+)
+
+var now = time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// keyRepositoryMock keeps the keys it stores by id, and records the writes made to them.
+type keyRepositoryMock struct {
+	keys    map[string]repository.APIKey
+	touched map[string]time.Time
+	err     error
+}
+
+func newKeyRepositoryMock(keys ...repository.APIKey) *keyRepositoryMock {
+	m := &keyRepositoryMock{keys: map[string]repository.APIKey{}, touched: map[string]time.Time{}}
+	for _, key := range keys {
+		m.keys[key.MongoID.Hex()] = key
+	}
+
+	return m
+}
+
+func (m *keyRepositoryMock) List(_ context.Context) ([]repository.APIKey, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	keys := []repository.APIKey{}
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (m *keyRepositoryMock) GetByID(_ context.Context, id string) (repository.APIKey, error) {
+	if m.err != nil {
+		return repository.APIKey{}, m.err
+	}
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return repository.APIKey{}, repository.ErrInvalidID
+	}
+	key, ok := m.keys[id]
+	if !ok {
+		return repository.APIKey{}, repository.ErrNotFound
+	}
+
+	return key, nil
+}
+
+func (m *keyRepositoryMock) GetByHash(_ context.Context, hash string) (repository.APIKey, error) {
+	if m.err != nil {
+		return repository.APIKey{}, m.err
+	}
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+
+	return repository.APIKey{}, repository.ErrNotFound
+}
+
+func (m *keyRepositoryMock) Create(_ context.Context, key repository.APIKey) (repository.APIKey, error) {
+	if m.err != nil {
+		return repository.APIKey{}, m.err
+	}
+	key.MongoID, _ = primitive.ObjectIDFromHex(createdID)
+	m.keys[createdID] = key
+
+	return key, nil
+}
+
+func (m *keyRepositoryMock) Revoke(_ context.Context, id string, at time.Time) error {
+	return m.update(id, func(key *repository.APIKey) { key.RevokedAt = &at })
+}
+
+func (m *keyRepositoryMock) Expire(_ context.Context, id string, at time.Time) error {
+	return m.update(id, func(key *repository.APIKey) { key.ExpiresAt = &at })
+}
+
+func (m *keyRepositoryMock) Touch(_ context.Context, id string, at time.Time) error {
+	m.touched[id] = at
+	return nil
+}
+
+func (m *keyRepositoryMock) update(id string, update func(key *repository.APIKey)) error {
+	if m.err != nil {
+		return m.err
+	}
+	key, ok := m.keys[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	update(&key)
+	m.keys[id] = key
+
+	return nil
+}
+
+func storedKey(modify func(key *repository.APIKey)) repository.APIKey {
+	id, _ := primitive.ObjectIDFromHex(someID)
+	key := repository.APIKey{
+		MongoID:   id,
+		Name:      "static-site",
+		Hash:      hash(someSecret),
+		Prefix:    someSecret[:shownLength],
+		Scopes:    []string{"read:drafts"},
+		CreatedBy: "ada",
+		CreatedAt: now.Add(-time.Hour),
+	}
+	if modify != nil {
+		modify(&key)
+	}
+
+	return key
+}
+
+func newTestService(db KeyRepository) *Service {
+	s := NewService(db, logging.Discard(), WithRotationGrace(time.Hour))
+	s.now = func() time.Time { return now }
+
+	return s
+}
+
+func timeAt(t time.Time) *time.Time {
+	return &t
+}
+
+func TestService_Issue(t *testing.T) {
+	tt := []struct {
+		name          string
+		params        IssueParams
+		err           error
+		expectedKey   Key
+		expectedError string
+	}{
+		{
+			name:   "Ok - Issue",
+			params: IssueParams{Name: " ci ", Scopes: []string{"write:patterns", "upload:media"}, ExpiresAt: timeAt(now.Add(time.Hour))},
+			expectedKey: Key{
+				ID:        createdID,
+				Name:      "ci",
+				Scopes:    []string{"write:patterns", "upload:media"},
+				CreatedBy: "ada",
+				CreatedAt: now,
+				ExpiresAt: timeAt(now.Add(time.Hour)),
+			},
+		},
+		{
+			name:          "Error - Missing name",
+			params:        IssueParams{Name: " ", Scopes: []string{"read:drafts"}},
+			expectedError: "API key name is required",
+		},
+		{
+			name:          "Error - Name too long",
+			params:        IssueParams{Name: strings.Repeat("ñ", MaxNameLength+1), Scopes: []string{"read:drafts"}},
+			expectedError: "API key name is too long, the limit is 100 characters",
+		},
+		{
+			name:          "Error - Missing scopes",
+			params:        IssueParams{Name: "ci"},
+			expectedError: "API key scopes are required",
+		},
+		{
+			name:          "Error - Unknown scope",
+			params:        IssueParams{Name: "ci", Scopes: []string{"read:drafts", "fly:patterns"}},
+			expectedError: `Invalid API key scope: unknown scope "fly:patterns"`,
+		},
+		{
+			name:          "Error - Scope not granted to keys",
+			params:        IssueParams{Name: "ci", Scopes: []string{"manage:apikeys"}},
+			expectedError: "Invalid API key scope: manage:apikeys can't be granted to API keys",
+		},
+		{
+			name:          "Error - Past expiry",
+			params:        IssueParams{Name: "ci", Scopes: []string{"read:drafts"}, ExpiresAt: timeAt(now)},
+			expectedError: "API key expiry must be in the future",
+		},
+		{
+			name:          "Error - Unavailable",
+			params:        IssueParams{Name: "ci", Scopes: []string{"read:drafts"}},
+			err:           mongo.CommandError{Labels: []string{"NetworkError"}},
+			expectedError: "Service temporarily unavailable",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newKeyRepositoryMock()
+			db.err = tc.err
+			ctx := auth.WithPrincipal(context.Background(), auth.Principal{Subject: "ada"})
+
+			issued, err := newTestService(db).Issue(ctx, tc.params)
+
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(issued.Secret, secretPrefix))
+			require.Len(t, issued.Secret, len(secretPrefix)+43)
+			require.Equal(t, issued.Secret[:shownLength], issued.Prefix)
+			require.Equal(t, hash(issued.Secret), db.keys[createdID].Hash)
+
+			tc.expectedKey.Prefix = issued.Prefix
+			require.Equal(t, tc.expectedKey, issued.Key)
+		})
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	tt := []struct {
+		name              string
+		secret            string
+		stored            repository.APIKey
+		err               error
+		expectedPrincipal auth.Principal
+		expectedTouched   bool
+		expectedError     error
+	}{
+		{
+			name:   "Ok - Authenticate",
+			secret: someSecret,
+			stored: storedKey(func(key *repository.APIKey) {
+				key.Scopes = []string{"read:drafts", "write:patterns"}
+				key.ExpiresAt = timeAt(now.Add(time.Second))
+			}),
+			expectedPrincipal: auth.Principal{
+				Subject:     "apikey:" + someID,
+				Permissions: []auth.Permission{auth.ReadDrafts, auth.CreatePatterns, auth.UpdatePatterns, auth.DeletePatterns},
+			},
+			expectedTouched: true,
+		},
+		{
+			name:              "Ok - Recently used",
+			secret:            someSecret,
+			stored:            storedKey(func(key *repository.APIKey) { key.LastUsedAt = timeAt(now.Add(-time.Second)) }),
+			expectedPrincipal: auth.Principal{Subject: "apikey:" + someID, Permissions: []auth.Permission{auth.ReadDrafts}},
+			expectedTouched:   false,
+		},
+		{
+			name:          "Error - Unknown key",
+			secret:        "sak_unknown",
+			stored:        storedKey(nil),
+			expectedError: ErrInvalidKey,
+		},
+		{
+			name:          "Error - Malformed key",
+			secret:        strings.TrimPrefix(someSecret, secretPrefix),
+			stored:        storedKey(func(key *repository.APIKey) { key.Hash = hash(strings.TrimPrefix(someSecret, secretPrefix)) }),
+			expectedError: ErrInvalidKey,
+		},
+		{
+			name:          "Error - Revoked",
+			secret:        someSecret,
+			stored:        storedKey(func(key *repository.APIKey) { key.RevokedAt = timeAt(now.Add(-time.Second)) }),
+			expectedError: ErrInvalidKey,
+		},
+		{
+			name:          "Error - Expired",
+			secret:        someSecret,
+			stored:        storedKey(func(key *repository.APIKey) { key.ExpiresAt = timeAt(now) }),
+			expectedError: ErrKeyExpired,
+		},
+		{
+			name:          "Error - Authenticate",
+			secret:        someSecret,
+			stored:        storedKey(nil),
+			err:           errors.New("some-error"),
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newKeyRepositoryMock(tc.stored)
+			db.err = tc.err
+
+			principal, err := newTestService(db).Authenticate(context.Background(), tc.secret)
+
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedPrincipal, principal)
+			_, touched := db.touched[someID]
+			require.Equal(t, tc.expectedTouched, touched)
+		})
+	}
+}
+
+func TestService_Rotate(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		stored        repository.APIKey
+		expectedError error
+	}{
+		{
+			name:   "Ok - Rotate",
+			id:     someID,
+			stored: storedKey(func(key *repository.APIKey) { key.ExpiresAt = timeAt(now.Add(48 * time.Hour)) }),
+		},
+		{
+			name:          "Error - Revoked",
+			id:            someID,
+			stored:        storedKey(func(key *repository.APIKey) { key.RevokedAt = timeAt(now) }),
+			expectedError: ErrKeyRevoked,
+		},
+		{
+			name:          "Error - Expired",
+			id:            someID,
+			stored:        storedKey(func(key *repository.APIKey) { key.ExpiresAt = timeAt(now.Add(-time.Second)) }),
+			expectedError: ErrKeyExpired,
+		},
+		{
+			name:          "Error - Not found",
+			id:            missingID,
+			stored:        storedKey(nil),
+			expectedError: ErrKeyNotFound,
+		},
+		{
+			name:          "Error - Erroneous ID",
+			id:            "aaaa",
+			stored:        storedKey(nil),
+			expectedError: ErrInvalidID,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newKeyRepositoryMock(tc.stored)
+			s := newTestService(db)
+
+			issued, err := s.Rotate(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+			require.Equal(t, createdID, issued.ID)
+			require.Equal(t, tc.stored.Name, issued.Name)
+			require.Equal(t, tc.stored.Scopes, issued.Scopes)
+			require.Equal(t, tc.stored.ExpiresAt, issued.ExpiresAt)
+			require.Equal(t, timeAt(now.Add(time.Hour)), db.keys[someID].ExpiresAt)
+
+			// Both secrets work until the grace period ends.
+			_, err = s.Authenticate(context.Background(), someSecret)
+			require.NoError(t, err)
+			_, err = s.Authenticate(context.Background(), issued.Secret)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		expectedError error
+	}{
+		{name: "Ok - Revoke", id: someID, expectedError: nil},
+		{name: "Error - Not found", id: missingID, expectedError: ErrKeyNotFound},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newKeyRepositoryMock(storedKey(nil))
+			s := newTestService(db)
+
+			err := s.Revoke(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+			_, err = s.Authenticate(context.Background(), someSecret)
+			require.Equal(t, ErrInvalidKey, err)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	tt := []struct {
+		name           string
+		err            error
+		expectedResult []Key
+		expectedError  error
+	}{
+		{
+			name: "Ok - List",
+			expectedResult: []Key{{
+				ID:        someID,
+				Name:      "static-site",
+				Prefix:    someSecret[:shownLength],
+				Scopes:    []string{"read:drafts"},
+				CreatedBy: "ada",
+				CreatedAt: now.Add(-time.Hour),
+			}},
+		},
+		{
+			name:           "Error - List",
+			err:            errors.New("some-error"),
+			expectedResult: nil,
+			expectedError:  ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newKeyRepositoryMock(storedKey(nil))
+			db.err = tc.err
+
+			result, err := newTestService(db).List(context.Background())
+
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package designpatters
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// blockField is a field of a content block, named as in JSON.
+type blockField struct {
+	name  string
+	value string
+}
+
+// blockFields returns the fields of content besides its type, in the order they are
+// validated and compared.
+func blockFields(content repository.Content) []blockField {
+	return []blockField{
+		{name: "title", value: content.Title},
+		{name: "text", value: content.Text},
+		{name: "language", value: content.Language},
+		{name: "code", value: content.Code},
+		{name: "url", value: content.URL},
+		{name: "mediaId", value: content.MediaID},
+		{name: "alt", value: content.Alt},
+		{name: "caption", value: content.Caption},
+		{name: "severity", value: content.Severity},
+		{name: "format", value: content.Format},
+		{name: "source", value: content.Source},
+	}
+}
+
+// blockTypeFields are the fields each type of block can have. Every block can have a title.
+var blockTypeFields = map[repository.BlockType]map[string]bool{
+	repository.BlockMarkdown: {"title": true, "text": true},
+	repository.BlockCode:     {"title": true, "language": true, "code": true},
+	repository.BlockImage:    {"title": true, "url": true, "mediaId": true, "alt": true, "caption": true},
+	repository.BlockCallout:  {"title": true, "severity": true, "text": true},
+	repository.BlockDiagram:  {"title": true, "format": true, "source": true},
+}
+
+var calloutSeverities = map[string]bool{"info": true, "tip": true, "warning": true, "danger": true}
+
+var diagramFormats = map[string]bool{"mermaid": true, "plantuml": true, "graphviz": true}
+
+// validateBlock reports through add the rules content breaks for its type. field is the path
+// of the block, such as contentData[1].
+func validateBlock(field string, content repository.Content, add func(field, format string, args ...interface{})) {
+	allowed, ok := blockTypeFields[content.Type]
+	switch {
+	case content.Type == "":
+		add(field+".type", "is required")
+		return
+	case !ok:
+		add(field+".type", "must be one of markdown, code, image, callout or diagram")
+		return
+	}
+
+	for _, f := range blockFields(content) {
+		if f.value != "" && !allowed[f.name] {
+			add(field+"."+f.name, "is not allowed in %s blocks", content.Type)
+		}
+	}
+
+	required := func(name, value string, maxLength int) {
+		if strings.TrimSpace(value) == "" {
+			add(field+"."+name, "is required")
+		} else if utf8.RuneCountInString(value) > maxLength {
+			add(field+"."+name, "must have at most %d characters", maxLength)
+		}
+	}
+
+	switch content.Type {
+	case repository.BlockMarkdown:
+		required("text", content.Text, MaxBlockTextLength)
+	case repository.BlockCode:
+		required("language", content.Language, MaxCodeLanguageLength)
+		required("code", content.Code, MaxBlockTextLength)
+	case repository.BlockImage:
+		// Images are either hosted elsewhere or uploaded to /media, not both.
+		switch {
+		case content.MediaID == "":
+			if !validImageURL(content.URL) {
+				add(field+".url", "must be an absolute http or https URL")
+			}
+		case !primitive.IsValidObjectID(content.MediaID):
+			add(field+".mediaId", "must be the id of an uploaded media")
+		case content.URL != "":
+			add(field+".url", "is not allowed along with mediaId")
+		}
+		if utf8.RuneCountInString(content.Alt) > MaxImageTextLength {
+			add(field+".alt", "must have at most %d characters", MaxImageTextLength)
+		}
+		if utf8.RuneCountInString(content.Caption) > MaxImageTextLength {
+			add(field+".caption", "must have at most %d characters", MaxImageTextLength)
+		}
+	case repository.BlockCallout:
+		if !calloutSeverities[content.Severity] {
+			add(field+".severity", "must be one of info, tip, warning or danger")
+		}
+		required("text", content.Text, MaxBlockTextLength)
+	case repository.BlockDiagram:
+		if !diagramFormats[content.Format] {
+			add(field+".format", "must be one of mermaid, plantuml or graphviz")
+		}
+		required("source", content.Source, MaxBlockTextLength)
+	}
+}
+
+// blockFieldChanges returns the names of the fields that differ between two blocks.
+func blockFieldChanges(from, to repository.Content) []string {
+	var fields []string
+
+	if from.Type != to.Type {
+		fields = append(fields, "type")
+	}
+
+	toFields := blockFields(to)
+	for i, f := range blockFields(from) {
+		if f.value != toFields[i].value {
+			fields = append(fields, f.name)
+		}
+	}
+
+	return fields
+}
+
+// blockPath returns the path of a field of the block at index i, such as contentData[1].text.
+func blockPath(i int, name string) string {
+	return fmt.Sprintf("contentData[%d].%s", i, name)
+}
@@ -0,0 +1,66 @@
+package designpatters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Design Patterns stored before typed blocks are valid once upgraded, so they can be sent
+// back unchanged.
+func TestValidate_LegacyBlocks(t *testing.T) {
+	data, err := bson.Marshal(bson.M{"title": "Singleton", "subtitle": "Una instancia", "contentdata": bson.A{
+		bson.M{"title": "Uso", "description": "Una instancia", "image": bson.A{"https://waydevs.com/a.png"}},
+		bson.M{"title": "Diagrama", "description": "", "image": bson.A{"https://waydevs.com/b.png"}},
+		bson.M{"title": "Ejemplo", "description": "", "image": nil},
+		bson.M{"title": "", "description": "", "image": bson.A{}},
+	}})
+	require.NoError(t, err)
+
+	var stored repository.DesignPattern
+	require.NoError(t, bson.Unmarshal(data, &stored))
+
+	require.NoError(t, validate(repositoryModelToServiceModel(stored)))
+}
+
+func TestBlockFieldChanges(t *testing.T) {
+	tt := []struct {
+		name     string
+		from     repository.Content
+		to       repository.Content
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			from:     repository.Content{Type: repository.BlockCode, Language: "go", Code: "var once sync.Once"},
+			to:       repository.Content{Type: repository.BlockCode, Language: "go", Code: "var once sync.Once"},
+			expected: nil,
+		},
+		{
+			name:     "changed fields",
+			from:     repository.Content{Type: repository.BlockImage, URL: "https://waydevs.com/a.png", Alt: "Clases"},
+			to:       repository.Content{Type: repository.BlockImage, URL: "https://waydevs.com/b.png", Caption: "Singleton"},
+			expected: []string{"url", "alt", "caption"},
+		},
+		{
+			name:     "uploaded image",
+			from:     repository.Content{Type: repository.BlockImage, URL: "https://waydevs.com/a.png"},
+			to:       repository.Content{Type: repository.BlockImage, MediaID: "5f9f1c5b9b9b9b9b9b9b9b9b"},
+			expected: []string{"url", "mediaId"},
+		},
+		{
+			name:     "changed type",
+			from:     repository.Content{Type: repository.BlockMarkdown, Title: "Uso", Text: "Una instancia"},
+			to:       repository.Content{Type: repository.BlockCallout, Title: "Uso", Severity: "tip", Text: "Una instancia"},
+			expected: []string{"type", "severity"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, blockFieldChanges(tc.from, tc.to))
+		})
+	}
+}
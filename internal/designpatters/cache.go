@@ -0,0 +1,231 @@
+package designpatters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// Cache keeps the DesignPatterns and the pages of lists read from the repository by key.
+// Implementations must be safe for concurrent use, like *cache.LRU[string, any], and may drop
+// values at any time.
+type Cache interface {
+	Get(key string) (any, bool)
+	Set(key string, value any)
+	Delete(key string)
+	Len() int
+}
+
+// WithCache makes GetByID and List read through c, so only reads it misses hit the
+// repository and concurrent misses of the same key share a single read. Writes drop what they
+// change from c, but writes of other instances of the service are only seen once c drops the
+// values they changed.
+func WithCache(c Cache) Option {
+	return func(s *Service) {
+		s.cache = &readCache{cache: c, loads: map[string]*load{}}
+	}
+}
+
+// CacheStats counts the reads of GetByID and List since the service was created.
+type CacheStats struct {
+	// Hits are the reads found in the Cache.
+	Hits int64 `json:"hits"`
+	// Misses are the reads that hit the repository.
+	Misses int64 `json:"misses"`
+	// Coalesced are the misses that waited for a concurrent read of the same key rather than
+	// hitting the repository again.
+	Coalesced int64 `json:"coalesced"`
+	// Entries is how many values the Cache keeps.
+	Entries int `json:"entries"`
+}
+
+// CacheStats returns the stats of the Cache set by WithCache, or zero stats without one.
+func (s *Service) CacheStats() CacheStats {
+	if s.cache == nil {
+		return CacheStats{}
+	}
+
+	return CacheStats{
+		Hits:      s.cache.hits.Load(),
+		Misses:    s.cache.misses.Load(),
+		Coalesced: s.cache.coalesced.Load(),
+		Entries:   s.cache.cache.Len(),
+	}
+}
+
+// listPage is a page of a list as kept in the Cache.
+type listPage struct {
+	designPatterns []repository.DesignPattern
+	total          int64
+}
+
+// readCache reads through a Cache, loading each key at most once at a time. Values it returns
+// are shared by every read of their key, so they must not be modified.
+type readCache struct {
+	cache Cache
+
+	mu sync.Mutex
+	// writes counts the invalidations, so reads that raced one don't keep what they read.
+	writes uint64
+	// patterns and lists are part of the keys of DesignPatterns and lists, so incrementing
+	// them drops every value of their kind at once. Dropped values age out of the Cache.
+	patterns uint64
+	lists    uint64
+	loads    map[string]*load
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	coalesced atomic.Int64
+}
+
+// load is a read of a key in progress, whose value and error are set once done is closed.
+type load struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// designPattern returns the DesignPattern with the given ID, reading it with read when c is
+// nil or doesn't keep it.
+func (c *readCache) designPattern(ctx context.Context, id string, read func(ctx context.Context, id string) (repository.DesignPattern, error)) (repository.DesignPattern, error) {
+	if c == nil {
+		return read(ctx, id)
+	}
+
+	c.mu.Lock()
+	key := fmt.Sprintf("pattern:%d:%s", c.patterns, id)
+	c.mu.Unlock()
+
+	value, err := c.get(ctx, key, func(ctx context.Context) (any, error) {
+		return read(ctx, id)
+	})
+	if err != nil {
+		return repository.DesignPattern{}, err
+	}
+
+	return value.(repository.DesignPattern), nil
+}
+
+// list returns the page of the list opts select, reading it with read when c is nil or
+// doesn't keep it.
+func (c *readCache) list(ctx context.Context, opts repository.ListOptions, read func(ctx context.Context, opts repository.ListOptions) ([]repository.DesignPattern, int64, error)) ([]repository.DesignPattern, int64, error) {
+	if c == nil {
+		return read(ctx, opts)
+	}
+
+	// Encoded as JSON, lists whose filters only differ in how their values are split, such as
+	// the tags "go idioms" and "go", "idioms", don't share a key.
+	filter, err := json.Marshal(opts)
+	if err != nil {
+		return read(ctx, opts)
+	}
+
+	c.mu.Lock()
+	key := fmt.Sprintf("list:%d:%s", c.lists, filter)
+	c.mu.Unlock()
+
+	value, err := c.get(ctx, key, func(ctx context.Context) (any, error) {
+		designPatterns, total, err := read(ctx, opts)
+		return listPage{designPatterns: designPatterns, total: total}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	page := value.(listPage)
+	return page.designPatterns, page.total, nil
+}
+
+// get returns the value of key in the Cache or, when it misses, the one read joins or starts
+// reading. Errors are not kept, so the next read of the key tries again.
+func (c *readCache) get(ctx context.Context, key string, read func(ctx context.Context) (any, error)) (any, error) {
+	for {
+		c.mu.Lock()
+		if value, ok := c.cache.Get(key); ok {
+			c.mu.Unlock()
+			c.hits.Add(1)
+			return value, nil
+		}
+
+		if l, ok := c.loads[key]; ok {
+			c.mu.Unlock()
+			c.coalesced.Add(1)
+
+			select {
+			case <-l.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+
+			// The read was canceled by the request that started it, not by this one.
+			if isContextError(l.err) && ctx.Err() == nil {
+				continue
+			}
+			return l.value, l.err
+		}
+
+		l := &load{done: make(chan struct{})}
+		c.loads[key] = l
+		writes := c.writes
+		c.mu.Unlock()
+		c.misses.Add(1)
+
+		l.value, l.err = read(ctx)
+
+		c.mu.Lock()
+		if c.loads[key] == l {
+			delete(c.loads, key)
+		}
+		if l.err == nil && c.writes == writes {
+			c.cache.Set(key, l.value)
+		}
+		c.mu.Unlock()
+		close(l.done)
+
+		return l.value, l.err
+	}
+}
+
+// invalidate drops the DesignPattern with the given ID and every list, which may include it.
+func (c *readCache) invalidate(id string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	key := fmt.Sprintf("pattern:%d:%s", c.patterns, id)
+	c.invalidateLocked()
+	c.mu.Unlock()
+
+	c.cache.Delete(key)
+}
+
+// invalidateAll drops every DesignPattern and list, for writes that don't tell which
+// DesignPatterns they changed.
+func (c *readCache) invalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.patterns++
+	c.invalidateLocked()
+	c.mu.Unlock()
+}
+
+// invalidateLocked drops every list and makes the reads in progress start over. It must be
+// called with c.mu held.
+func (c *readCache) invalidateLocked() {
+	c.writes++
+	c.lists++
+	c.loads = map[string]*load{}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,266 @@
+package designpatters
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/cache"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// readCounterMock counts the reads of DesignPatterns and lists, which wait for release when it
+// is set.
+type readCounterMock struct {
+	designPatternRepositoryMock
+	reads   *atomic.Int64
+	lists   *atomic.Int64
+	release chan struct{}
+}
+
+func newReadCounterMock() readCounterMock {
+	return readCounterMock{reads: &atomic.Int64{}, lists: &atomic.Int64{}}
+}
+
+func (r readCounterMock) GetByID(ctx context.Context, id string) (repository.DesignPattern, error) {
+	r.reads.Add(1)
+	if r.release != nil {
+		select {
+		case <-r.release:
+		case <-ctx.Done():
+			return repository.DesignPattern{}, ctx.Err()
+		}
+	}
+
+	if id == "not-found" {
+		return repository.DesignPattern{}, repository.ErrNotFound
+	}
+
+	return repository.DesignPattern{Title: "ok"}, nil
+}
+
+func (r readCounterMock) List(ctx context.Context, opts repository.ListOptions) ([]repository.DesignPattern, int64, error) {
+	r.lists.Add(1)
+	return r.designPatternRepositoryMock.List(ctx, opts)
+}
+
+func newCachedService(db DesignPatternRepository) *Service {
+	return NewService(db, &revisionRepositoryMock{}, logging.Discard(), WithCache(cache.NewLRU[string, any](10, time.Minute)))
+}
+
+func TestService_Cache(t *testing.T) {
+	db := newReadCounterMock()
+	service := newCachedService(db)
+	ctx := context.Background()
+
+	// Reads with other options share what was read.
+	_, err := service.GetByID(ctx, "ok", ReadOptions{})
+	require.NoError(t, err)
+	designPattern, err := service.GetByID(ctx, "ok", ReadOptions{IncludeDrafts: true, RenderHTML: true, Locales: []string{"en"}})
+	require.NoError(t, err)
+	require.Equal(t, "ok", designPattern.Title)
+
+	// Errors are not kept.
+	for i := 0; i < 2; i++ {
+		_, err = service.GetByID(ctx, "not-found", ReadOptions{})
+		require.ErrorIs(t, err, ErrDesignPatternNotFound)
+	}
+
+	_, err = service.List(ctx, ListParams{})
+	require.NoError(t, err)
+	_, err = service.List(ctx, ListParams{Page: 1, Limit: DefaultListLimit})
+	require.NoError(t, err)
+	_, err = service.List(ctx, ListParams{AnyTags: []string{"creational"}})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(3), db.reads.Load())
+	require.Equal(t, int64(2), db.lists.Load())
+	require.Equal(t, CacheStats{Hits: 2, Misses: 5, Entries: 3}, service.CacheStats())
+}
+
+func TestService_Cache_ListKeys(t *testing.T) {
+	db := newReadCounterMock()
+	service := newCachedService(db)
+	ctx := context.Background()
+
+	// Lists whose tags only differ in how they are split are different lists.
+	_, err := service.List(ctx, ListParams{AnyTags: []string{"go idioms"}})
+	require.NoError(t, err)
+	_, err = service.List(ctx, ListParams{AnyTags: []string{"go", "idioms"}})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(2), db.lists.Load())
+	require.Equal(t, CacheStats{Misses: 2, Entries: 2}, service.CacheStats())
+}
+
+func TestService_Cache_Invalidation(t *testing.T) {
+	tt := []struct {
+		name          string
+		write         func(ctx context.Context, service *Service) error
+		expectedReads int64
+	}{
+		{
+			name: "create",
+			write: func(ctx context.Context, service *Service) error {
+				_, err := service.Create(ctx, DesignPattern{Title: "ok"})
+				return err
+			},
+			expectedReads: 1,
+		},
+		{
+			name: "update",
+			write: func(ctx context.Context, service *Service) error {
+				_, err := service.Update(ctx, DesignPattern{ID: "638d568a507b6e07cd39de82", Title: "ok"})
+				return err
+			},
+			// Only the lists are dropped, the DesignPattern read is another one.
+			expectedReads: 1,
+		},
+		{
+			name: "delete",
+			write: func(ctx context.Context, service *Service) error {
+				return service.Delete(ctx, "ok", 0)
+			},
+			expectedReads: 2,
+		},
+		{
+			name: "transition",
+			write: func(ctx context.Context, service *Service) error {
+				_, err := service.Transition(ctx, "ok", Transition{Status: StatusArchived})
+				return err
+			},
+			// Transition reads the DesignPattern it moves from the repository too.
+			expectedReads: 3,
+		},
+		{
+			name: "restore",
+			write: func(ctx context.Context, service *Service) error {
+				_, err := service.Restore(ctx, "ok")
+				return err
+			},
+			expectedReads: 2,
+		},
+		{
+			name: "publish due",
+			write: func(ctx context.Context, service *Service) error {
+				_, err := service.PublishDue(ctx)
+				return err
+			},
+			expectedReads: 2,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newReadCounterMock()
+			service := newCachedService(db)
+			ctx := context.Background()
+
+			_, err := service.GetByID(ctx, "ok", ReadOptions{})
+			require.NoError(t, err)
+			_, err = service.List(ctx, ListParams{})
+			require.NoError(t, err)
+
+			require.NoError(t, tc.write(ctx, service))
+
+			_, err = service.GetByID(ctx, "ok", ReadOptions{})
+			require.NoError(t, err)
+			_, err = service.List(ctx, ListParams{})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedReads, db.reads.Load())
+			require.Equal(t, int64(2), db.lists.Load())
+		})
+	}
+}
+
+func TestService_Cache_Coalescing(t *testing.T) {
+	db := newReadCounterMock()
+	db.release = make(chan struct{})
+	service := newCachedService(db)
+
+	var wg sync.WaitGroup
+	results := make([]DesignPattern, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = service.GetByID(context.Background(), "ok", ReadOptions{})
+		}(i)
+	}
+
+	require.Eventually(t, func() bool {
+		stats := service.CacheStats()
+		return stats.Misses == 1 && stats.Coalesced == 2
+	}, time.Second, time.Millisecond)
+	close(db.release)
+	wg.Wait()
+
+	require.Equal(t, int64(1), db.reads.Load())
+	for _, result := range results {
+		require.Equal(t, "ok", result.Title)
+	}
+}
+
+func TestService_Cache_CanceledRead(t *testing.T) {
+	db := newReadCounterMock()
+	db.release = make(chan struct{})
+	service := newCachedService(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := service.GetByID(ctx, "ok", ReadOptions{})
+		canceled <- err
+	}()
+	require.Eventually(t, func() bool { return service.CacheStats().Misses == 1 }, time.Second, time.Millisecond)
+
+	waited := make(chan error)
+	go func() {
+		_, err := service.GetByID(context.Background(), "ok", ReadOptions{})
+		waited <- err
+	}()
+	require.Eventually(t, func() bool { return service.CacheStats().Coalesced == 1 }, time.Second, time.Millisecond)
+
+	// The waiting read starts over rather than failing with the cancellation of another.
+	cancel()
+	require.Error(t, <-canceled)
+	require.Eventually(t, func() bool { return service.CacheStats().Misses == 2 }, time.Second, time.Millisecond)
+	close(db.release)
+	require.NoError(t, <-waited)
+}
+
+func TestService_Cache_WriteDuringRead(t *testing.T) {
+	db := newReadCounterMock()
+	db.release = make(chan struct{})
+	service := newCachedService(db)
+
+	done := make(chan error)
+	go func() {
+		_, err := service.GetByID(context.Background(), "ok", ReadOptions{})
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return service.CacheStats().Misses == 1 }, time.Second, time.Millisecond)
+
+	// What the read returns may be older than the write, so it is not kept.
+	require.NoError(t, service.Delete(context.Background(), "ok", 0))
+	close(db.release)
+	require.NoError(t, <-done)
+
+	_, err := service.GetByID(context.Background(), "ok", ReadOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), db.reads.Load())
+}
+
+func TestService_CacheStats_Disabled(t *testing.T) {
+	service := NewService(newReadCounterMock(), &revisionRepositoryMock{}, logging.Discard())
+
+	_, err := service.GetByID(context.Background(), "ok", ReadOptions{})
+
+	require.NoError(t, err)
+	require.Equal(t, CacheStats{}, service.CacheStats())
+}
@@ -0,0 +1,33 @@
+package designpatters
+
+import (
+	"context"
+
+	"github.com/waydevs/sections-api/internal/platform/auth"
+)
+
+type changeKey struct{}
+
+// Change describes who made a change to a DesignPattern and why. It is stored along with the
+// Revision the change creates.
+type Change struct {
+	Author string
+	Note   string
+}
+
+// WithChange returns a copy of ctx carrying change, so the Service can record it in the
+// Revisions of the writes made with ctx.
+func WithChange(ctx context.Context, change Change) context.Context {
+	return context.WithValue(ctx, changeKey{}, change)
+}
+
+// ChangeFrom returns the Change carried by ctx, or an empty one. When ctx was authenticated,
+// the author is always the authenticated subject, whatever the Change says.
+func ChangeFrom(ctx context.Context) Change {
+	change, _ := ctx.Value(changeKey{}).(Change)
+	if principal, ok := auth.PrincipalFrom(ctx); ok {
+		change.Author = principal.Subject
+	}
+
+	return change
+}
@@ -0,0 +1,42 @@
+package designpatters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/auth"
+)
+
+func TestChangeFrom(t *testing.T) {
+	tt := []struct {
+		name     string
+		ctx      context.Context
+		expected Change
+	}{
+		{
+			name:     "no change",
+			ctx:      context.Background(),
+			expected: Change{},
+		},
+		{
+			name:     "change",
+			ctx:      WithChange(context.Background(), Change{Author: "ada", Note: "Typo"}),
+			expected: Change{Author: "ada", Note: "Typo"},
+		},
+		{
+			name: "authenticated change",
+			ctx: auth.WithPrincipal(
+				WithChange(context.Background(), Change{Author: "someone-else", Note: "Typo"}),
+				auth.Principal{Subject: "ada"},
+			),
+			expected: Change{Author: "ada", Note: "Typo"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ChangeFrom(tc.ctx))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package designpatters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// Code identifies the kind of an Error. Codes are part of the API, so clients can rely on
+// them instead of on messages, which may change.
+type Code string
+
+const (
+	CodeNotFound        Code = "not_found"
+	CodeInvalidID       Code = "invalid_id"
+	CodeInvalidArgument Code = "invalid_argument"
+	CodeConflict        Code = "conflict"
+	CodeVersionMismatch Code = "version_mismatch"
+	CodeValidation      Code = "validation_failed"
+	CodeUnavailable     Code = "unavailable"
+	CodeInternal        Code = "internal"
+)
+
+// Error is an error returned by the Service along with its Code.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+var (
+	// ErrSomethingWentWrong is returned when something went wrong.
+	ErrSomethingWentWrong = &Error{Code: CodeInternal, Message: "Something went wrong"}
+
+	// ErrDesignPatternNotFound is returned when a DesignPattern is not found.
+	ErrDesignPatternNotFound = &Error{Code: CodeNotFound, Message: "Design Pattern not found"}
+
+	// ErrNotInTrash is returned when a DesignPattern to restore or purge is not in the trash.
+	ErrNotInTrash = &Error{Code: CodeNotFound, Message: "Design Pattern not found in the trash"}
+
+	// ErrRevisionNotFound is returned when a Revision of a DesignPattern is not found.
+	ErrRevisionNotFound = &Error{Code: CodeNotFound, Message: "Revision not found"}
+
+	// ErrTranslationNotFound is returned when a DesignPattern is not translated to a locale.
+	ErrTranslationNotFound = &Error{Code: CodeNotFound, Message: "Translation not found"}
+
+	// ErrInvalidStatus is returned when a transition is requested to an unknown Status.
+	ErrInvalidStatus = &Error{Code: CodeInvalidArgument, Message: "Invalid status, use draft, in_review, published or archived"}
+
+	// ErrInvalidID is returned when an id is not a valid DesignPattern id.
+	ErrInvalidID = &Error{Code: CodeInvalidID, Message: "Invalid Design Pattern id"}
+
+	// ErrInvalidCategory is returned when DesignPatterns are browsed by an unknown Category.
+	ErrInvalidCategory = &Error{Code: CodeInvalidArgument, Message: "Invalid category, use creational, structural or behavioral"}
+
+	// ErrInvalidSort is returned when a list is requested with an unknown sort.
+	ErrInvalidSort = &Error{Code: CodeInvalidArgument, Message: "Invalid sort, use title or createdAt optionally prefixed with -"}
+
+	// ErrInvalidSearchQuery is returned when a search query is empty or too long.
+	ErrInvalidSearchQuery = &Error{Code: CodeInvalidArgument, Message: "Invalid search query, it must have between 1 and 200 characters"}
+
+	// ErrConflict is returned when a change clashes with the stored DesignPatterns.
+	ErrConflict = &Error{Code: CodeConflict, Message: "Design Pattern conflicts with an existing one"}
+
+	// ErrSlugTaken is returned when a DesignPattern is renamed to a slug used by another one.
+	ErrSlugTaken = &Error{Code: CodeConflict, Message: "Slug is already used by another Design Pattern"}
+
+	// ErrVersionMismatch is returned when a change was made against a version of the
+	// DesignPattern that is no longer the stored one.
+	ErrVersionMismatch = &Error{Code: CodeVersionMismatch, Message: "Design Pattern was modified, fetch it again and retry"}
+
+	// ErrUnavailable is returned when the storage can't be reached, so the request may
+	// succeed if retried later.
+	ErrUnavailable = &Error{Code: CodeUnavailable, Message: "Service temporarily unavailable"}
+
+	// ErrInvalidDesignPattern is wrapped by every ValidationError.
+	ErrInvalidDesignPattern = &Error{Code: CodeValidation, Message: "Invalid Design Pattern"}
+)
+
+// CodeOf returns the Code of err, or CodeInternal when err is not an Error.
+func CodeOf(err error) Code {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Code
+	}
+
+	return CodeInternal
+}
+
+// repositoryError converts an error from the repository into an Error, logging the ones
+// callers can't do anything about.
+func (s *Service) repositoryError(ctx context.Context, msg string, err error, args ...any) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrDesignPatternNotFound
+	case errors.Is(err, repository.ErrInvalidID):
+		return ErrInvalidID
+	case errors.Is(err, repository.ErrVersionConflict):
+		return ErrVersionMismatch
+	case repository.IsDuplicateKey(err):
+		return ErrConflict
+	}
+
+	s.logger.ErrorContext(ctx, msg, append(args, "error", err)...)
+
+	if repository.IsUnavailable(err) {
+		return ErrUnavailable
+	}
+
+	return ErrSomethingWentWrong
+}
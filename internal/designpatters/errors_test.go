@@ -0,0 +1,44 @@
+package designpatters
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOf(t *testing.T) {
+	tt := []struct {
+		name         string
+		err          error
+		expectedCode Code
+	}{
+		{
+			name:         "service error",
+			err:          ErrDesignPatternNotFound,
+			expectedCode: CodeNotFound,
+		},
+		{
+			name:         "wrapped service error",
+			err:          fmt.Errorf("getting: %w", ErrUnavailable),
+			expectedCode: CodeUnavailable,
+		},
+		{
+			name:         "validation error",
+			err:          &ValidationError{Fields: []FieldError{{Field: "title", Message: "is required"}}},
+			expectedCode: CodeValidation,
+		},
+		{
+			name:         "unknown error",
+			err:          errors.New("some-error"),
+			expectedCode: CodeInternal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedCode, CodeOf(tc.err))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package designpatters
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// snippetRadius is the number of characters kept around the first match of a snippet.
+	snippetRadius = 60
+
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+	ellipsis       = "…"
+)
+
+// foldedRunes maps accented characters to their base letter, so highlighting matches the
+// same words as the diacritic insensitive Mongo text index.
+var foldedRunes = map[rune]rune{
+	'á': 'a', 'à': 'a', 'ä': 'a', 'â': 'a',
+	'é': 'e', 'è': 'e', 'ë': 'e', 'ê': 'e',
+	'í': 'i', 'ì': 'i', 'ï': 'i', 'î': 'i',
+	'ó': 'o', 'ò': 'o', 'ö': 'o', 'ô': 'o',
+	'ú': 'u', 'ù': 'u', 'ü': 'u', 'û': 'u',
+	'ñ': 'n', 'ç': 'c',
+}
+
+// searchTerms extracts the words of a full-text query, ignoring negated terms.
+func searchTerms(query string) [][]rune {
+	seen := map[string]bool{}
+	terms := [][]rune{}
+
+	for _, word := range strings.Fields(query) {
+		if strings.HasPrefix(word, "-") {
+			continue
+		}
+
+		word = strings.Trim(word, `"`)
+		folded := foldRunes([]rune(word))
+		if len(folded) == 0 || seen[string(folded)] {
+			continue
+		}
+
+		seen[string(folded)] = true
+		terms = append(terms, folded)
+	}
+
+	return terms
+}
+
+// highlights returns a snippet for every field of the DesignPattern where any of the terms
+// is found.
+func highlights(designPattern DesignPattern, terms [][]rune) []Highlight {
+	result := []Highlight{}
+
+	add := func(field, text string) {
+		if snippet, ok := highlightSnippet(text, terms); ok {
+			result = append(result, Highlight{Field: field, Snippet: snippet})
+		}
+	}
+
+	add("title", designPattern.Title)
+	add("subtitle", designPattern.Subtitle)
+	for i, content := range designPattern.ContentData {
+		add(blockPath(i, "title"), content.Title)
+		add(blockPath(i, "text"), content.Text)
+		add(blockPath(i, "caption"), content.Caption)
+	}
+
+	return result
+}
+
+// highlightSnippet returns an HTML escaped excerpt of text around the first match, with
+// every whole word match wrapped in <mark> tags. It reports false when nothing matched.
+func highlightSnippet(text string, terms [][]rune) (string, bool) {
+	original := []rune(text)
+	matches := findMatches(foldRunes(original), terms)
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	start := matches[0][0] - snippetRadius
+	if start <= 0 {
+		start = 0
+	} else {
+		for start < matches[0][0] && !unicode.IsSpace(original[start-1]) {
+			start++
+		}
+	}
+
+	end := matches[0][1] + snippetRadius
+	if end >= len(original) {
+		end = len(original)
+	} else {
+		for end > matches[0][1] && !unicode.IsSpace(original[end]) {
+			end--
+		}
+	}
+
+	var snippet strings.Builder
+	if start > 0 {
+		snippet.WriteString(ellipsis)
+	}
+
+	position := start
+	for _, match := range matches {
+		if match[1] > end {
+			break
+		}
+
+		snippet.WriteString(html.EscapeString(string(original[position:match[0]])))
+		snippet.WriteString(highlightOpen)
+		snippet.WriteString(html.EscapeString(string(original[match[0]:match[1]])))
+		snippet.WriteString(highlightClose)
+		position = match[1]
+	}
+	snippet.WriteString(html.EscapeString(string(original[position:end])))
+
+	if end < len(original) {
+		snippet.WriteString(ellipsis)
+	}
+
+	return snippet.String(), true
+}
+
+// findMatches returns the [start, end) rune positions of the whole word occurrences of the
+// terms in text, in order and without overlaps.
+func findMatches(text []rune, terms [][]rune) [][2]int {
+	matches := [][2]int{}
+
+	for i := 0; i < len(text); i++ {
+		if i > 0 && isWordRune(text[i-1]) {
+			continue
+		}
+
+		longest := 0
+		for _, term := range terms {
+			end := i + len(term)
+			if end > len(text) || len(term) <= longest || string(text[i:end]) != string(term) {
+				continue
+			}
+			if end < len(text) && isWordRune(text[end]) {
+				continue
+			}
+			longest = len(term)
+		}
+
+		if longest > 0 {
+			matches = append(matches, [2]int{i, i + longest})
+			i += longest - 1
+		}
+	}
+
+	return matches
+}
+
+func foldRunes(runes []rune) []rune {
+	folded := make([]rune, len(runes))
+	for i, r := range runes {
+		r = unicode.ToLower(r)
+		if base, ok := foldedRunes[r]; ok {
+			r = base
+		}
+		folded[i] = r
+	}
+
+	return folded
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,62 @@
+package designpatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	terms := searchTerms(`Fábrica "abstracta" -singleton fabrica`)
+
+	require.Equal(t, [][]rune{[]rune("fabrica"), []rune("abstracta")}, terms)
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	tt := []struct {
+		name            string
+		text            string
+		query           string
+		expectedSnippet string
+		expectedFound   bool
+	}{
+		{
+			name:            "match is case and accent insensitive",
+			text:            "El patrón Observer",
+			query:           "PATRON",
+			expectedSnippet: "El <mark>patrón</mark> Observer",
+			expectedFound:   true,
+		},
+		{
+			name:            "only whole words match",
+			text:            "Factory Method",
+			query:           "fact",
+			expectedSnippet: "",
+			expectedFound:   false,
+		},
+		{
+			name:            "text is escaped",
+			text:            "Use <T> generics",
+			query:           "generics",
+			expectedSnippet: "Use &lt;T&gt; <mark>generics</mark>",
+			expectedFound:   true,
+		},
+		{
+			name:            "long text is trimmed around the first match",
+			text:            strings.Repeat("lorem ", 20) + "adapter" + strings.Repeat(" ipsum", 20),
+			query:           "adapter",
+			expectedSnippet: "…" + strings.Repeat("lorem ", 10) + "<mark>adapter</mark>" + strings.Repeat(" ipsum", 10) + "…",
+			expectedFound:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			snippet, found := highlightSnippet(tc.text, searchTerms(tc.query))
+
+			require.Equal(t, tc.expectedFound, found)
+			require.Equal(t, tc.expectedSnippet, snippet)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package designpatters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// Status is a stage of the lifecycle of a DesignPattern. Only published DesignPatterns are
+// public.
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusInReview  Status = repository.StatusInReview
+	StatusPublished Status = repository.StatusPublished
+	StatusArchived  Status = "archived"
+)
+
+// transitions holds the statuses a DesignPattern can move to from each Status. Moving from
+// in review to in review reschedules the publication.
+var transitions = map[Status][]Status{
+	StatusDraft:     {StatusInReview, StatusArchived},
+	StatusInReview:  {StatusDraft, StatusInReview, StatusPublished, StatusArchived},
+	StatusPublished: {StatusDraft, StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+// Transition moves a DesignPattern to another Status.
+type Transition struct {
+	Status Status
+	// PublishAt schedules the publication of a DesignPattern moving to in review. It must be
+	// in the future.
+	PublishAt *time.Time
+	// Version is the version the transition was requested against, or zero to move
+	// whatever version is stored.
+	Version int64
+	// Authorize, when set, is called with the Status the DesignPattern moves from and the one
+	// it moves to before moving it. Its error is returned as is.
+	Authorize func(from, to Status) error
+}
+
+// Transition moves the DesignPattern with the given ID to another Status. It returns
+// ErrInvalidStatus for unknown statuses, an Error with CodeConflict when the lifecycle
+// doesn't allow the transition, a *ValidationError when PublishAt is invalid and
+// ErrVersionMismatch when the DesignPattern changed since the version of the transition.
+func (s *Service) Transition(ctx context.Context, id string, transition Transition) (DesignPattern, error) {
+	if _, ok := transitions[transition.Status]; !ok {
+		return DesignPattern{}, ErrInvalidStatus
+	}
+
+	current, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
+
+	if transition.Version != 0 && transition.Version != current.Version {
+		return DesignPattern{}, ErrVersionMismatch
+	}
+
+	from := statusOf(current)
+	if transition.Authorize != nil {
+		if err := transition.Authorize(from, transition.Status); err != nil {
+			return DesignPattern{}, err
+		}
+	}
+
+	if !canTransition(from, transition.Status) {
+		return DesignPattern{}, &Error{
+			Code:    CodeConflict,
+			Message: fmt.Sprintf("Design Pattern can't move from %s to %s", from, transition.Status),
+		}
+	}
+
+	now := time.Now().UTC()
+	if transition.PublishAt != nil {
+		if transition.Status != StatusInReview {
+			return DesignPattern{}, &ValidationError{Fields: []FieldError{{Field: "publishAt", Message: "can only be set when moving to in_review"}}}
+		}
+		if !transition.PublishAt.After(now) {
+			return DesignPattern{}, &ValidationError{Fields: []FieldError{{Field: "publishAt", Message: "must be in the future"}}}
+		}
+	}
+
+	current.Status = string(transition.Status)
+	current.PublishAt = transition.PublishAt
+	if transition.Status == StatusPublished {
+		current.PublishedAt = &now
+	}
+
+	updated, err := s.db.UpdateStatus(ctx, current)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "updating design pattern status", err, "id", id)
+	}
+	s.cache.invalidate(id)
+
+	return repositoryModelToServiceModel(updated), nil
+}
+
+// PublishDue publishes the DesignPatterns in review whose PublishAt has come and returns how
+// many were published.
+func (s *Service) PublishDue(ctx context.Context) (int64, error) {
+	published, err := s.db.PublishDue(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, s.repositoryError(ctx, "publishing scheduled design patterns", err)
+	}
+	if published > 0 {
+		s.cache.invalidateAll()
+	}
+
+	return published, nil
+}
+
+// RunScheduler calls PublishDue right away and then every interval, until ctx is done.
+func (s *Service) RunScheduler(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		// Errors are logged by PublishDue and the next run retries.
+		if published, err := s.PublishDue(ctx); err == nil && published > 0 {
+			s.logger.InfoContext(ctx, "scheduled design patterns published", "count", published)
+		}
+	})
+}
+
+func canTransition(from, to Status) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// statusOf returns the Status of a stored DesignPattern. DesignPatterns stored before the
+// lifecycle don't have one and are published.
+func statusOf(designPattern repository.DesignPattern) Status {
+	if designPattern.Status == "" {
+		return StatusPublished
+	}
+
+	return Status(designPattern.Status)
+}
+
+// runEvery calls task right away and then every interval, until ctx is done.
+func runEvery(ctx context.Context, interval time.Duration, task func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
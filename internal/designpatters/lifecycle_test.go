@@ -0,0 +1,200 @@
+package designpatters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+func TestService_Transition(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	errUnpublishDenied := errors.New("unpublish denied")
+	denyUnpublish := func(from, to Status) error {
+		if from == StatusPublished {
+			return errUnpublishDenied
+		}
+		return nil
+	}
+
+	tt := []struct {
+		name              string
+		id                string
+		transition        Transition
+		expectedStatus    Status
+		expectedPublished bool
+		expectedError     error
+	}{
+		{
+			name:           "ok submit for review",
+			id:             "draft",
+			transition:     Transition{Status: StatusInReview},
+			expectedStatus: StatusInReview,
+		},
+		{
+			name:           "ok schedule",
+			id:             "draft",
+			transition:     Transition{Status: StatusInReview, PublishAt: &future},
+			expectedStatus: StatusInReview,
+		},
+		{
+			name:              "ok publish",
+			id:                "in-review",
+			transition:        Transition{Status: StatusPublished},
+			expectedStatus:    StatusPublished,
+			expectedPublished: true,
+		},
+		{
+			name:           "ok archive published",
+			id:             "ok",
+			transition:     Transition{Status: StatusArchived},
+			expectedStatus: StatusArchived,
+		},
+		{
+			name:           "ok authorized",
+			id:             "draft",
+			transition:     Transition{Status: StatusInReview, Authorize: denyUnpublish},
+			expectedStatus: StatusInReview,
+		},
+		{
+			name:          "error not authorized",
+			id:            "ok",
+			transition:    Transition{Status: StatusArchived, Authorize: denyUnpublish},
+			expectedError: errUnpublishDenied,
+		},
+		{
+			name:          "error invalid status",
+			id:            "draft",
+			transition:    Transition{Status: "live"},
+			expectedError: ErrInvalidStatus,
+		},
+		{
+			name:       "error transition not allowed",
+			id:         "draft",
+			transition: Transition{Status: StatusPublished},
+			expectedError: &Error{
+				Code:    CodeConflict,
+				Message: "Design Pattern can't move from draft to published",
+			},
+		},
+		{
+			name:       "error publish at without review",
+			id:         "ok",
+			transition: Transition{Status: StatusDraft, PublishAt: &future},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "publishAt", Message: "can only be set when moving to in_review"},
+			}},
+		},
+		{
+			name:       "error publish at in the past",
+			id:         "draft",
+			transition: Transition{Status: StatusInReview, PublishAt: &past},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "publishAt", Message: "must be in the future"},
+			}},
+		},
+		{
+			name:          "error version mismatch",
+			id:            "ok",
+			transition:    Transition{Status: StatusArchived, Version: 3},
+			expectedError: ErrVersionMismatch,
+		},
+		{
+			name:          "error not found",
+			id:            "not-found",
+			transition:    Transition{Status: StatusArchived},
+			expectedError: ErrDesignPatternNotFound,
+		},
+		{
+			name:          "error updating",
+			id:            "update-error",
+			transition:    Transition{Status: StatusArchived},
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Transition(context.Background(), tc.id, tc.transition)
+
+			require.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+			require.Equal(t, tc.expectedStatus, response.Status)
+			require.Equal(t, tc.transition.PublishAt, response.PublishAt)
+			require.Equal(t, tc.expectedPublished, response.PublishedAt != nil)
+			require.Equal(t, int64(1), response.Version)
+		})
+	}
+}
+
+func TestService_PublishDue(t *testing.T) {
+	db := designPatternRepositoryMock{}
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+	published, err := service.PublishDue(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), published)
+}
+
+// publishRecorderMock records the times PublishDue is called with.
+type publishRecorderMock struct {
+	designPatternRepositoryMock
+	times chan time.Time
+}
+
+func (p publishRecorderMock) PublishDue(_ context.Context, now time.Time) (int64, error) {
+	p.times <- now
+	return 0, nil
+}
+
+func TestService_RunScheduler(t *testing.T) {
+	db := publishRecorderMock{times: make(chan time.Time)}
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.RunScheduler(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case now := <-db.times:
+			require.WithinDuration(t, time.Now(), now, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("scheduler didn't run")
+		}
+	}
+
+	cancel()
+	// A run may be waiting to record its time when the context is cancelled.
+	go func() {
+		for range db.times {
+		}
+	}()
+
+	select {
+	case <-done:
+		close(db.times)
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduler didn't stop")
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	require.Equal(t, StatusPublished, statusOf(repository.DesignPattern{}))
+	require.Equal(t, StatusArchived, statusOf(repository.DesignPattern{Status: string(StatusArchived)}))
+}
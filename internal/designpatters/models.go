@@ -1,10 +1,220 @@
 package designpatters
 
-import "github.com/waydevs/sections-api/internal/platform/repository"
+import (
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/markdown"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
 
 type DesignPattern struct {
-	ID          string               `json:"id"`
+	ID string `json:"id"`
+	// Slug and SlugAliases are read-only, they are generated from the title on creation and
+	// change with RenameSlug.
+	Slug        string               `json:"slug"`
+	SlugAliases []string             `json:"slugAliases,omitempty"`
+	Title       string               `json:"title"`
+	Subtitle    string               `json:"subtitle"`
+	ContentData []repository.Content `json:"contentData"`
+	// TableOfContents is only set on reads asking for HTML, it lists the headings of the
+	// markdown in ContentData.
+	TableOfContents []markdown.Heading `json:"tableOfContents,omitempty"`
+	Category        Category           `json:"category,omitempty"`
+	// Tags are free-form, they are stored in lowercase without repeated ones.
+	Tags []string `json:"tags,omitempty"`
+	// Version is incremented by every write. On updates, it is the version the changes were
+	// made against, or zero to update whatever version is stored.
+	Version int64 `json:"version"`
+	// DeletedAt is set while the DesignPattern is in the trash.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Status, PublishAt and PublishedAt are read-only, they change with Transition.
+	Status      Status     `json:"status"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Locale is the locale of the content returned by reads. Writes always change the
+	// content in the source locale.
+	Locale string `json:"locale,omitempty"`
+}
+
+// ReadOptions tells which DesignPatterns a read can return and in which locale.
+type ReadOptions struct {
+	// IncludeDrafts returns DesignPatterns in any Status, not only published ones.
+	IncludeDrafts bool
+	// Locales are the locales the content is preferred in, most preferred first.
+	Locales []string
+	// RenderHTML sets the HTML of the markdown and callout blocks and the table of contents.
+	RenderHTML bool
+}
+
+// ListParams are the pagination, sorting and filtering parameters to list DesignPatterns.
+type ListParams struct {
+	// Page is 1-based. Zero means the first page.
+	Page int
+	// Limit is the page size. Zero means DefaultListLimit.
+	Limit int
+	// Sort is one of "title", "createdAt", optionally prefixed with "-" for descending order.
+	Sort          string
+	Title         string
+	Subtitle      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// IncludeDrafts lists DesignPatterns in any Status, not only published ones.
+	IncludeDrafts bool
+	// Locales are the locales the content is preferred in, most preferred first.
+	Locales  []string
+	Category Category
+	// AnyTags lists DesignPatterns with at least one of the tags and AllTags those with
+	// every one of them.
+	AnyTags []string
+	AllTags []string
+	// RenderHTML sets the HTML of the markdown and callout blocks and the table of contents.
+	RenderHTML bool
+}
+
+// ListResult is a page of DesignPatterns.
+type ListResult struct {
+	Items []DesignPattern
+	Page  int
+	Limit int
+	Total int64
+}
+
+// TrashParams are the pagination parameters to list the DesignPatterns in the trash.
+type TrashParams struct {
+	// Page is 1-based. Zero means the first page.
+	Page int
+	// Limit is the page size. Zero means DefaultListLimit.
+	Limit int
+}
+
+// Revision is an immutable snapshot of a DesignPattern, stored on every change of its
+// content. Number is the version of the DesignPattern the snapshot holds.
+type Revision struct {
+	Number    int64         `json:"number"`
+	Snapshot  DesignPattern `json:"snapshot"`
+	Author    string        `json:"author"`
+	Note      string        `json:"note"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// RevisionsParams are the pagination parameters to list the Revisions of a DesignPattern.
+type RevisionsParams struct {
+	// Page is 1-based. Zero means the first page.
+	Page int
+	// Limit is the page size. Zero means DefaultListLimit.
+	Limit int
+}
+
+// RevisionsResult is a page of Revisions, newest first.
+type RevisionsResult struct {
+	Items []Revision
+	Page  int
+	Limit int
+	Total int64
+}
+
+// Diff holds the changes between two Revisions of a DesignPattern.
+type Diff struct {
+	From   int64         `json:"from"`
+	To     int64         `json:"to"`
+	Fields []FieldChange `json:"fields"`
+	Blocks []BlockChange `json:"blocks"`
+}
+
+// FieldChange is a top-level field that changed between two Revisions.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// BlockChangeType tells how a content block changed between two Revisions.
+type BlockChangeType string
+
+const (
+	BlockAdded    BlockChangeType = "added"
+	BlockRemoved  BlockChangeType = "removed"
+	BlockModified BlockChangeType = "modified"
+)
+
+// BlockChange is a content block that changed between two Revisions. From is nil for added
+// blocks and To is nil for removed ones. Fields lists the fields of modified blocks that
+// changed.
+type BlockChange struct {
+	Index  int                 `json:"index"`
+	Change BlockChangeType     `json:"change"`
+	Fields []string            `json:"fields,omitempty"`
+	From   *repository.Content `json:"from,omitempty"`
+	To     *repository.Content `json:"to,omitempty"`
+}
+
+// SearchParams are the parameters of a full-text search over DesignPatterns.
+type SearchParams struct {
+	Query string
+	// Page is 1-based. Zero means the first page.
+	Page int
+	// Limit is the page size. Zero means DefaultListLimit.
+	Limit int
+	// Locales are the locales the content is preferred in, most preferred first.
+	Locales []string
+}
+
+// SearchResult is a page of DesignPatterns matching a full-text search, most relevant first.
+type SearchResult struct {
+	Items []SearchHit
+	Page  int
+	Limit int
+	Total int64
+}
+
+// SearchHit is a DesignPattern matching a full-text search.
+type SearchHit struct {
+	DesignPattern
+	Score      float64     `json:"score"`
+	Highlights []Highlight `json:"highlights"`
+}
+
+// Highlight is an excerpt of the field where a search matched, with the matching words
+// wrapped in <mark> tags and the rest of the text HTML escaped.
+type Highlight struct {
+	Field   string `json:"field"`
+	Snippet string `json:"snippet"`
+}
+
+// TranslationStatus tells whether a DesignPattern is translated to a locale and whether the
+// translation is up to date with the content in the source locale.
+type TranslationStatus string
+
+const (
+	TranslationCurrent  TranslationStatus = "current"
+	TranslationOutdated TranslationStatus = "outdated"
+	TranslationMissing  TranslationStatus = "missing"
+)
+
+// Translation is the content of a DesignPattern in another locale than its source one.
+type Translation struct {
+	Locale      string               `json:"locale"`
 	Title       string               `json:"title"`
 	Subtitle    string               `json:"subtitle"`
 	ContentData []repository.Content `json:"contentData"`
+	// Status is current or outdated, when the source content changed after the translation.
+	Status    TranslationStatus `json:"status"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	// Version is the version of the DesignPattern holding the translation.
+	Version int64 `json:"version"`
+}
+
+// TranslationReport tells which locales a DesignPattern is missing or has outdated
+// translations in.
+type TranslationReport struct {
+	SourceLocale string         `json:"sourceLocale"`
+	Locales      []LocaleStatus `json:"locales"`
+}
+
+// LocaleStatus is the TranslationStatus of a DesignPattern in a locale. UpdatedAt is nil
+// when the translation is missing.
+type LocaleStatus struct {
+	Locale    string            `json:"locale"`
+	Status    TranslationStatus `json:"status"`
+	UpdatedAt *time.Time        `json:"updatedAt,omitempty"`
 }
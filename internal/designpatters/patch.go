@@ -0,0 +1,372 @@
+package designpatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchType is the format of a patch document.
+type PatchType string
+
+const (
+	// MergePatch is a JSON Merge Patch (RFC 7396).
+	MergePatch PatchType = "merge-patch"
+
+	// JSONPatch is a JSON Patch (RFC 6902).
+	JSONPatch PatchType = "json-patch"
+)
+
+// Patch is a document describing changes to a DesignPattern.
+type Patch struct {
+	Type     PatchType
+	Document []byte
+	// Version is the version the patch was made against, or zero to patch whatever
+	// version is stored.
+	Version int64
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+func invalidPatch(format string, args ...interface{}) error {
+	return &Error{Code: CodeInvalidArgument, Message: "Invalid patch, " + fmt.Sprintf(format, args...)}
+}
+
+// conflictingPatch is returned when a well-formed patch can't be applied to the current
+// state of the DesignPattern.
+func conflictingPatch(format string, args ...interface{}) error {
+	return &Error{Code: CodeConflict, Message: "Patch can't be applied, " + fmt.Sprintf(format, args...)}
+}
+
+// applyPatch applies patch to the JSON representation of designPattern.
+func applyPatch(designPattern DesignPattern, patch Patch) (DesignPattern, error) {
+	raw, err := json.Marshal(designPattern)
+	if err != nil {
+		return DesignPattern{}, err
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return DesignPattern{}, err
+	}
+
+	switch patch.Type {
+	case MergePatch:
+		var mergePatch interface{}
+		if err := json.Unmarshal(patch.Document, &mergePatch); err != nil {
+			return DesignPattern{}, invalidPatch("it must be a JSON document")
+		}
+		document = applyMergePatch(document, mergePatch)
+
+	case JSONPatch:
+		var operations []patchOperation
+		if err := json.Unmarshal(patch.Document, &operations); err != nil {
+			return DesignPattern{}, invalidPatch("it must be an array of operations")
+		}
+		for i, operation := range operations {
+			if document, err = applyOperation(document, operation); err != nil {
+				return DesignPattern{}, fmt.Errorf("operation %d: %w", i, err)
+			}
+		}
+
+	default:
+		return DesignPattern{}, invalidPatch("unknown patch type %q", patch.Type)
+	}
+
+	if raw, err = json.Marshal(document); err != nil {
+		return DesignPattern{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var patched DesignPattern
+	if err := decoder.Decode(&patched); err != nil {
+		return DesignPattern{}, invalidPatch("the result is not a Design Pattern: %s", strings.TrimPrefix(err.Error(), "json: "))
+	}
+
+	return patched, nil
+}
+
+// applyMergePatch implements the MergePatch algorithm of RFC 7396.
+func applyMergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = applyMergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
+
+func applyOperation(document interface{}, operation patchOperation) (interface{}, error) {
+	if operation.Path == nil {
+		return nil, invalidPatch("%q operation without path", operation.Op)
+	}
+	path, err := parsePointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, invalidPatch("%q operation without value", operation.Op)
+		}
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return nil, invalidPatch("%q operation with an invalid value", operation.Op)
+		}
+
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, invalidPatch("%q operation without from", operation.Op)
+		}
+		from, err := parsePointer(*operation.From)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = getValue(document, from); err != nil {
+			return nil, err
+		}
+
+		if operation.Op == "move" {
+			if len(from) < len(path) && reflect.DeepEqual(from, path[:len(from)]) {
+				return nil, invalidPatch("can't move %q into one of its children", *operation.From)
+			}
+			if document, err = removeValue(document, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = copyValue(value)
+		}
+	}
+
+	switch operation.Op {
+	case "add", "move", "copy":
+		return addValue(document, path, value)
+	case "remove":
+		return removeValue(document, path)
+	case "replace":
+		return replaceValue(document, path, value)
+	case "test":
+		current, err := getValue(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, conflictingPatch("test of %q failed", *operation.Path)
+		}
+		return document, nil
+	default:
+		return nil, invalidPatch("unknown operation %q", operation.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, invalidPatch("%q is not a JSON pointer", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+func pointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+
+	return b.String()
+}
+
+// arrayIndex parses token as an index of an array with the given length. Indexes equal to
+// the length are only valid when adding, as they append.
+func arrayIndex(token string, length int, adding bool) (int, error) {
+	if adding && token == "-" {
+		return length, nil
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, invalidPatch("%q is not an array index", token)
+	}
+
+	if index > length || (index == length && !adding) {
+		return 0, conflictingPatch("index %d is out of bounds", index)
+	}
+
+	return index, nil
+}
+
+func getValue(document interface{}, path []string) (interface{}, error) {
+	for i, token := range path {
+		switch node := document.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, conflictingPatch("%q does not exist", pointer(path[:i+1]))
+			}
+			document = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			document = node[index]
+		default:
+			return nil, conflictingPatch("%q does not exist", pointer(path[:i+1]))
+		}
+	}
+
+	return document, nil
+}
+
+// modifyParent calls modify with the parent of the value at path and the last token of
+// path, and stores the parent it returns, as arrays change when growing or shrinking.
+func modifyParent(document interface{}, path []string, modify func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return modify(document, path[0])
+	}
+
+	child, err := getValue(document, path[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	child, err = modifyParent(child, path[1:], modify)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node := document.(type) {
+	case map[string]interface{}:
+		node[path[0]] = child
+	case []interface{}:
+		index, _ := arrayIndex(path[0], len(node), false)
+		node[index] = child
+	}
+
+	return document, nil
+}
+
+func addValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return modifyParent(document, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		default:
+			return nil, conflictingPatch("%q does not exist", pointer(path[:len(path)-1]))
+		}
+	})
+}
+
+func removeValue(document interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, invalidPatch("the whole Design Pattern can't be removed")
+	}
+
+	return modifyParent(document, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, conflictingPatch("%q does not exist", pointer(path))
+			}
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:index], node[index+1:]...), nil
+		default:
+			return nil, conflictingPatch("%q does not exist", pointer(path))
+		}
+	})
+}
+
+func replaceValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if _, err := getValue(document, path); err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return modifyParent(document, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+		case []interface{}:
+			index, _ := arrayIndex(token, len(node), false)
+			node[index] = value
+		}
+		return parent, nil
+	})
+}
+
+// copyValue returns a deep copy of a decoded JSON value, so a copied value can be patched
+// without changing the original.
+func copyValue(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for key, child := range node {
+			copied[key] = copyValue(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, child := range node {
+			copied[i] = copyValue(child)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,132 @@
+package designpatters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+func TestApplyPatch(t *testing.T) {
+	original := DesignPattern{
+		ID:       "638d568a507b6e07cd39de82",
+		Title:    "Singleton",
+		Subtitle: "Creational",
+		ContentData: []repository.Content{
+			{Type: repository.BlockImage, Title: "Intent", URL: "https://example.com/a.png", Alt: "One instance"},
+			{Type: repository.BlockMarkdown, Title: "Usage", Text: "Loggers"},
+		},
+	}
+
+	tt := []struct {
+		name          string
+		patch         Patch
+		expected      DesignPattern
+		expectedError string
+	}{
+		{
+			name:  "merge patch",
+			patch: Patch{Type: MergePatch, Document: []byte(`{"subtitle":"Creational pattern"}`)},
+			expected: DesignPattern{
+				ID:          original.ID,
+				Title:       "Singleton",
+				Subtitle:    "Creational pattern",
+				ContentData: original.ContentData,
+			},
+		},
+		{
+			name:  "merge patch removing a member",
+			patch: Patch{Type: MergePatch, Document: []byte(`{"contentData":null}`)},
+			expected: DesignPattern{
+				ID:       original.ID,
+				Title:    "Singleton",
+				Subtitle: "Creational",
+			},
+		},
+		{
+			name:          "merge patch with unknown member",
+			patch:         Patch{Type: MergePatch, Document: []byte(`{"author":"me"}`)},
+			expectedError: `Invalid patch, the result is not a Design Pattern: unknown field "author"`,
+		},
+		{
+			name:          "malformed merge patch",
+			patch:         Patch{Type: MergePatch, Document: []byte(`{`)},
+			expectedError: "Invalid patch, it must be a JSON document",
+		},
+		{
+			name: "json patch",
+			patch: Patch{Type: JSONPatch, Document: []byte(`[
+				{"op":"test","path":"/contentData/1/title","value":"Usage"},
+				{"op":"replace","path":"/contentData/1/text","value":"Configuration"},
+				{"op":"add","path":"/contentData/-","value":{"type":"callout","severity":"warning","text":"Global state"}},
+				{"op":"copy","from":"/contentData/0/title","path":"/contentData/2/title"},
+				{"op":"remove","path":"/contentData/0/alt"},
+				{"op":"move","from":"/contentData/2","path":"/contentData/0"}
+			]`)},
+			expected: DesignPattern{
+				ID:       original.ID,
+				Title:    "Singleton",
+				Subtitle: "Creational",
+				ContentData: []repository.Content{
+					{Type: repository.BlockCallout, Title: "Intent", Severity: "warning", Text: "Global state"},
+					{Type: repository.BlockImage, Title: "Intent", URL: "https://example.com/a.png"},
+					{Type: repository.BlockMarkdown, Title: "Usage", Text: "Configuration"},
+				},
+			},
+		},
+		{
+			name:          "json patch with failed test",
+			patch:         Patch{Type: JSONPatch, Document: []byte(`[{"op":"test","path":"/title","value":"Factory"}]`)},
+			expectedError: `operation 0: Patch can't be applied, test of "/title" failed`,
+		},
+		{
+			name:          "json patch with missing path",
+			patch:         Patch{Type: JSONPatch, Document: []byte(`[{"op":"remove","path":"/contentData/5"}]`)},
+			expectedError: "operation 0: Patch can't be applied, index 5 is out of bounds",
+		},
+		{
+			name:          "json patch with unknown operation",
+			patch:         Patch{Type: JSONPatch, Document: []byte(`[{"op":"merge","path":"/title"}]`)},
+			expectedError: `operation 0: Invalid patch, unknown operation "merge"`,
+		},
+		{
+			name:          "json patch without value",
+			patch:         Patch{Type: JSONPatch, Document: []byte(`[{"op":"add","path":"/title"}]`)},
+			expectedError: `operation 0: Invalid patch, "add" operation without value`,
+		},
+		{
+			name:          "json patch moving into a child",
+			patch:         Patch{Type: JSONPatch, Document: []byte(`[{"op":"move","from":"/contentData","path":"/contentData/0"}]`)},
+			expectedError: `operation 0: Invalid patch, can't move "/contentData" into one of its children`,
+		},
+		{
+			name:          "malformed json patch",
+			patch:         Patch{Type: JSONPatch, Document: []byte(`{"op":"remove"}`)},
+			expectedError: "Invalid patch, it must be an array of operations",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			patched, err := applyPatch(original, tc.patch)
+
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, patched)
+		})
+	}
+}
+
+func TestParsePointer(t *testing.T) {
+	tokens, err := parsePointer("/a~1b/c~0d/0")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/b", "c~d", "0"}, tokens)
+	require.Equal(t, "/a~1b/c~0d/0", pointer(tokens))
+
+	_, err = parsePointer("title")
+	require.Equal(t, CodeInvalidArgument, CodeOf(err))
+}
@@ -0,0 +1,90 @@
+package designpatters
+
+import (
+	"github.com/waydevs/sections-api/internal/platform/cache"
+	"github.com/waydevs/sections-api/internal/platform/markdown"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// DefaultRenderCacheSize is how many rendered DesignPatterns are kept, unless
+// WithRenderCacheSize sets another size.
+const DefaultRenderCacheSize = 1000
+
+// WithRenderCacheSize sets how many rendered DesignPatterns are kept, so reads of the same
+// version in the same locale don't render its markdown again. Zero disables the cache.
+func WithRenderCacheSize(size int) Option {
+	return func(s *Service) {
+		s.rendered = cache.NewLRU[renderKey, rendering](size, 0)
+	}
+}
+
+// rendering is the HTML of each block of a DesignPattern, empty for blocks without markdown,
+// and its table of contents.
+type rendering struct {
+	blocks          []string
+	tableOfContents []markdown.Heading
+}
+
+// render sets the HTML of the markdown and callout blocks of designPattern and its table of
+// contents, rendering them only when the version in its locale isn't cached.
+func (s *Service) render(designPattern DesignPattern) DesignPattern {
+	key := renderKey{id: designPattern.ID, version: designPattern.Version, locale: designPattern.Locale}
+
+	result, ok := s.rendered.Get(key)
+	if !ok {
+		result = renderBlocks(designPattern.ContentData)
+		s.rendered.Set(key, result)
+	}
+
+	blocks := make([]repository.Content, len(designPattern.ContentData))
+	copy(blocks, designPattern.ContentData)
+	for i := range blocks {
+		blocks[i].HTML = result.blocks[i]
+	}
+	designPattern.ContentData = blocks
+	designPattern.TableOfContents = result.tableOfContents
+
+	return designPattern
+}
+
+// storedBlocks returns blocks without the HTML set by reads, so content sent back as it was
+// read is stored the same as the content it was rendered from.
+func storedBlocks(blocks []repository.Content) []repository.Content {
+	if blocks == nil {
+		return nil
+	}
+
+	stored := make([]repository.Content, len(blocks))
+	for i, block := range blocks {
+		block.HTML = ""
+		stored[i] = block
+	}
+
+	return stored
+}
+
+// renderBlocks renders the markdown of blocks, with heading IDs unique across all of them.
+func renderBlocks(blocks []repository.Content) rendering {
+	anchors := markdown.NewAnchors()
+	result := rendering{blocks: make([]string, len(blocks))}
+
+	for i, block := range blocks {
+		if block.Type != repository.BlockMarkdown && block.Type != repository.BlockCallout {
+			continue
+		}
+
+		html, headings := markdown.Render(block.Text, anchors)
+		result.blocks[i] = html
+		result.tableOfContents = append(result.tableOfContents, headings...)
+	}
+
+	return result
+}
+
+// renderKey identifies the content of a DesignPattern: writes increment its version, so
+// cached renderings of previous versions are never read again and age out.
+type renderKey struct {
+	id      string
+	version int64
+	locale  string
+}
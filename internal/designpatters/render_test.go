@@ -0,0 +1,90 @@
+package designpatters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/markdown"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+func TestRenderBlocks(t *testing.T) {
+	result := renderBlocks([]repository.Content{
+		{Type: repository.BlockMarkdown, Title: "Uso", Text: "## Cuándo\nCon *un* solo logger"},
+		{Type: repository.BlockCode, Language: "go", Code: "# no es markdown"},
+		{Type: repository.BlockCallout, Severity: "warning", Text: "## Cuándo\n<script>alert(1)</script>"},
+	})
+
+	require.Equal(t, []string{
+		"<h2 id=\"cuándo\">Cuándo</h2>\n<p>Con <em>un</em> solo logger</p>",
+		"",
+		"<h2 id=\"cuándo-2\">Cuándo</h2>\n<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>",
+	}, result.blocks)
+	require.Equal(t, []markdown.Heading{
+		{Level: 2, ID: "cuándo", Text: "Cuándo"},
+		{Level: 2, ID: "cuándo-2", Text: "Cuándo"},
+	}, result.tableOfContents)
+}
+
+func TestService_GetByID_RenderHTML(t *testing.T) {
+	db := designPatternRepositoryMock{}
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard(), testLocales)
+
+	response, err := service.GetByID(context.Background(), "translated", ReadOptions{Locales: []string{"en"}, RenderHTML: true})
+
+	require.NoError(t, err)
+	require.Equal(t, []repository.Content{
+		{Type: repository.BlockMarkdown, Title: "Use", Text: "Ensures a single instance", HTML: "<p>Ensures a single instance</p>"},
+		{Type: repository.BlockImage, URL: "https://waydevs.com/uso.png", Alt: "Class diagram"},
+		{Type: repository.BlockMarkdown, Title: "Ejemplo", Text: "Una conexión a la base de datos", HTML: "<p>Una conexión a la base de datos</p>"},
+	}, response.ContentData)
+
+	// The stored DesignPattern is not changed by rendering.
+	unrendered, err := service.GetByID(context.Background(), "translated", ReadOptions{Locales: []string{"en"}})
+	require.NoError(t, err)
+	require.Empty(t, unrendered.ContentData[0].HTML)
+}
+
+func TestService_Render_Cache(t *testing.T) {
+	designPattern := DesignPattern{
+		ID:          "000000000000000000000000",
+		Version:     3,
+		Locale:      "es",
+		ContentData: []repository.Content{{Type: repository.BlockMarkdown, Text: "# Uso"}},
+	}
+	changed := designPattern
+	changed.ContentData = []repository.Content{{Type: repository.BlockMarkdown, Text: "# Ejemplo"}}
+	newer := changed
+	newer.Version = 4
+
+	tt := []struct {
+		name             string
+		cacheSize        int
+		expectedChanged  string
+		expectedVersion4 string
+	}{
+		{name: "cached per version", cacheSize: 1, expectedChanged: "<h1 id=\"uso\">Uso</h1>", expectedVersion4: "<h1 id=\"ejemplo\">Ejemplo</h1>"},
+		{name: "cache disabled", cacheSize: 0, expectedChanged: "<h1 id=\"ejemplo\">Ejemplo</h1>", expectedVersion4: "<h1 id=\"ejemplo\">Ejemplo</h1>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(designPatternRepositoryMock{}, &revisionRepositoryMock{}, logging.Discard(), WithRenderCacheSize(tc.cacheSize))
+
+			service.render(designPattern)
+
+			require.Equal(t, tc.expectedChanged, service.render(changed).ContentData[0].HTML)
+			require.Equal(t, tc.expectedVersion4, service.render(newer).ContentData[0].HTML)
+		})
+	}
+}
+
+func TestStoredBlocks(t *testing.T) {
+	blocks := []repository.Content{{Type: repository.BlockMarkdown, Text: "# Uso", HTML: "<h1 id=\"uso\">Uso</h1>"}}
+
+	require.Equal(t, []repository.Content{{Type: repository.BlockMarkdown, Text: "# Uso"}}, storedBlocks(blocks))
+	require.Equal(t, "<h1 id=\"uso\">Uso</h1>", blocks[0].HTML)
+	require.Nil(t, storedBlocks(nil))
+}
@@ -0,0 +1,202 @@
+package designpatters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/paging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// RevisionRepository is a repository for the Revisions of DesignPatterns.
+type RevisionRepository interface {
+	Create(ctx context.Context, revision repository.Revision) (repository.Revision, error)
+	List(ctx context.Context, designPatternID string, skip, limit int64) ([]repository.Revision, int64, error)
+	Get(ctx context.Context, designPatternID string, number int64) (repository.Revision, error)
+}
+
+// ListRevisions returns a page of the Revisions of a DesignPattern, newest first. Unless opts
+// include drafts, the Revisions of DesignPatterns that are not published are not found.
+func (s *Service) ListRevisions(ctx context.Context, id string, params RevisionsParams, opts ReadOptions) (RevisionsResult, error) {
+	if err := s.checkReadable(ctx, id, opts); err != nil {
+		return RevisionsResult{}, err
+	}
+
+	page, limit := paging.Normalize(params.Page, params.Limit)
+
+	revisions, total, err := s.revisions.List(ctx, id, int64((page-1)*limit), int64(limit))
+	if err != nil {
+		return RevisionsResult{}, s.repositoryError(ctx, "listing design pattern revisions", err, "id", id)
+	}
+
+	items := make([]Revision, 0, len(revisions))
+	for _, revision := range revisions {
+		items = append(items, repositoryRevisionToServiceRevision(revision))
+	}
+
+	return RevisionsResult{
+		Items: items,
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}, nil
+}
+
+// GetRevision returns a Revision of a DesignPattern by its number. Unless opts include drafts,
+// the Revisions of DesignPatterns that are not published are not found.
+func (s *Service) GetRevision(ctx context.Context, id string, number int64, opts ReadOptions) (Revision, error) {
+	if err := s.checkReadable(ctx, id, opts); err != nil {
+		return Revision{}, err
+	}
+
+	return s.revision(ctx, id, number)
+}
+
+// revision returns a Revision of a DesignPattern by its number, whatever its Status.
+func (s *Service) revision(ctx context.Context, id string, number int64) (Revision, error) {
+	revision, err := s.revisions.Get(ctx, id, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return Revision{}, ErrRevisionNotFound
+		}
+		return Revision{}, s.repositoryError(ctx, "getting design pattern revision", err, "id", id, "number", number)
+	}
+
+	return repositoryRevisionToServiceRevision(revision), nil
+}
+
+// DiffRevisions returns the changes from one Revision of a DesignPattern to another. Unless
+// opts include drafts, the Revisions of DesignPatterns that are not published are not found.
+func (s *Service) DiffRevisions(ctx context.Context, id string, from, to int64, opts ReadOptions) (Diff, error) {
+	if err := s.checkReadable(ctx, id, opts); err != nil {
+		return Diff{}, err
+	}
+
+	fromRevision, err := s.revision(ctx, id, from)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	toRevision, err := s.revision(ctx, id, to)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	return diff(fromRevision, toRevision), nil
+}
+
+// Rollback restores the content of a DesignPattern to the one of a previous Revision. The
+// rollback is a change like any other, so it creates a new Revision rather than discarding
+// the ones after number. When version is not zero, it returns ErrVersionMismatch unless the
+// DesignPattern is still at that version.
+func (s *Service) Rollback(ctx context.Context, id string, number, version int64) (DesignPattern, error) {
+	revision, err := s.revision(ctx, id, number)
+	if err != nil {
+		return DesignPattern{}, err
+	}
+
+	change := ChangeFrom(ctx)
+	if change.Note == "" {
+		change.Note = fmt.Sprintf("Rollback to revision %d", number)
+	}
+
+	designPattern := revision.Snapshot
+	designPattern.ID = id
+	designPattern.Version = version
+
+	return s.Update(WithChange(ctx, change), designPattern)
+}
+
+// checkReadable returns ErrDesignPatternNotFound unless the DesignPattern with the given ID
+// can be read with opts, so what is hidden from it isn't disclosed by other reads.
+func (s *Service) checkReadable(ctx context.Context, id string, opts ReadOptions) error {
+	_, err := s.readable(ctx, id, opts)
+	return err
+}
+
+// readable returns the stored DesignPattern with the given ID, or ErrDesignPatternNotFound
+// when it is not published and opts don't include drafts. It is shared with the cache, so it
+// must not be modified.
+func (s *Service) readable(ctx context.Context, id string, opts ReadOptions) (repository.DesignPattern, error) {
+	designPattern, err := s.cache.designPattern(ctx, id, s.db.GetByID)
+	if err != nil {
+		return repository.DesignPattern{}, s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
+
+	if !opts.IncludeDrafts && statusOf(designPattern) != StatusPublished {
+		return repository.DesignPattern{}, ErrDesignPatternNotFound
+	}
+
+	return designPattern, nil
+}
+
+// recordRevision stores a Revision of designPattern with the Change carried by ctx. The
+// write it records already happened, so a failure is logged rather than returned.
+func (s *Service) recordRevision(ctx context.Context, designPattern repository.DesignPattern) {
+	change := ChangeFrom(ctx)
+
+	_, err := s.revisions.Create(ctx, repository.Revision{
+		DesignPatternID: designPattern.MongoID,
+		Number:          designPattern.Version,
+		Snapshot:        designPattern,
+		Author:          change.Author,
+		Note:            change.Note,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "recording design pattern revision", "id", designPattern.MongoID.Hex(), "version", designPattern.Version, "error", err)
+	}
+}
+
+// diff compares the top-level fields of two Revisions and their content blocks position by
+// position.
+func diff(from, to Revision) Diff {
+	result := Diff{
+		From:   from.Number,
+		To:     to.Number,
+		Fields: []FieldChange{},
+		Blocks: []BlockChange{},
+	}
+
+	if from.Snapshot.Title != to.Snapshot.Title {
+		result.Fields = append(result.Fields, FieldChange{Field: "title", From: from.Snapshot.Title, To: to.Snapshot.Title})
+	}
+	if from.Snapshot.Subtitle != to.Snapshot.Subtitle {
+		result.Fields = append(result.Fields, FieldChange{Field: "subtitle", From: from.Snapshot.Subtitle, To: to.Snapshot.Subtitle})
+	}
+	if from.Snapshot.Category != to.Snapshot.Category {
+		result.Fields = append(result.Fields, FieldChange{Field: "category", From: string(from.Snapshot.Category), To: string(to.Snapshot.Category)})
+	}
+	if fromTags, toTags := strings.Join(from.Snapshot.Tags, ", "), strings.Join(to.Snapshot.Tags, ", "); fromTags != toTags {
+		result.Fields = append(result.Fields, FieldChange{Field: "tags", From: fromTags, To: toTags})
+	}
+
+	fromBlocks, toBlocks := from.Snapshot.ContentData, to.Snapshot.ContentData
+	for i := 0; i < len(fromBlocks) || i < len(toBlocks); i++ {
+		switch {
+		case i >= len(fromBlocks):
+			result.Blocks = append(result.Blocks, BlockChange{Index: i, Change: BlockAdded, To: &toBlocks[i]})
+		case i >= len(toBlocks):
+			result.Blocks = append(result.Blocks, BlockChange{Index: i, Change: BlockRemoved, From: &fromBlocks[i]})
+		default:
+			if fields := blockFieldChanges(fromBlocks[i], toBlocks[i]); len(fields) > 0 {
+				result.Blocks = append(result.Blocks, BlockChange{Index: i, Change: BlockModified, Fields: fields, From: &fromBlocks[i], To: &toBlocks[i]})
+			}
+		}
+	}
+
+	return result
+}
+
+func repositoryRevisionToServiceRevision(revision repository.Revision) Revision {
+	return Revision{
+		Number:    revision.Number,
+		Snapshot:  repositoryModelToServiceModel(revision.Snapshot),
+		Author:    revision.Author,
+		Note:      revision.Note,
+		CreatedAt: revision.CreatedAt,
+	}
+}
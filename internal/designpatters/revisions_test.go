@@ -0,0 +1,410 @@
+package designpatters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// revisionRepositoryMock holds two revisions of every DesignPattern and records the ones
+// created.
+type revisionRepositoryMock struct {
+	created []repository.Revision
+}
+
+func (r *revisionRepositoryMock) Create(_ context.Context, revision repository.Revision) (repository.Revision, error) {
+	r.created = append(r.created, revision)
+	return revision, nil
+}
+
+func (r *revisionRepositoryMock) List(_ context.Context, id string, _, _ int64) ([]repository.Revision, int64, error) {
+	switch id {
+	case "error":
+		return nil, 0, errors.New("some-error")
+
+	case "invalid-id":
+		return nil, 0, repository.ErrInvalidID
+
+	default:
+		return []repository.Revision{storedRevisions[2], storedRevisions[1]}, 2, nil
+	}
+}
+
+func (r *revisionRepositoryMock) Get(_ context.Context, id string, number int64) (repository.Revision, error) {
+	if id == "error" {
+		return repository.Revision{}, errors.New("some-error")
+	}
+
+	revision, ok := storedRevisions[number]
+	if !ok {
+		return repository.Revision{}, repository.ErrNotFound
+	}
+
+	return revision, nil
+}
+
+var storedRevisions = map[int64]repository.Revision{
+	1: {
+		Number: 1,
+		Snapshot: repository.DesignPattern{
+			Title:    "ok",
+			Subtitle: "Creational",
+			ContentData: []repository.Content{
+				{Type: repository.BlockMarkdown, Title: "Uso", Text: "Una instancia"},
+			},
+			Version: 1,
+		},
+		Author: "ana",
+		Note:   "First draft",
+	},
+	2: {
+		Number: 2,
+		Snapshot: repository.DesignPattern{
+			Title:    "error",
+			Subtitle: "Creacional",
+			ContentData: []repository.Content{
+				{Type: repository.BlockMarkdown, Title: "Uso", Text: "Una única instancia"},
+				{Type: repository.BlockCode, Title: "Ejemplo", Language: "go", Code: "var logger = sync.OnceValue(newLogger)"},
+			},
+			Category: "creational",
+			Tags:     []string{"gof", "concurrency"},
+			Version:  2,
+		},
+		Author: "luis",
+	},
+}
+
+func TestService_ListRevisions(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		opts             ReadOptions
+		expectedResponse RevisionsResult
+		expectedError    error
+	}{
+		{
+			name: "ok",
+			id:   "ok",
+			expectedResponse: RevisionsResult{
+				Items: []Revision{
+					repositoryRevisionToServiceRevision(storedRevisions[2]),
+					repositoryRevisionToServiceRevision(storedRevisions[1]),
+				},
+				Page:  1,
+				Limit: DefaultListLimit,
+				Total: 2,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok draft",
+			id:   "draft",
+			opts: ReadOptions{IncludeDrafts: true},
+			expectedResponse: RevisionsResult{
+				Items: []Revision{
+					repositoryRevisionToServiceRevision(storedRevisions[2]),
+					repositoryRevisionToServiceRevision(storedRevisions[1]),
+				},
+				Page:  1,
+				Limit: DefaultListLimit,
+				Total: 2,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error draft not found",
+			id:               "draft",
+			expectedResponse: RevisionsResult{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error invalid id",
+			id:               "invalid-id",
+			expectedResponse: RevisionsResult{},
+			expectedError:    ErrInvalidID,
+		},
+		{
+			name:             "error",
+			id:               "error",
+			expectedResponse: RevisionsResult{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(designPatternRepositoryMock{}, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.ListRevisions(context.Background(), tc.id, RevisionsParams{}, tc.opts)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_GetRevision(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		opts             ReadOptions
+		number           int64
+		expectedResponse Revision
+		expectedError    error
+	}{
+		{
+			name:             "ok",
+			id:               "ok",
+			number:           1,
+			expectedResponse: repositoryRevisionToServiceRevision(storedRevisions[1]),
+			expectedError:    nil,
+		},
+		{
+			name:             "ok in review",
+			id:               "in-review",
+			opts:             ReadOptions{IncludeDrafts: true},
+			number:           1,
+			expectedResponse: repositoryRevisionToServiceRevision(storedRevisions[1]),
+			expectedError:    nil,
+		},
+		{
+			name:             "error in review not found",
+			id:               "in-review",
+			number:           1,
+			expectedResponse: Revision{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error not found",
+			id:               "ok",
+			number:           3,
+			expectedResponse: Revision{},
+			expectedError:    ErrRevisionNotFound,
+		},
+		{
+			name:             "error",
+			id:               "error",
+			number:           1,
+			expectedResponse: Revision{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(designPatternRepositoryMock{}, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.GetRevision(context.Background(), tc.id, tc.number, tc.opts)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_DiffRevisions(t *testing.T) {
+	first := storedRevisions[1].Snapshot.ContentData
+	second := storedRevisions[2].Snapshot.ContentData
+
+	tt := []struct {
+		name             string
+		id               string
+		from             int64
+		to               int64
+		expectedResponse Diff
+		expectedError    error
+	}{
+		{
+			name: "ok",
+			id:   "ok",
+			from: 1,
+			to:   2,
+			expectedResponse: Diff{
+				From: 1,
+				To:   2,
+				Fields: []FieldChange{
+					{Field: "title", From: "ok", To: "error"},
+					{Field: "subtitle", From: "Creational", To: "Creacional"},
+					{Field: "category", From: "", To: "creational"},
+					{Field: "tags", From: "", To: "gof, concurrency"},
+				},
+				Blocks: []BlockChange{
+					{Index: 0, Change: BlockModified, Fields: []string{"text"}, From: &first[0], To: &second[0]},
+					{Index: 1, Change: BlockAdded, To: &second[1]},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok backwards",
+			id:   "ok",
+			from: 2,
+			to:   1,
+			expectedResponse: Diff{
+				From: 2,
+				To:   1,
+				Fields: []FieldChange{
+					{Field: "title", From: "error", To: "ok"},
+					{Field: "subtitle", From: "Creacional", To: "Creational"},
+					{Field: "category", From: "creational", To: ""},
+					{Field: "tags", From: "gof, concurrency", To: ""},
+				},
+				Blocks: []BlockChange{
+					{Index: 0, Change: BlockModified, Fields: []string{"text"}, From: &second[0], To: &first[0]},
+					{Index: 1, Change: BlockRemoved, From: &second[1]},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok same revision",
+			id:   "ok",
+			from: 1,
+			to:   1,
+			expectedResponse: Diff{
+				From:   1,
+				To:     1,
+				Fields: []FieldChange{},
+				Blocks: []BlockChange{},
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error not found",
+			id:               "ok",
+			from:             1,
+			to:               3,
+			expectedResponse: Diff{},
+			expectedError:    ErrRevisionNotFound,
+		},
+		{
+			name:             "error draft not found",
+			id:               "draft",
+			from:             1,
+			to:               2,
+			expectedResponse: Diff{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(designPatternRepositoryMock{}, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.DiffRevisions(context.Background(), tc.id, tc.from, tc.to, ReadOptions{})
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Rollback(t *testing.T) {
+	tt := []struct {
+		name             string
+		number           int64
+		version          int64
+		note             string
+		expectedResponse DesignPattern
+		expectedNote     string
+		expectedError    error
+	}{
+		{
+			name:   "ok",
+			number: 1,
+			expectedResponse: DesignPattern{
+				ID:          "638d568a507b6e07cd39de82",
+				Status:      StatusPublished,
+				Title:       "ok",
+				Subtitle:    "Creational",
+				ContentData: storedRevisions[1].Snapshot.ContentData,
+				Version:     1,
+			},
+			expectedNote:  "Rollback to revision 1",
+			expectedError: nil,
+		},
+		{
+			name:    "ok with note and version",
+			number:  1,
+			version: 4,
+			note:    "Revert the vandalism",
+			expectedResponse: DesignPattern{
+				ID:          "638d568a507b6e07cd39de82",
+				Status:      StatusPublished,
+				Title:       "ok",
+				Subtitle:    "Creational",
+				ContentData: storedRevisions[1].Snapshot.ContentData,
+				Version:     5,
+			},
+			expectedNote:  "Revert the vandalism",
+			expectedError: nil,
+		},
+		{
+			name:             "error version mismatch",
+			number:           1,
+			version:          staleVersion,
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrVersionMismatch,
+		},
+		{
+			name:             "error not found",
+			number:           3,
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrRevisionNotFound,
+		},
+		{
+			name:             "error",
+			number:           2,
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			revisions := &revisionRepositoryMock{}
+			service := NewService(designPatternRepositoryMock{}, revisions, logging.Discard())
+			ctx := WithChange(context.Background(), Change{Author: "ana", Note: tc.note})
+
+			response, err := service.Rollback(ctx, "638d568a507b6e07cd39de82", tc.number, tc.version)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				require.Len(t, revisions.created, 1)
+				require.Equal(t, tc.expectedNote, revisions.created[0].Note)
+			}
+		})
+	}
+}
+
+func TestService_RecordsRevisions(t *testing.T) {
+	revisions := &revisionRepositoryMock{}
+	service := NewService(designPatternRepositoryMock{}, revisions, logging.Discard())
+	ctx := WithChange(context.Background(), Change{Author: "ana", Note: "Typo"})
+
+	created, err := service.Create(ctx, DesignPattern{Title: "ok"})
+	require.NoError(t, err)
+
+	_, err = service.Patch(ctx, "ok", Patch{Type: MergePatch, Document: []byte(`{"subtitle":"patched"}`)})
+	require.NoError(t, err)
+
+	_, err = service.Update(ctx, DesignPattern{ID: "638d568a507b6e07cd39de82", Title: "ok", Version: 1})
+	require.NoError(t, err)
+
+	_, err = service.Update(ctx, DesignPattern{ID: "638d568a507b6e07cd39de82", Title: "error"})
+	require.Error(t, err)
+
+	require.Len(t, revisions.created, 3)
+	require.Equal(t, created.Title, revisions.created[0].Snapshot.Title)
+	require.Equal(t, int64(2), revisions.created[2].Number)
+	for _, revision := range revisions.created {
+		require.Equal(t, "ana", revision.Author)
+		require.Equal(t, "Typo", revision.Note)
+		require.False(t, revision.CreatedAt.IsZero())
+	}
+}
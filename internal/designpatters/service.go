@@ -2,106 +2,311 @@ package designpatters
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/waydevs/sections-api/internal/platform/cache"
+	"github.com/waydevs/sections-api/internal/platform/paging"
 	"github.com/waydevs/sections-api/internal/platform/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var (
-	// ErrSomethingWentWrong is returned when something went wrong.
-	ErrSomethingWentWrong = errors.New("Something went wrong")
+const (
+	// DefaultListLimit is the page size used when none is given.
+	DefaultListLimit = paging.DefaultLimit
 
-	// ErrDesignPatternNotFound is returned when a DesignPattern is not found.
-	ErrDesignPatternNotFound = errors.New("Design Pattern not found")
+	// MaxListLimit is the largest page size allowed.
+	MaxListLimit = paging.MaxLimit
+
+	// MaxSearchQueryLength is the longest search query allowed, in characters.
+	MaxSearchQueryLength = 200
 )
 
 // DesignPatternRepository is a repository for DesignPattern.
 type DesignPatternRepository interface {
 	GetByID(ctx context.Context, id string) (repository.DesignPattern, error)
+	List(ctx context.Context, opts repository.ListOptions) ([]repository.DesignPattern, int64, error)
+	Search(ctx context.Context, query string, skip, limit int64) ([]repository.SearchResult, int64, error)
 	Create(ctx context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, version int64) error
 	Update(ctx context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error)
+	Patch(ctx context.Context, original, patched repository.DesignPattern) (repository.DesignPattern, error)
+	ListDeleted(ctx context.Context, skip, limit int64) ([]repository.DesignPattern, int64, error)
+	Restore(ctx context.Context, id string) (repository.DesignPattern, error)
+	Purge(ctx context.Context, id string) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	UpdateStatus(ctx context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error)
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	GetBySlug(ctx context.Context, slug string) (repository.DesignPattern, error)
+	TakenSlugs(ctx context.Context, base string) ([]string, error)
+	UpdateSlug(ctx context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error)
+	SetTranslation(ctx context.Context, id string, version int64, locale string, translation repository.Translation) (repository.DesignPattern, error)
+	DeleteTranslation(ctx context.Context, id string, version int64, locale string) (repository.DesignPattern, error)
+	TagCounts(ctx context.Context, filter repository.ListFilter) ([]repository.TagCount, error)
 }
 
 // Service handles the business logic and use cases for DesignPattern.
 type Service struct {
-	db DesignPatternRepository
+	db           DesignPatternRepository
+	revisions    RevisionRepository
+	logger       *slog.Logger
+	sourceLocale string
+	locales      []string
+	rendered     *cache.LRU[renderKey, rendering]
+	cache        *readCache
 }
 
-// NewService creates a new DesignPattern service.
-func NewService(db DesignPatternRepository) *Service {
-	return &Service{db: db}
+// NewService creates a new DesignPattern service that records a Revision of every content
+// change in revisions.
+func NewService(db DesignPatternRepository, revisions RevisionRepository, logger *slog.Logger, opts ...Option) *Service {
+	s := &Service{
+		db:           db,
+		revisions:    revisions,
+		logger:       logger,
+		sourceLocale: DefaultLocale,
+		locales:      DefaultLocales,
+		rendered:     cache.NewLRU[renderKey, rendering](DefaultRenderCacheSize, 0),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-// GetByID returns a DesignPattern by its ID.
-func (s *Service) GetByID(ctx context.Context, id string) (DesignPattern, error) {
-	// Validaciones o cache
+// GetByID returns a DesignPattern by its ID in the locale opts prefer, with its markdown
+// rendered when opts ask for HTML. Unless opts include drafts, DesignPatterns that are not
+// published are not found.
+func (s *Service) GetByID(ctx context.Context, id string, opts ReadOptions) (DesignPattern, error) {
+	designPattern, err := s.cache.designPattern(ctx, id, s.db.GetByID)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
 
-	designPattern, err := s.db.GetByID(ctx, id)
+	converted := s.localize(designPattern, opts.Locales)
+	if !opts.IncludeDrafts && converted.Status != StatusPublished {
+		return DesignPattern{}, ErrDesignPatternNotFound
+	}
+
+	if opts.RenderHTML {
+		converted = s.render(converted)
+	}
+
+	return converted, nil
+}
+
+// List returns a page of DesignPatterns in the locale params prefer, only the published ones
+// unless params include drafts. Page and limit are normalized to valid values.
+func (s *Service) List(ctx context.Context, params ListParams) (ListResult, error) {
+	opts, err := listParamsToRepositoryOptions(&params)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return DesignPattern{}, ErrDesignPatternNotFound
+		return ListResult{}, err
+	}
+
+	designPatterns, total, err := s.cache.list(ctx, opts, s.db.List)
+	if err != nil {
+		return ListResult{}, s.repositoryError(ctx, "listing design patterns", err)
+	}
+
+	items := make([]DesignPattern, 0, len(designPatterns))
+	for _, designPattern := range designPatterns {
+		converted := s.localize(designPattern, params.Locales)
+		if params.RenderHTML {
+			converted = s.render(converted)
 		}
+		items = append(items, converted)
+	}
 
-		fmt.Println(err)
-		return DesignPattern{}, ErrSomethingWentWrong
+	return ListResult{
+		Items: items,
+		Page:  params.Page,
+		Limit: params.Limit,
+		Total: total,
+	}, nil
+}
+
+// Search returns a page of published DesignPatterns matching a full-text query, most relevant
+// first, in the locale params prefer, with highlighted snippets of the fields where the query
+// matched.
+func (s *Service) Search(ctx context.Context, params SearchParams) (SearchResult, error) {
+	query := strings.TrimSpace(params.Query)
+	if query == "" || len([]rune(query)) > MaxSearchQueryLength {
+		return SearchResult{}, ErrInvalidSearchQuery
+	}
+
+	page, limit := paging.Normalize(params.Page, params.Limit)
+
+	results, total, err := s.db.Search(ctx, query, int64((page-1)*limit), int64(limit))
+	if err != nil {
+		return SearchResult{}, s.repositoryError(ctx, "searching design patterns", err, "query", query)
 	}
 
-	return repositoryModelToServiceModel(designPattern), nil
+	terms := searchTerms(query)
+	items := make([]SearchHit, 0, len(results))
+	for _, result := range results {
+		designPattern := s.localize(result.DesignPattern, params.Locales)
+		items = append(items, SearchHit{
+			DesignPattern: designPattern,
+			Score:         result.Score,
+			Highlights:    highlights(designPattern, terms),
+		})
+	}
+
+	return SearchResult{
+		Items: items,
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}, nil
 }
 
-// Create creates a new DesignPattern.
+// Create creates a new draft DesignPattern with a unique slug generated from its title and
+// records its first Revision with the Change carried by ctx. It returns a *ValidationError
+// when the DesignPattern is invalid.
 func (s *Service) Create(ctx context.Context, designPattern DesignPattern) (DesignPattern, error) {
-	// Validaciones o cache
+	if err := validate(designPattern); err != nil {
+		return DesignPattern{}, err
+	}
 
 	convertedDesignPattern, err := serviceModelToRepositoryModelForCreation(designPattern)
 	if err != nil {
-		fmt.Println(err)
+		s.logger.ErrorContext(ctx, "converting design pattern", "error", err)
 		return DesignPattern{}, ErrSomethingWentWrong
 	}
-	designPatternCreated, err := s.db.Create(ctx, convertedDesignPattern)
+
+	var designPatternCreated repository.DesignPattern
+	for attempt := 1; ; attempt++ {
+		convertedDesignPattern.Slug, err = s.uniqueSlug(ctx, designPattern.Title)
+		if err != nil {
+			return DesignPattern{}, s.repositoryError(ctx, "generating design pattern slug", err)
+		}
+
+		designPatternCreated, err = s.db.Create(ctx, convertedDesignPattern)
+		// Another DesignPattern may take the same slug in the meantime.
+		if err == nil || !repository.IsDuplicateKey(err) || attempt == maxSlugAttempts {
+			break
+		}
+	}
 	if err != nil {
-		fmt.Println(err)
-		return DesignPattern{}, ErrSomethingWentWrong
+		return DesignPattern{}, s.repositoryError(ctx, "creating design pattern", err)
 	}
+	s.cache.invalidate(designPatternCreated.MongoID.Hex())
+	s.recordRevision(ctx, designPatternCreated)
 
 	return repositoryModelToServiceModel(designPatternCreated), nil
 }
 
-// Delete deletes a DesignPattern by its ID.
-func (s *Service) Delete(ctx context.Context, id string) error {
-	// Validaciones o cache
-
-	err := s.db.Delete(ctx, id)
+// Delete moves a DesignPattern to the trash by its ID, from where it can be restored until
+// it is purged. When version is not zero, it returns ErrVersionMismatch unless the
+// DesignPattern is still at that version.
+func (s *Service) Delete(ctx context.Context, id string, version int64) error {
+	err := s.db.Delete(ctx, id, version)
 	if err != nil {
-		fmt.Println(err)
-		return ErrSomethingWentWrong
+		return s.repositoryError(ctx, "deleting design pattern", err, "id", id)
 	}
+	s.cache.invalidate(id)
 
 	return nil
 }
 
-// Update updates a DesignPattern.
+// Update updates a DesignPattern and records a Revision with the Change carried by ctx. It
+// returns a *ValidationError when the DesignPattern is invalid and ErrVersionMismatch when its
+// Version is not zero and no longer the stored one.
 func (s *Service) Update(ctx context.Context, designPattern DesignPattern) (DesignPattern, error) {
-	// Validaciones o cache
+	if err := validate(designPattern); err != nil {
+		return DesignPattern{}, err
+	}
 
 	convertedDesignPattern, err := serviceModelToRepositoryModel(designPattern)
 	if err != nil {
-		fmt.Println(err)
-		return DesignPattern{}, ErrSomethingWentWrong
+		return DesignPattern{}, err
 	}
 
 	designPatternUpdated, err := s.db.Update(ctx, convertedDesignPattern)
 	if err != nil {
-		fmt.Println(err)
-		return DesignPattern{}, ErrSomethingWentWrong
+		return DesignPattern{}, s.repositoryError(ctx, "updating design pattern", err, "id", designPattern.ID)
 	}
+	s.cache.invalidate(designPattern.ID)
+	s.recordRevision(ctx, designPatternUpdated)
 
 	return repositoryModelToServiceModel(designPatternUpdated), nil
 }
 
+// Patch applies a patch to the DesignPattern with the given ID, stores only the fields it
+// changed and records a Revision with the Change carried by ctx when something changed. It
+// returns a *ValidationError when the patched DesignPattern is invalid and
+// ErrVersionMismatch when the DesignPattern changed since the version of the patch, or
+// while the patch was being applied.
+func (s *Service) Patch(ctx context.Context, id string, patch Patch) (DesignPattern, error) {
+	original, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
+
+	if patch.Version != 0 && patch.Version != original.Version {
+		return DesignPattern{}, ErrVersionMismatch
+	}
+
+	patched, err := applyPatch(repositoryModelToServiceModel(original), patch)
+	if err != nil {
+		return DesignPattern{}, err
+	}
+
+	var readOnly []FieldError
+	if patched.ID != original.MongoID.Hex() {
+		readOnly = append(readOnly, FieldError{Field: "id", Message: "is read-only"})
+	}
+	if patched.Version != original.Version {
+		readOnly = append(readOnly, FieldError{Field: "version", Message: "is read-only"})
+	}
+	if patched.Slug != original.Slug {
+		readOnly = append(readOnly, FieldError{Field: "slug", Message: "is read-only"})
+	}
+	if !reflect.DeepEqual(patched.SlugAliases, original.SlugAliases) {
+		readOnly = append(readOnly, FieldError{Field: "slugAliases", Message: "is read-only"})
+	}
+	if patched.Locale != "" {
+		readOnly = append(readOnly, FieldError{Field: "locale", Message: "is read-only"})
+	}
+	if patched.DeletedAt != nil {
+		readOnly = append(readOnly, FieldError{Field: "deletedAt", Message: "is read-only"})
+	}
+	current := repositoryModelToServiceModel(original)
+	if patched.Status != current.Status {
+		readOnly = append(readOnly, FieldError{Field: "status", Message: "is read-only"})
+	}
+	if !reflect.DeepEqual(patched.PublishAt, current.PublishAt) {
+		readOnly = append(readOnly, FieldError{Field: "publishAt", Message: "is read-only"})
+	}
+	if !reflect.DeepEqual(patched.PublishedAt, current.PublishedAt) {
+		readOnly = append(readOnly, FieldError{Field: "publishedAt", Message: "is read-only"})
+	}
+	if len(readOnly) > 0 {
+		return DesignPattern{}, &ValidationError{Fields: readOnly}
+	}
+	if err := validate(patched); err != nil {
+		return DesignPattern{}, err
+	}
+
+	convertedDesignPattern, err := serviceModelToRepositoryModel(patched)
+	if err != nil {
+		return DesignPattern{}, err
+	}
+
+	designPatternPatched, err := s.db.Patch(ctx, original, convertedDesignPattern)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "patching design pattern", err, "id", id)
+	}
+	if designPatternPatched.Version != original.Version {
+		s.cache.invalidate(id)
+		s.recordRevision(ctx, designPatternPatched)
+	}
+
+	return repositoryModelToServiceModel(designPatternPatched), nil
+}
+
 // Hacemos la converson de los modelos de la capa de repositorio a los modelos de la capa de servicio
 // y viceversa porque no queremos que la capa de servicio tenga que depender de la capa de repositorio
 // ni devolver modelos de la capa de repositorio al usuario.
@@ -109,9 +314,18 @@ func (s *Service) Update(ctx context.Context, designPattern DesignPattern) (Desi
 func repositoryModelToServiceModel(designPattern repository.DesignPattern) DesignPattern {
 	return DesignPattern{
 		ID:          designPattern.MongoID.Hex(),
+		Slug:        designPattern.Slug,
+		SlugAliases: designPattern.SlugAliases,
 		Title:       designPattern.Title,
 		Subtitle:    designPattern.Subtitle,
 		ContentData: designPattern.ContentData,
+		Category:    Category(designPattern.Category),
+		Tags:        designPattern.Tags,
+		Version:     designPattern.Version,
+		DeletedAt:   designPattern.DeletedAt,
+		Status:      statusOf(designPattern),
+		PublishAt:   designPattern.PublishAt,
+		PublishedAt: designPattern.PublishedAt,
 	}
 }
 
@@ -119,20 +333,63 @@ func serviceModelToRepositoryModelForCreation(designPattern DesignPattern) (repo
 	return repository.DesignPattern{
 		Title:       designPattern.Title,
 		Subtitle:    designPattern.Subtitle,
-		ContentData: designPattern.ContentData,
+		ContentData: storedBlocks(designPattern.ContentData),
+		Category:    string(designPattern.Category),
+		Tags:        normalizeTags(designPattern.Tags),
+		Status:      string(StatusDraft),
 	}, nil
 }
 
 func serviceModelToRepositoryModel(designPattern DesignPattern) (repository.DesignPattern, error) {
 	primitiveID, err := primitive.ObjectIDFromHex(designPattern.ID)
 	if err != nil {
-		return repository.DesignPattern{}, err
+		return repository.DesignPattern{}, ErrInvalidID
 	}
 
 	return repository.DesignPattern{
 		MongoID:     primitiveID,
 		Title:       designPattern.Title,
 		Subtitle:    designPattern.Subtitle,
-		ContentData: designPattern.ContentData,
+		ContentData: storedBlocks(designPattern.ContentData),
+		Category:    string(designPattern.Category),
+		Tags:        normalizeTags(designPattern.Tags),
+		Version:     designPattern.Version,
 	}, nil
 }
+
+// listParamsToRepositoryOptions normalizes the page, limit and tags in params and converts
+// them to repository options.
+func listParamsToRepositoryOptions(params *ListParams) (repository.ListOptions, error) {
+	params.Page, params.Limit = paging.Normalize(params.Page, params.Limit)
+
+	if params.Category != "" && !categories[params.Category] {
+		return repository.ListOptions{}, ErrInvalidCategory
+	}
+	params.AnyTags = normalizeTags(params.AnyTags)
+	params.AllTags = normalizeTags(params.AllTags)
+
+	opts := repository.ListOptions{
+		Skip:  int64((params.Page - 1) * params.Limit),
+		Limit: int64(params.Limit),
+		Filter: repository.ListFilter{
+			Title:         params.Title,
+			Subtitle:      params.Subtitle,
+			CreatedAfter:  params.CreatedAfter,
+			CreatedBefore: params.CreatedBefore,
+			PublishedOnly: !params.IncludeDrafts,
+			Category:      string(params.Category),
+			AnyTags:       params.AnyTags,
+			AllTags:       params.AllTags,
+		},
+	}
+
+	if params.Sort != "" {
+		var ok bool
+		opts.SortBy, opts.Descending, ok = paging.Sort(params.Sort)
+		if !ok {
+			return repository.ListOptions{}, ErrInvalidSort
+		}
+	}
+
+	return opts, nil
+}
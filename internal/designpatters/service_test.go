@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
 	"github.com/waydevs/sections-api/internal/platform/repository"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type designPatternRepositoryMock struct{}
@@ -18,47 +21,333 @@ func (d designPatternRepositoryMock) GetByID(_ context.Context, id string) (repo
 			Title: "ok",
 		}, nil
 
+	case "draft":
+		return repository.DesignPattern{
+			Title:  "draft",
+			Status: string(StatusDraft),
+		}, nil
+
+	case "in-review":
+		return repository.DesignPattern{
+			Title:  "in-review",
+			Status: string(StatusInReview),
+		}, nil
+
+	case "update-error":
+		return repository.DesignPattern{
+			Title: "error",
+		}, nil
+
+	case "translated":
+		return translatedDesignPattern(), nil
+
+	case "slugged":
+		return repository.DesignPattern{
+			Slug:        "singleton",
+			SlugAliases: []string{"old-singleton"},
+			Title:       "Singleton",
+		}, nil
+
 	case "not-found":
 		return repository.DesignPattern{}, repository.ErrNotFound
 
+	case "invalid-id":
+		return repository.DesignPattern{}, repository.ErrInvalidID
+
+	case "unavailable":
+		return repository.DesignPattern{}, context.DeadlineExceeded
+
 	default:
 		return repository.DesignPattern{}, errors.New("some-error")
 	}
 }
 
+func (d designPatternRepositoryMock) List(_ context.Context, opts repository.ListOptions) ([]repository.DesignPattern, int64, error) {
+	switch opts.Filter.Title {
+	case "error":
+		return nil, 0, errors.New("some-error")
+
+	default:
+		return []repository.DesignPattern{
+			{Title: "ok"},
+		}, 1, nil
+	}
+}
+
+func (d designPatternRepositoryMock) Search(_ context.Context, query string, _, _ int64) ([]repository.SearchResult, int64, error) {
+	switch query {
+	case "error":
+		return nil, 0, errors.New("some-error")
+
+	default:
+		return []repository.SearchResult{
+			{
+				DesignPattern: repository.DesignPattern{
+					Title: "Singleton",
+					ContentData: []repository.Content{
+						{Type: repository.BlockMarkdown, Title: "Uso", Text: "Garantiza una única instancia"},
+					},
+				},
+				Score: 2.5,
+			},
+		}, 1, nil
+	}
+}
+
 func (d designPatternRepositoryMock) Create(_ context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error) {
 	switch designPattern.Title {
-	case "ok":
+	case "ok", "Singletón":
 		return designPattern, nil
 
+	case "duplicate":
+		return repository.DesignPattern{}, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+
 	default:
 		return repository.DesignPattern{}, errors.New("some-error")
 	}
 }
 
-func (d designPatternRepositoryMock) Delete(_ context.Context, id string) error {
+func (d designPatternRepositoryMock) Delete(_ context.Context, id string, version int64) error {
+	if version == staleVersion {
+		return repository.ErrVersionConflict
+	}
+
 	switch id {
 	case "ok":
 		return nil
 
+	case "not-found":
+		return repository.ErrNotFound
+
 	default:
 		return errors.New("some-error")
 	}
 }
 
 func (d designPatternRepositoryMock) Update(_ context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error) {
+	if designPattern.Version == staleVersion {
+		return repository.DesignPattern{}, repository.ErrVersionConflict
+	}
+
 	switch designPattern.Title {
 	case "ok":
+		designPattern.Version++
 		return designPattern, nil
 
+	case "not-found":
+		return repository.DesignPattern{}, repository.ErrNotFound
+
 	default:
 		return repository.DesignPattern{}, errors.New("some-error")
 	}
 }
 
+func (d designPatternRepositoryMock) Patch(_ context.Context, _, patched repository.DesignPattern) (repository.DesignPattern, error) {
+	switch patched.Title {
+	case "error":
+		return repository.DesignPattern{}, errors.New("some-error")
+
+	default:
+		patched.Version++
+		return patched, nil
+	}
+}
+
+func (d designPatternRepositoryMock) ListDeleted(_ context.Context, skip, _ int64) ([]repository.DesignPattern, int64, error) {
+	if skip > 0 {
+		return nil, 0, errors.New("some-error")
+	}
+
+	return []repository.DesignPattern{
+		{Title: "ok", DeletedAt: &deletedAt},
+	}, 1, nil
+}
+
+func (d designPatternRepositoryMock) Restore(_ context.Context, id string) (repository.DesignPattern, error) {
+	switch id {
+	case "ok":
+		return repository.DesignPattern{Title: "ok", Version: 2}, nil
+
+	case "not-found":
+		return repository.DesignPattern{}, repository.ErrNotFound
+
+	case "invalid-id":
+		return repository.DesignPattern{}, repository.ErrInvalidID
+
+	default:
+		return repository.DesignPattern{}, errors.New("some-error")
+	}
+}
+
+func (d designPatternRepositoryMock) Purge(_ context.Context, id string) error {
+	switch id {
+	case "ok":
+		return nil
+
+	case "not-found":
+		return repository.ErrNotFound
+
+	default:
+		return errors.New("some-error")
+	}
+}
+
+func (d designPatternRepositoryMock) PurgeDeletedBefore(_ context.Context, cutoff time.Time) (int64, error) {
+	if cutoff.After(time.Now()) {
+		return 0, errors.New("some-error")
+	}
+
+	return 3, nil
+}
+
+func (d designPatternRepositoryMock) UpdateStatus(_ context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error) {
+	if designPattern.Title == "error" {
+		return repository.DesignPattern{}, errors.New("some-error")
+	}
+
+	designPattern.Version++
+	return designPattern, nil
+}
+
+func (d designPatternRepositoryMock) PublishDue(_ context.Context, now time.Time) (int64, error) {
+	if now.IsZero() {
+		return 0, errors.New("some-error")
+	}
+
+	return 2, nil
+}
+
+func (d designPatternRepositoryMock) GetBySlug(_ context.Context, slug string) (repository.DesignPattern, error) {
+	switch slug {
+	case "singleton", "old-singleton":
+		return repository.DesignPattern{
+			Slug:        "singleton",
+			SlugAliases: []string{"old-singleton"},
+			Title:       "Singleton",
+		}, nil
+
+	case "draft":
+		return repository.DesignPattern{
+			Slug:   "draft",
+			Title:  "draft",
+			Status: string(StatusDraft),
+		}, nil
+
+	case "not-found":
+		return repository.DesignPattern{}, repository.ErrNotFound
+
+	default:
+		return repository.DesignPattern{}, errors.New("some-error")
+	}
+}
+
+func (d designPatternRepositoryMock) TakenSlugs(_ context.Context, base string) ([]string, error) {
+	switch base {
+	case "singleton":
+		return []string{"singleton", "singleton-2"}, nil
+
+	case "singleton-2":
+		return []string{"singleton-2"}, nil
+
+	case "unavailable":
+		return nil, context.DeadlineExceeded
+
+	default:
+		return []string{}, nil
+	}
+}
+
+func (d designPatternRepositoryMock) UpdateSlug(_ context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error) {
+	if designPattern.Title == "error" {
+		return repository.DesignPattern{}, errors.New("some-error")
+	}
+
+	designPattern.Version++
+	return designPattern, nil
+}
+
+func (d designPatternRepositoryMock) SetTranslation(_ context.Context, id string, version int64, locale string, translation repository.Translation) (repository.DesignPattern, error) {
+	if id == "update-error" {
+		return repository.DesignPattern{}, errors.New("some-error")
+	}
+
+	designPattern := translatedDesignPattern()
+	designPattern.Translations[locale] = translation
+	designPattern.Version = version + 1
+	return designPattern, nil
+}
+
+func (d designPatternRepositoryMock) DeleteTranslation(_ context.Context, id string, version int64, locale string) (repository.DesignPattern, error) {
+	if version == staleVersion {
+		return repository.DesignPattern{}, repository.ErrVersionConflict
+	}
+
+	designPattern := translatedDesignPattern()
+	delete(designPattern.Translations, locale)
+	designPattern.Version++
+	return designPattern, nil
+}
+
+func (d designPatternRepositoryMock) TagCounts(_ context.Context, filter repository.ListFilter) ([]repository.TagCount, error) {
+	if filter.Category == string(CategoryStructural) {
+		return nil, errors.New("some-error")
+	}
+
+	counts := []repository.TagCount{{Tag: "concurrency", Count: 2}}
+	if !filter.PublishedOnly {
+		counts = append(counts, repository.TagCount{Tag: "work in progress", Count: 1})
+	}
+	return counts, nil
+}
+
+// translatedAt is when the translations of the repository mock were made.
+var translatedAt = time.Date(2023, 2, 3, 4, 5, 6, 0, time.UTC)
+
+// translatedDesignPattern is a DesignPattern written in Spanish, with a current English
+// translation of its first blocks and an outdated Portuguese one.
+func translatedDesignPattern() repository.DesignPattern {
+	designPattern := repository.DesignPattern{
+		Slug:     "singleton",
+		Title:    "Singleton",
+		Subtitle: "Una única instancia",
+		ContentData: []repository.Content{
+			{Type: repository.BlockMarkdown, Title: "Uso", Text: "Garantiza una única instancia"},
+			{Type: repository.BlockImage, URL: "https://waydevs.com/uso.png", Alt: "Diagrama de clases"},
+			{Type: repository.BlockMarkdown, Title: "Ejemplo", Text: "Una conexión a la base de datos"},
+		},
+		Version: 2,
+	}
+	designPattern.Translations = map[string]repository.Translation{
+		"en": {
+			Title:    "Singleton",
+			Subtitle: "A single instance",
+			ContentData: []repository.Content{
+				{Type: repository.BlockMarkdown, Title: "Use", Text: "Ensures a single instance"},
+				{Type: repository.BlockImage, URL: "https://waydevs.com/uso.png", Alt: "Class diagram"},
+			},
+			SourceHash: sourceHash(designPattern),
+			UpdatedAt:  translatedAt,
+		},
+		"pt": {
+			Title:      "Singleton",
+			Subtitle:   "Uma única instância",
+			SourceHash: "stale",
+			UpdatedAt:  translatedAt,
+		},
+	}
+
+	return designPattern
+}
+
+// deletedAt is when the DesignPatterns in the trash of the repository mock were deleted.
+var deletedAt = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// staleVersion is a version the repository mock always reports as conflicting.
+const staleVersion = 7
+
 func TestNewService(t *testing.T) {
 	db := designPatternRepositoryMock{}
-	service := NewService(db)
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
 
 	require.NotNil(t, service)
 }
@@ -67,6 +356,7 @@ func TestService_GetByID(t *testing.T) {
 	tt := []struct {
 		name             string
 		id               string
+		opts             ReadOptions
 		expectedResponse DesignPattern
 		expectedError    error
 	}{
@@ -75,17 +365,49 @@ func TestService_GetByID(t *testing.T) {
 			id:   "ok",
 			expectedResponse: DesignPattern{
 				// Is an empty ObjectID
-				ID:    "000000000000000000000000",
-				Title: "ok",
+				ID:     "000000000000000000000000",
+				Status: StatusPublished,
+				Title:  "ok",
+				Locale: DefaultLocale,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok draft",
+			id:   "draft",
+			opts: ReadOptions{IncludeDrafts: true},
+			expectedResponse: DesignPattern{
+				ID:     "000000000000000000000000",
+				Status: StatusDraft,
+				Title:  "draft",
+				Locale: DefaultLocale,
 			},
 			expectedError: nil,
 		},
+		{
+			name:             "error draft not public",
+			id:               "draft",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
 		{
 			name:             "error not found",
 			id:               "not-found",
 			expectedResponse: DesignPattern{},
 			expectedError:    ErrDesignPatternNotFound,
 		},
+		{
+			name:             "error invalid id",
+			id:               "invalid-id",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrInvalidID,
+		},
+		{
+			name:             "error unavailable",
+			id:               "unavailable",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrUnavailable,
+		},
 		{
 			name:             "error",
 			id:               "error",
@@ -97,9 +419,169 @@ func TestService_GetByID(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			db := designPatternRepositoryMock{}
-			service := NewService(db)
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.GetByID(context.Background(), tc.id, tc.opts)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	tt := []struct {
+		name             string
+		params           ListParams
+		expectedResponse ListResult
+		expectedError    error
+	}{
+		{
+			name:   "ok with defaults",
+			params: ListParams{},
+			expectedResponse: ListResult{
+				Items: []DesignPattern{
+					{ID: "000000000000000000000000", Title: "ok", Status: StatusPublished, Locale: DefaultLocale},
+				},
+				Page:  1,
+				Limit: DefaultListLimit,
+				Total: 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "ok with limit above max",
+			params: ListParams{Page: 3, Limit: 1000, Sort: "-title"},
+			expectedResponse: ListResult{
+				Items: []DesignPattern{
+					{ID: "000000000000000000000000", Title: "ok", Status: StatusPublished, Locale: DefaultLocale},
+				},
+				Page:  3,
+				Limit: MaxListLimit,
+				Total: 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error invalid sort",
+			params:           ListParams{Sort: "subtitle"},
+			expectedResponse: ListResult{},
+			expectedError:    ErrInvalidSort,
+		},
+		{
+			name:             "error invalid category",
+			params:           ListParams{Category: "functional"},
+			expectedResponse: ListResult{},
+			expectedError:    ErrInvalidCategory,
+		},
+		{
+			name:             "error",
+			params:           ListParams{Title: "error"},
+			expectedResponse: ListResult{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
 
-			response, err := service.GetByID(context.Background(), tc.id)
+			response, err := service.List(context.Background(), tc.params)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestListParamsToRepositoryOptions(t *testing.T) {
+	params := ListParams{Page: 3, Limit: 10, Sort: "-createdAt", Title: "factory"}
+
+	opts, err := listParamsToRepositoryOptions(&params)
+
+	require.NoError(t, err)
+	require.Equal(t, repository.ListOptions{
+		Skip:       20,
+		Limit:      10,
+		SortBy:     repository.SortByCreation,
+		Descending: true,
+		Filter:     repository.ListFilter{Title: "factory", PublishedOnly: true},
+	}, opts)
+}
+
+func TestListParamsToRepositoryOptions_Tags(t *testing.T) {
+	params := ListParams{
+		Category: CategoryBehavioral,
+		AnyTags:  []string{"Concurrency", " go idioms "},
+		AllTags:  []string{"GoF", "gof"},
+	}
+
+	opts, err := listParamsToRepositoryOptions(&params)
+
+	require.NoError(t, err)
+	require.Equal(t, repository.ListFilter{
+		PublishedOnly: true,
+		Category:      "behavioral",
+		AnyTags:       []string{"concurrency", "go idioms"},
+		AllTags:       []string{"gof"},
+	}, opts.Filter)
+}
+
+func TestService_Search(t *testing.T) {
+	tt := []struct {
+		name             string
+		params           SearchParams
+		expectedResponse SearchResult
+		expectedError    error
+	}{
+		{
+			name:   "ok",
+			params: SearchParams{Query: "unica instancia"},
+			expectedResponse: SearchResult{
+				Items: []SearchHit{
+					{
+						DesignPattern: DesignPattern{
+							ID:     "000000000000000000000000",
+							Status: StatusPublished,
+							Title:  "Singleton",
+							Locale: DefaultLocale,
+							ContentData: []repository.Content{
+								{Type: repository.BlockMarkdown, Title: "Uso", Text: "Garantiza una única instancia"},
+							},
+						},
+						Score: 2.5,
+						Highlights: []Highlight{
+							{Field: "contentData[0].text", Snippet: "Garantiza una <mark>única</mark> <mark>instancia</mark>"},
+						},
+					},
+				},
+				Page:  1,
+				Limit: DefaultListLimit,
+				Total: 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error empty query",
+			params:           SearchParams{Query: "   "},
+			expectedResponse: SearchResult{},
+			expectedError:    ErrInvalidSearchQuery,
+		},
+		{
+			name:             "error",
+			params:           SearchParams{Query: "error"},
+			expectedResponse: SearchResult{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Search(context.Background(), tc.params)
 
 			require.Equal(t, tc.expectedResponse, response)
 			require.Equal(t, tc.expectedError, err)
@@ -122,15 +604,65 @@ func TestService_Create(t *testing.T) {
 			},
 			expectedResponse: DesignPattern{
 				// Is an empty ObjectID
-				ID:    "000000000000000000000000",
-				Title: "ok",
+				ID:     "000000000000000000000000",
+				Slug:   "ok",
+				Status: StatusDraft,
+				Title:  "ok",
 			},
 			expectedError: nil,
 		},
 		{
-			name:             "error",
+			name: "ok with category and tags",
+			designPattern: DesignPattern{
+				Title:    "ok",
+				Category: CategoryCreational,
+				Tags:     []string{" Go  Idioms", "concurrency", "go idioms"},
+			},
+			expectedResponse: DesignPattern{
+				ID:       "000000000000000000000000",
+				Slug:     "ok",
+				Status:   StatusDraft,
+				Title:    "ok",
+				Category: CategoryCreational,
+				Tags:     []string{"go idioms", "concurrency"},
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "ok slug taken",
+			designPattern: DesignPattern{Title: "Singletón"},
+			expectedResponse: DesignPattern{
+				ID:     "000000000000000000000000",
+				Slug:   "singleton-3",
+				Status: StatusDraft,
+				Title:  "Singletón",
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error generating slug",
+			designPattern:    DesignPattern{Title: "Unavailable"},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrUnavailable,
+		},
+		{
+			name:             "error invalid",
 			designPattern:    DesignPattern{},
 			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "title", Message: "is required"},
+			}},
+		},
+		{
+			name:             "error conflict",
+			designPattern:    DesignPattern{Title: "duplicate"},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrConflict,
+		},
+		{
+			name:             "error",
+			designPattern:    DesignPattern{Title: "error"},
+			expectedResponse: DesignPattern{},
 			expectedError:    ErrSomethingWentWrong,
 		},
 	}
@@ -138,7 +670,7 @@ func TestService_Create(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			db := designPatternRepositoryMock{}
-			service := NewService(db)
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
 
 			response, err := service.Create(context.Background(), tc.designPattern)
 
@@ -152,6 +684,7 @@ func TestService_Delete(t *testing.T) {
 	tt := []struct {
 		name          string
 		id            string
+		version       int64
 		expectedError error
 	}{
 		{
@@ -159,6 +692,17 @@ func TestService_Delete(t *testing.T) {
 			id:            "ok",
 			expectedError: nil,
 		},
+		{
+			name:          "error not found",
+			id:            "not-found",
+			expectedError: ErrDesignPatternNotFound,
+		},
+		{
+			name:          "error version mismatch",
+			id:            "ok",
+			version:       staleVersion,
+			expectedError: ErrVersionMismatch,
+		},
 		{
 			name:          "error",
 			id:            "error",
@@ -169,9 +713,9 @@ func TestService_Delete(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			db := designPatternRepositoryMock{}
-			service := NewService(db)
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
 
-			err := service.Delete(context.Background(), tc.id)
+			err := service.Delete(context.Background(), tc.id, tc.version)
 
 			require.Equal(t, tc.expectedError, err)
 		})
@@ -192,15 +736,47 @@ func TestService_Update(t *testing.T) {
 				Title: "ok",
 			},
 			expectedResponse: DesignPattern{
-				ID:    "638d568a507b6e07cd39de82",
-				Title: "ok",
+				ID:      "638d568a507b6e07cd39de82",
+				Title:   "ok",
+				Version: 1,
+				Status:  StatusPublished,
 			},
 			expectedError: nil,
 		},
 		{
-			name:             "error",
+			name: "error version mismatch",
+			designPattern: DesignPattern{
+				ID:      "638d568a507b6e07cd39de82",
+				Title:   "ok",
+				Version: staleVersion,
+			},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrVersionMismatch,
+		},
+		{
+			name:             "error invalid",
 			designPattern:    DesignPattern{},
 			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "title", Message: "is required"},
+			}},
+		},
+		{
+			name:             "error invalid id",
+			designPattern:    DesignPattern{Title: "error"},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrInvalidID,
+		},
+		{
+			name:             "error not found",
+			designPattern:    DesignPattern{ID: "638d568a507b6e07cd39de82", Title: "not-found"},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error",
+			designPattern:    DesignPattern{ID: "638d568a507b6e07cd39de82", Title: "error"},
+			expectedResponse: DesignPattern{},
 			expectedError:    ErrSomethingWentWrong,
 		},
 	}
@@ -208,7 +784,7 @@ func TestService_Update(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			db := designPatternRepositoryMock{}
-			service := NewService(db)
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
 
 			response, err := service.Update(context.Background(), tc.designPattern)
 
@@ -217,3 +793,106 @@ func TestService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Patch(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		patch            Patch
+		expectedResponse DesignPattern
+		expectedError    error
+	}{
+		{
+			name:  "ok",
+			id:    "ok",
+			patch: Patch{Type: MergePatch, Document: []byte(`{"subtitle":"patched"}`)},
+			expectedResponse: DesignPattern{
+				ID:       "000000000000000000000000",
+				Status:   StatusPublished,
+				Title:    "ok",
+				Subtitle: "patched",
+				Version:  1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error version mismatch",
+			id:               "ok",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"subtitle":"patched"}`), Version: 3},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrVersionMismatch,
+		},
+		{
+			name:             "error read-only version",
+			id:               "ok",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"version":3}`)},
+			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "version", Message: "is read-only"},
+			}},
+		},
+		{
+			name:             "error not found",
+			id:               "not-found",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"subtitle":"patched"}`)},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error invalid",
+			id:               "ok",
+			patch:            Patch{Type: JSONPatch, Document: []byte(`[{"op":"remove","path":"/title"}]`)},
+			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "title", Message: "is required"},
+			}},
+		},
+		{
+			name:             "error read-only id",
+			id:               "ok",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"id":"638d568a507b6e07cd39de82"}`)},
+			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "id", Message: "is read-only"},
+			}},
+		},
+		{
+			name:             "error read-only slug",
+			id:               "ok",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"slug":"patched","slugAliases":["ok"]}`)},
+			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "slug", Message: "is read-only"},
+				{Field: "slugAliases", Message: "is read-only"},
+			}},
+		},
+		{
+			name:             "error read-only deletedAt",
+			id:               "ok",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"deletedAt":"2023-01-02T03:04:05Z"}`)},
+			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{
+				{Field: "deletedAt", Message: "is read-only"},
+			}},
+		},
+		{
+			name:             "error",
+			id:               "ok",
+			patch:            Patch{Type: MergePatch, Document: []byte(`{"title":"error"}`)},
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Patch(context.Background(), tc.id, tc.patch)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
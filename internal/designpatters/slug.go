@@ -0,0 +1,194 @@
+package designpatters
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSlugLength is the longest slug allowed, in characters.
+	MaxSlugLength = 80
+
+	// fallbackSlug is the slug of DesignPatterns whose title has no letters or digits.
+	fallbackSlug = "design-pattern"
+
+	// maxSlugAttempts is how many slugs Create tries when other DesignPatterns take them
+	// while it is creating one.
+	maxSlugAttempts = 3
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// transliterations spells the accented letters of Spanish titles in ASCII.
+var transliterations = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"Á", "a", "É", "e", "Í", "i", "Ó", "o", "Ú", "u", "Ü", "u", "Ñ", "n",
+)
+
+// GetBySlug returns a DesignPattern by its current slug or one of its previous slugs, which
+// callers can tell apart by comparing slug with the Slug of the result, in the locale opts
+// prefer and with its markdown rendered when opts ask for HTML. Unless opts include drafts,
+// DesignPatterns that are not published are not found.
+func (s *Service) GetBySlug(ctx context.Context, slug string, opts ReadOptions) (DesignPattern, error) {
+	designPattern, err := s.db.GetBySlug(ctx, slug)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "getting design pattern by slug", err, "slug", slug)
+	}
+
+	converted := s.localize(designPattern, opts.Locales)
+	if !opts.IncludeDrafts && converted.Status != StatusPublished {
+		return DesignPattern{}, ErrDesignPatternNotFound
+	}
+
+	if opts.RenderHTML {
+		converted = s.render(converted)
+	}
+
+	return converted, nil
+}
+
+// RenameSlug changes the slug of the DesignPattern with the given ID, keeping the previous
+// one as an alias so links to it keep working. It returns a *ValidationError when slug is not
+// a valid slug, ErrSlugTaken when another DesignPattern uses it and ErrVersionMismatch when
+// version is not zero and the DesignPattern changed since then.
+func (s *Service) RenameSlug(ctx context.Context, id, slug string, version int64) (DesignPattern, error) {
+	if utf8.RuneCountInString(slug) > MaxSlugLength || !slugPattern.MatchString(slug) {
+		return DesignPattern{}, &ValidationError{Fields: []FieldError{{
+			Field:   "slug",
+			Message: "must be lowercase letters and digits separated by single dashes, up to 80 characters",
+		}}}
+	}
+
+	current, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
+
+	if version != 0 && version != current.Version {
+		return DesignPattern{}, ErrVersionMismatch
+	}
+
+	if slug == current.Slug {
+		return repositoryModelToServiceModel(current), nil
+	}
+
+	// A DesignPattern can take back one of its own previous slugs.
+	aliases := make([]string, 0, len(current.SlugAliases)+1)
+	ownAlias := false
+	for _, alias := range current.SlugAliases {
+		if alias == slug {
+			ownAlias = true
+			continue
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if !ownAlias {
+		taken, err := s.db.TakenSlugs(ctx, slug)
+		if err != nil {
+			return DesignPattern{}, s.repositoryError(ctx, "checking design pattern slug", err, "slug", slug)
+		}
+		for _, takenSlug := range taken {
+			if takenSlug == slug {
+				return DesignPattern{}, ErrSlugTaken
+			}
+		}
+	}
+
+	if current.Slug != "" {
+		aliases = append(aliases, current.Slug)
+	}
+	current.Slug = slug
+	current.SlugAliases = aliases
+
+	updated, err := s.db.UpdateSlug(ctx, current)
+	if err != nil {
+		return DesignPattern{}, s.repositoryError(ctx, "renaming design pattern slug", err, "id", id)
+	}
+	s.cache.invalidate(id)
+
+	return repositoryModelToServiceModel(updated), nil
+}
+
+// uniqueSlug returns the slug of title, followed by the lowest number from 2 that makes it
+// unique when it is taken. Long slugs are shortened to fit the number, so the numbered slugs
+// of the shortened stem are checked as well.
+func (s *Service) uniqueSlug(ctx context.Context, title string) (string, error) {
+	base := slugify(title)
+
+	used := map[string]bool{}
+	checked := map[string]bool{}
+	check := func(stem string) error {
+		if checked[stem] {
+			return nil
+		}
+		checked[stem] = true
+
+		taken, err := s.db.TakenSlugs(ctx, stem)
+		if err != nil {
+			return err
+		}
+		for _, slug := range taken {
+			used[slug] = true
+		}
+
+		return nil
+	}
+
+	if err := check(base); err != nil {
+		return "", err
+	}
+
+	slug := base
+	for n := 2; used[slug]; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		stem := truncateSlug(base, MaxSlugLength-len(suffix))
+		if err := check(stem); err != nil {
+			return "", err
+		}
+		slug = stem + suffix
+	}
+
+	return slug, nil
+}
+
+// slugify turns title into lowercase ASCII letters and digits separated by single dashes.
+func slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(transliterations.Replace(title)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+
+	if b.Len() == 0 {
+		return fallbackSlug
+	}
+
+	return truncateSlug(b.String(), MaxSlugLength)
+}
+
+// truncateSlug shortens slug to at most length characters, cutting at a dash when there is
+// one, so words are not split.
+func truncateSlug(slug string, length int) string {
+	if len(slug) <= length {
+		return slug
+	}
+
+	slug = slug[:length]
+	if i := strings.LastIndexByte(slug, '-'); i > 0 {
+		slug = slug[:i]
+	}
+
+	return strings.TrimSuffix(slug, "-")
+}
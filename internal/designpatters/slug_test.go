@@ -0,0 +1,275 @@
+package designpatters
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestSlugify(t *testing.T) {
+	tt := []struct {
+		name     string
+		title    string
+		expected string
+	}{
+		{name: "ok", title: "Singleton", expected: "singleton"},
+		{name: "ok spaces and punctuation", title: "  Abstract Factory: the basics!  ", expected: "abstract-factory-the-basics"},
+		{name: "ok spanish accents", title: "Método Fábrica y Compañía", expected: "metodo-fabrica-y-compania"},
+		{name: "ok digits", title: "Top 10 patterns", expected: "top-10-patterns"},
+		{name: "ok no letters", title: "¿?", expected: fallbackSlug},
+		{name: "ok long", title: strings.Repeat("observer ", 20), expected: strings.TrimSuffix(strings.Repeat("observer-", 8), "-")},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, slugify(tc.title))
+		})
+	}
+}
+
+func TestService_GetBySlug(t *testing.T) {
+	singleton := DesignPattern{
+		ID:          "000000000000000000000000",
+		Slug:        "singleton",
+		SlugAliases: []string{"old-singleton"},
+		Status:      StatusPublished,
+		Title:       "Singleton",
+		Locale:      DefaultLocale,
+	}
+
+	tt := []struct {
+		name             string
+		slug             string
+		opts             ReadOptions
+		expectedResponse DesignPattern
+		expectedError    error
+	}{
+		{
+			name:             "ok",
+			slug:             "singleton",
+			expectedResponse: singleton,
+			expectedError:    nil,
+		},
+		{
+			name:             "ok alias",
+			slug:             "old-singleton",
+			expectedResponse: singleton,
+			expectedError:    nil,
+		},
+		{
+			name: "ok draft",
+			slug: "draft",
+			opts: ReadOptions{IncludeDrafts: true},
+			expectedResponse: DesignPattern{
+				ID:     "000000000000000000000000",
+				Slug:   "draft",
+				Status: StatusDraft,
+				Title:  "draft",
+				Locale: DefaultLocale,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error draft not public",
+			slug:             "draft",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error not found",
+			slug:             "not-found",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error",
+			slug:             "error",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.GetBySlug(context.Background(), tc.slug, tc.opts)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_RenameSlug(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		slug             string
+		version          int64
+		expectedResponse DesignPattern
+		expectedError    error
+	}{
+		{
+			name: "ok",
+			id:   "slugged",
+			slug: "singleton-pattern",
+			expectedResponse: DesignPattern{
+				ID:          "000000000000000000000000",
+				Slug:        "singleton-pattern",
+				SlugAliases: []string{"old-singleton", "singleton"},
+				Status:      StatusPublished,
+				Title:       "Singleton",
+				Version:     1,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok previous slug",
+			id:   "slugged",
+			slug: "old-singleton",
+			expectedResponse: DesignPattern{
+				ID:          "000000000000000000000000",
+				Slug:        "old-singleton",
+				SlugAliases: []string{"singleton"},
+				Status:      StatusPublished,
+				Title:       "Singleton",
+				Version:     1,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok same slug",
+			id:   "slugged",
+			slug: "singleton",
+			expectedResponse: DesignPattern{
+				ID:          "000000000000000000000000",
+				Slug:        "singleton",
+				SlugAliases: []string{"old-singleton"},
+				Status:      StatusPublished,
+				Title:       "Singleton",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "ok without slug",
+			id:   "ok",
+			slug: "ok-pattern",
+			expectedResponse: DesignPattern{
+				ID:          "000000000000000000000000",
+				Slug:        "ok-pattern",
+				SlugAliases: []string{},
+				Status:      StatusPublished,
+				Title:       "ok",
+				Version:     1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error slug taken",
+			id:               "ok",
+			slug:             "singleton-2",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrSlugTaken,
+		},
+		{
+			name:             "error invalid slug",
+			id:               "ok",
+			slug:             "Not A Slug",
+			expectedResponse: DesignPattern{},
+			expectedError: &ValidationError{Fields: []FieldError{{
+				Field:   "slug",
+				Message: "must be lowercase letters and digits separated by single dashes, up to 80 characters",
+			}}},
+		},
+		{
+			name:             "error version mismatch",
+			id:               "ok",
+			slug:             "ok-pattern",
+			version:          3,
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrVersionMismatch,
+		},
+		{
+			name:             "error not found",
+			id:               "not-found",
+			slug:             "ok-pattern",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error updating",
+			id:               "update-error",
+			slug:             "ok-pattern",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.RenameSlug(context.Background(), tc.id, tc.slug, tc.version)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+// slugStoreMock keeps the slugs of the DesignPatterns it creates, rejecting taken ones like
+// the unique index of the repository does.
+type slugStoreMock struct {
+	designPatternRepositoryMock
+	slugs *[]string
+}
+
+func (s slugStoreMock) Create(_ context.Context, designPattern repository.DesignPattern) (repository.DesignPattern, error) {
+	for _, slug := range *s.slugs {
+		if slug == designPattern.Slug {
+			return repository.DesignPattern{}, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+		}
+	}
+	*s.slugs = append(*s.slugs, designPattern.Slug)
+
+	return designPattern, nil
+}
+
+func (s slugStoreMock) TakenSlugs(_ context.Context, base string) ([]string, error) {
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(base) + "(-[0-9]+)?$")
+
+	taken := []string{}
+	for _, slug := range *s.slugs {
+		if pattern.MatchString(slug) {
+			taken = append(taken, slug)
+		}
+	}
+
+	return taken, nil
+}
+
+func TestService_Create_LongSlugs(t *testing.T) {
+	db := slugStoreMock{slugs: &[]string{}}
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+	// The slug of the title is 80 characters long, so numbered ones are shortened.
+	title := strings.Repeat("observer ", 9)
+	stem := strings.TrimSuffix(strings.Repeat("observer-", 8), "-")
+
+	slugs := []string{}
+	for i := 0; i < 4; i++ {
+		created, err := service.Create(context.Background(), DesignPattern{Title: title, Subtitle: "ok"})
+		require.NoError(t, err)
+		slugs = append(slugs, created.Slug)
+	}
+
+	require.Equal(t, []string{slugify(title), stem + "-2", stem + "-3", stem + "-4"}, slugs)
+}
@@ -0,0 +1,83 @@
+package designpatters
+
+import (
+	"context"
+	"strings"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// Category is the Gang of Four family of a DesignPattern.
+type Category string
+
+const (
+	CategoryCreational Category = "creational"
+	CategoryStructural Category = "structural"
+	CategoryBehavioral Category = "behavioral"
+)
+
+var categories = map[Category]bool{
+	CategoryCreational: true,
+	CategoryStructural: true,
+	CategoryBehavioral: true,
+}
+
+// TagsParams narrow down the DesignPatterns whose tags are counted.
+type TagsParams struct {
+	// Category counts only the tags of DesignPatterns in it. Empty counts them all.
+	Category Category
+	// IncludeDrafts counts the tags of DesignPatterns in any Status, not only published ones.
+	IncludeDrafts bool
+}
+
+// TagCount is a tag along with the number of DesignPatterns tagged with it.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// Tags returns the tags in use along with how many DesignPatterns are tagged with each, most
+// used first. It returns ErrInvalidCategory for unknown categories.
+func (s *Service) Tags(ctx context.Context, params TagsParams) ([]TagCount, error) {
+	if params.Category != "" && !categories[params.Category] {
+		return nil, ErrInvalidCategory
+	}
+
+	counts, err := s.db.TagCounts(ctx, repository.ListFilter{
+		Category:      string(params.Category),
+		PublishedOnly: !params.IncludeDrafts,
+	})
+	if err != nil {
+		return nil, s.repositoryError(ctx, "counting design pattern tags", err)
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for _, count := range counts {
+		tags = append(tags, TagCount{Tag: count.Tag, Count: count.Count})
+	}
+
+	return tags, nil
+}
+
+// normalizeTag lowercases tag and collapses its whitespace, so "Go  Idioms" and "go idioms"
+// are the same tag.
+func normalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
+
+// normalizeTags normalizes every tag and drops the empty and repeated ones, keeping the order
+// of the rest. It returns nil when no tag is left.
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
@@ -0,0 +1,77 @@
+package designpatters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+func TestService_Tags(t *testing.T) {
+	tt := []struct {
+		name             string
+		params           TagsParams
+		expectedResponse []TagCount
+		expectedError    error
+	}{
+		{
+			name:             "ok published",
+			params:           TagsParams{Category: CategoryBehavioral},
+			expectedResponse: []TagCount{{Tag: "concurrency", Count: 2}},
+			expectedError:    nil,
+		},
+		{
+			name:   "ok with drafts",
+			params: TagsParams{IncludeDrafts: true},
+			expectedResponse: []TagCount{
+				{Tag: "concurrency", Count: 2},
+				{Tag: "work in progress", Count: 1},
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error invalid category",
+			params:           TagsParams{Category: "functional"},
+			expectedResponse: nil,
+			expectedError:    ErrInvalidCategory,
+		},
+		{
+			name:             "error",
+			params:           TagsParams{Category: CategoryStructural},
+			expectedResponse: nil,
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Tags(context.Background(), tc.params)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tt := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{name: "none", tags: nil, expected: nil},
+		{name: "only empty", tags: []string{" ", ""}, expected: nil},
+		{name: "lowercase and whitespace", tags: []string{"Go  Idioms", "\tConcurrency "}, expected: []string{"go idioms", "concurrency"}},
+		{name: "repeated", tags: []string{"gof", "GoF", "creational", "gof"}, expected: []string{"gof", "creational"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, normalizeTags(tc.tags))
+		})
+	}
+}
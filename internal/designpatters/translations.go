@@ -0,0 +1,261 @@
+package designpatters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// DefaultLocale is the locale DesignPatterns are written in, unless WithLocales sets another.
+const DefaultLocale = "es"
+
+// DefaultLocales are the locales DesignPatterns are available in, unless WithLocales sets
+// others.
+var DefaultLocales = []string{"es", "en"}
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithLocales sets the source locale DesignPatterns are written in and the locales they are
+// available in, which include the source one.
+func WithLocales(source string, locales []string) Option {
+	return func(s *Service) {
+		s.sourceLocale = source
+		s.locales = locales
+	}
+}
+
+// GetTranslation returns the translation of the DesignPattern with the given ID to locale. It
+// returns ErrDesignPatternNotFound for DesignPatterns that are not published, unless opts
+// include drafts.
+func (s *Service) GetTranslation(ctx context.Context, id, locale string, opts ReadOptions) (Translation, error) {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	designPattern, err := s.readable(ctx, id, opts)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	translation, ok := designPattern.Translations[locale]
+	if !ok {
+		return Translation{}, ErrTranslationNotFound
+	}
+
+	return repositoryTranslationToServiceTranslation(designPattern, locale, translation), nil
+}
+
+// PutTranslation creates or replaces the translation of the DesignPattern with the given ID
+// to translation.Locale, made from its current content in the source locale. It returns a
+// *ValidationError when the translation is invalid and ErrVersionMismatch when version is not
+// zero and the DesignPattern changed since then.
+func (s *Service) PutTranslation(ctx context.Context, id string, translation Translation, version int64) (Translation, error) {
+	locale, err := s.translationLocale(translation.Locale)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	if err := validate(DesignPattern{Title: translation.Title, Subtitle: translation.Subtitle, ContentData: translation.ContentData}); err != nil {
+		return Translation{}, err
+	}
+
+	current, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return Translation{}, s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
+
+	if version != 0 && version != current.Version {
+		return Translation{}, ErrVersionMismatch
+	}
+
+	stored := repository.Translation{
+		Title:       translation.Title,
+		Subtitle:    translation.Subtitle,
+		ContentData: storedBlocks(translation.ContentData),
+		SourceHash:  sourceHash(current),
+		UpdatedAt:   time.Now().UTC(),
+	}
+
+	// The source hash is only right for the version it was computed from.
+	updated, err := s.db.SetTranslation(ctx, id, current.Version, locale, stored)
+	if err != nil {
+		return Translation{}, s.repositoryError(ctx, "translating design pattern", err, "id", id, "locale", locale)
+	}
+	s.cache.invalidate(id)
+
+	return repositoryTranslationToServiceTranslation(updated, locale, stored), nil
+}
+
+// DeleteTranslation removes the translation of the DesignPattern with the given ID to
+// locale. When version is not zero, it returns ErrVersionMismatch unless the DesignPattern is
+// still at that version.
+func (s *Service) DeleteTranslation(ctx context.Context, id, locale string, version int64) error {
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return s.repositoryError(ctx, "getting design pattern", err, "id", id)
+	}
+
+	if _, ok := current.Translations[locale]; !ok {
+		return ErrTranslationNotFound
+	}
+
+	if _, err := s.db.DeleteTranslation(ctx, id, version, locale); err != nil {
+		return s.repositoryError(ctx, "deleting design pattern translation", err, "id", id, "locale", locale)
+	}
+	s.cache.invalidate(id)
+
+	return nil
+}
+
+// TranslationReport returns the TranslationStatus of the DesignPattern with the given ID in
+// every locale other than the source one. Like GetTranslation, it hides DesignPatterns that
+// are not published unless opts include drafts.
+func (s *Service) TranslationReport(ctx context.Context, id string, opts ReadOptions) (TranslationReport, error) {
+	designPattern, err := s.readable(ctx, id, opts)
+	if err != nil {
+		return TranslationReport{}, err
+	}
+
+	report := TranslationReport{SourceLocale: s.sourceLocale, Locales: []LocaleStatus{}}
+	for _, locale := range s.translatableLocales() {
+		status := LocaleStatus{Locale: locale, Status: TranslationMissing}
+		if translation, ok := designPattern.Translations[locale]; ok {
+			updatedAt := translation.UpdatedAt
+			status.Status = translationStatus(designPattern, translation)
+			status.UpdatedAt = &updatedAt
+		}
+		report.Locales = append(report.Locales, status)
+	}
+
+	return report, nil
+}
+
+// localize converts designPattern to the service model, in the first of locales it is
+// translated to. Regional locales such as en-us fall back to their language, and the content
+// is in the source locale when none of locales is available.
+func (s *Service) localize(designPattern repository.DesignPattern, locales []string) DesignPattern {
+	converted := repositoryModelToServiceModel(designPattern)
+	converted.Locale = s.sourceLocale
+
+	for _, requested := range locales {
+		for _, locale := range localeFallbacks(requested) {
+			if locale == s.sourceLocale {
+				return converted
+			}
+
+			translation, ok := designPattern.Translations[locale]
+			if !ok {
+				continue
+			}
+
+			converted.Locale = locale
+			converted.Title = translation.Title
+			converted.Subtitle = translation.Subtitle
+			converted.ContentData = translatedBlocks(designPattern.ContentData, translation.ContentData)
+			return converted
+		}
+	}
+
+	return converted
+}
+
+// translationLocale normalizes locale and checks a DesignPattern can be translated to it.
+func (s *Service) translationLocale(locale string) (string, error) {
+	locale = strings.ToLower(locale)
+	for _, translatable := range s.translatableLocales() {
+		if locale == translatable {
+			return locale, nil
+		}
+	}
+
+	return "", &Error{
+		Code:    CodeInvalidArgument,
+		Message: fmt.Sprintf("Unsupported locale, use one of %s", strings.Join(s.translatableLocales(), ", ")),
+	}
+}
+
+// translatableLocales returns the locales other than the source one.
+func (s *Service) translatableLocales() []string {
+	locales := make([]string, 0, len(s.locales))
+	for _, locale := range s.locales {
+		if locale != s.sourceLocale {
+			locales = append(locales, locale)
+		}
+	}
+
+	return locales
+}
+
+// localeFallbacks returns locale in lowercase followed by its language when it is regional.
+func localeFallbacks(locale string) []string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexByte(locale, '-'); i > 0 {
+		return []string{locale, locale[:i]}
+	}
+
+	return []string{locale}
+}
+
+// translatedBlocks returns the source blocks replaced by their translation. Blocks that are
+// not translated yet, or whose translation is of another type, stay in the source locale.
+func translatedBlocks(source, translated []repository.Content) []repository.Content {
+	if len(source) == 0 {
+		return source
+	}
+
+	blocks := make([]repository.Content, len(source))
+	for i, block := range source {
+		if i < len(translated) && translated[i].Type == block.Type {
+			block = translated[i]
+		}
+		blocks[i] = block
+	}
+
+	return blocks
+}
+
+// sourceHash identifies the content of designPattern in the source locale.
+func sourceHash(designPattern repository.DesignPattern) string {
+	// Marshaling strings and slices of them can't fail.
+	content, _ := json.Marshal(struct {
+		Title       string
+		Subtitle    string
+		ContentData []repository.Content
+	}{designPattern.Title, designPattern.Subtitle, designPattern.ContentData})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func translationStatus(designPattern repository.DesignPattern, translation repository.Translation) TranslationStatus {
+	if translation.SourceHash != sourceHash(designPattern) {
+		return TranslationOutdated
+	}
+
+	return TranslationCurrent
+}
+
+func repositoryTranslationToServiceTranslation(designPattern repository.DesignPattern, locale string, translation repository.Translation) Translation {
+	return Translation{
+		Locale:      locale,
+		Title:       translation.Title,
+		Subtitle:    translation.Subtitle,
+		ContentData: translation.ContentData,
+		Status:      translationStatus(designPattern, translation),
+		UpdatedAt:   translation.UpdatedAt,
+		Version:     designPattern.Version,
+	}
+}
@@ -0,0 +1,313 @@
+package designpatters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// testLocales are the locales of the Service in the translation tests, written in Spanish.
+var testLocales = WithLocales("es", []string{"es", "en", "pt", "fr"})
+
+func TestService_GetByID_Locales(t *testing.T) {
+	source := repositoryModelToServiceModel(translatedDesignPattern())
+	source.Locale = "es"
+
+	english := source
+	english.Locale = "en"
+	english.Subtitle = "A single instance"
+	english.ContentData = []repository.Content{
+		{Type: repository.BlockMarkdown, Title: "Use", Text: "Ensures a single instance"},
+		{Type: repository.BlockImage, URL: "https://waydevs.com/uso.png", Alt: "Class diagram"},
+		{Type: repository.BlockMarkdown, Title: "Ejemplo", Text: "Una conexión a la base de datos"},
+	}
+
+	tt := []struct {
+		name             string
+		locales          []string
+		expectedResponse DesignPattern
+	}{
+		{name: "ok source", locales: nil, expectedResponse: source},
+		{name: "ok translated", locales: []string{"en"}, expectedResponse: english},
+		{name: "ok regional fallback", locales: []string{"EN-us"}, expectedResponse: english},
+		{name: "ok first available", locales: []string{"de", "en", "es"}, expectedResponse: english},
+		{name: "ok source preferred", locales: []string{"es-AR", "en"}, expectedResponse: source},
+		{name: "ok none available", locales: []string{"fr", "de"}, expectedResponse: source},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard(), testLocales)
+
+			response, err := service.GetByID(context.Background(), "translated", ReadOptions{Locales: tc.locales})
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func TestService_GetTranslation(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		locale           string
+		opts             ReadOptions
+		expectedResponse Translation
+		expectedError    error
+	}{
+		{
+			name:   "ok",
+			id:     "translated",
+			locale: "EN",
+			expectedResponse: Translation{
+				Locale:   "en",
+				Title:    "Singleton",
+				Subtitle: "A single instance",
+				ContentData: []repository.Content{
+					{Type: repository.BlockMarkdown, Title: "Use", Text: "Ensures a single instance"},
+					{Type: repository.BlockImage, URL: "https://waydevs.com/uso.png", Alt: "Class diagram"},
+				},
+				Status:    TranslationCurrent,
+				UpdatedAt: translatedAt,
+				Version:   2,
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "ok outdated",
+			id:     "translated",
+			locale: "pt",
+			expectedResponse: Translation{
+				Locale:    "pt",
+				Title:     "Singleton",
+				Subtitle:  "Uma única instância",
+				Status:    TranslationOutdated,
+				UpdatedAt: translatedAt,
+				Version:   2,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error missing",
+			id:               "translated",
+			locale:           "fr",
+			expectedResponse: Translation{},
+			expectedError:    ErrTranslationNotFound,
+		},
+		{
+			name:             "error source locale",
+			id:               "translated",
+			locale:           "es",
+			expectedResponse: Translation{},
+			expectedError:    &Error{Code: CodeInvalidArgument, Message: "Unsupported locale, use one of en, pt, fr"},
+		},
+		{
+			name:             "error not found",
+			id:               "not-found",
+			locale:           "en",
+			expectedResponse: Translation{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error draft",
+			id:               "draft",
+			locale:           "en",
+			expectedResponse: Translation{},
+			expectedError:    ErrDesignPatternNotFound,
+		},
+		{
+			name:             "error draft with drafts",
+			id:               "draft",
+			locale:           "en",
+			opts:             ReadOptions{IncludeDrafts: true},
+			expectedResponse: Translation{},
+			expectedError:    ErrTranslationNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard(), testLocales)
+
+			response, err := service.GetTranslation(context.Background(), tc.id, tc.locale, tc.opts)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_PutTranslation(t *testing.T) {
+	french := Translation{
+		Locale:      "fr",
+		Title:       "Singleton",
+		Subtitle:    "Une seule instance",
+		ContentData: []repository.Content{{Type: repository.BlockMarkdown, Title: "Usage", Text: "Garantit une seule instance"}},
+	}
+
+	tt := []struct {
+		name          string
+		id            string
+		translation   Translation
+		version       int64
+		expectedError error
+	}{
+		{
+			name:          "ok",
+			id:            "translated",
+			translation:   french,
+			version:       2,
+			expectedError: nil,
+		},
+		{
+			name:          "error invalid",
+			id:            "translated",
+			translation:   Translation{Locale: "fr"},
+			expectedError: &ValidationError{Fields: []FieldError{{Field: "title", Message: "is required"}}},
+		},
+		{
+			name:          "error unsupported locale",
+			id:            "translated",
+			translation:   Translation{Locale: "de", Title: "Singleton"},
+			expectedError: &Error{Code: CodeInvalidArgument, Message: "Unsupported locale, use one of en, pt, fr"},
+		},
+		{
+			name:          "error version mismatch",
+			id:            "translated",
+			translation:   french,
+			version:       3,
+			expectedError: ErrVersionMismatch,
+		},
+		{
+			name:          "error not found",
+			id:            "not-found",
+			translation:   french,
+			expectedError: ErrDesignPatternNotFound,
+		},
+		{
+			name:          "error updating",
+			id:            "update-error",
+			translation:   french,
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard(), testLocales)
+
+			response, err := service.PutTranslation(context.Background(), tc.id, tc.translation, tc.version)
+
+			require.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+			require.WithinDuration(t, time.Now(), response.UpdatedAt, time.Minute)
+			response.UpdatedAt = time.Time{}
+			expected := tc.translation
+			expected.Status = TranslationCurrent
+			expected.Version = 3
+			require.Equal(t, expected, response)
+		})
+	}
+}
+
+func TestService_DeleteTranslation(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		locale        string
+		version       int64
+		expectedError error
+	}{
+		{
+			name:          "ok",
+			id:            "translated",
+			locale:        "en",
+			expectedError: nil,
+		},
+		{
+			name:          "error missing",
+			id:            "translated",
+			locale:        "fr",
+			expectedError: ErrTranslationNotFound,
+		},
+		{
+			name:          "error version mismatch",
+			id:            "translated",
+			locale:        "en",
+			version:       staleVersion,
+			expectedError: ErrVersionMismatch,
+		},
+		{
+			name:          "error unsupported locale",
+			id:            "translated",
+			locale:        "de",
+			expectedError: &Error{Code: CodeInvalidArgument, Message: "Unsupported locale, use one of en, pt, fr"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard(), testLocales)
+
+			err := service.DeleteTranslation(context.Background(), tc.id, tc.locale, tc.version)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_TranslationReport(t *testing.T) {
+	db := designPatternRepositoryMock{}
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard(), testLocales)
+
+	report, err := service.TranslationReport(context.Background(), "translated", ReadOptions{})
+
+	require.NoError(t, err)
+	require.Equal(t, TranslationReport{
+		SourceLocale: "es",
+		Locales: []LocaleStatus{
+			{Locale: "en", Status: TranslationCurrent, UpdatedAt: &translatedAt},
+			{Locale: "pt", Status: TranslationOutdated, UpdatedAt: &translatedAt},
+			{Locale: "fr", Status: TranslationMissing},
+		},
+	}, report)
+
+	_, err = service.TranslationReport(context.Background(), "not-found", ReadOptions{})
+	require.Equal(t, ErrDesignPatternNotFound, err)
+
+	// Unpublished DesignPatterns are only reported with drafts.
+	_, err = service.TranslationReport(context.Background(), "draft", ReadOptions{})
+	require.Equal(t, ErrDesignPatternNotFound, err)
+	_, err = service.TranslationReport(context.Background(), "draft", ReadOptions{IncludeDrafts: true})
+	require.NoError(t, err)
+}
+
+func TestTranslatedBlocks(t *testing.T) {
+	source := []repository.Content{
+		{Type: repository.BlockMarkdown, Title: "Uso", Text: "Una instancia"},
+		{Type: repository.BlockCode, Language: "go", Code: "var once sync.Once"},
+		{Type: repository.BlockCallout, Severity: "tip", Text: "Usalo con cuidado"},
+	}
+
+	blocks := translatedBlocks(source, []repository.Content{
+		{Type: repository.BlockMarkdown, Title: "Use", Text: "A single instance"},
+		{Type: repository.BlockMarkdown, Text: "var once sync.Once"},
+	})
+
+	require.Equal(t, []repository.Content{
+		{Type: repository.BlockMarkdown, Title: "Use", Text: "A single instance"},
+		source[1],
+		source[2],
+	}, blocks)
+}
@@ -0,0 +1,83 @@
+package designpatters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/paging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+// ListTrash returns a page of the DesignPatterns in the trash, most recently deleted first.
+func (s *Service) ListTrash(ctx context.Context, params TrashParams) (ListResult, error) {
+	page, limit := paging.Normalize(params.Page, params.Limit)
+
+	designPatterns, total, err := s.db.ListDeleted(ctx, int64((page-1)*limit), int64(limit))
+	if err != nil {
+		return ListResult{}, s.repositoryError(ctx, "listing deleted design patterns", err)
+	}
+
+	items := make([]DesignPattern, 0, len(designPatterns))
+	for _, designPattern := range designPatterns {
+		items = append(items, repositoryModelToServiceModel(designPattern))
+	}
+
+	return ListResult{
+		Items: items,
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}, nil
+}
+
+// Restore takes a DesignPattern out of the trash by its ID. It returns ErrNotInTrash when
+// the DesignPattern is not in the trash.
+func (s *Service) Restore(ctx context.Context, id string) (DesignPattern, error) {
+	designPattern, err := s.db.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return DesignPattern{}, ErrNotInTrash
+		}
+		return DesignPattern{}, s.repositoryError(ctx, "restoring design pattern", err, "id", id)
+	}
+	s.cache.invalidate(id)
+
+	return repositoryModelToServiceModel(designPattern), nil
+}
+
+// Purge permanently removes a DesignPattern in the trash by its ID. It returns ErrNotInTrash
+// when the DesignPattern is not in the trash, so it has to be deleted first.
+func (s *Service) Purge(ctx context.Context, id string) error {
+	err := s.db.Purge(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrNotInTrash
+		}
+		return s.repositoryError(ctx, "purging design pattern", err, "id", id)
+	}
+
+	s.logger.InfoContext(ctx, "design pattern purged", "id", id)
+	return nil
+}
+
+// PurgeExpired permanently removes the DesignPatterns that have been in the trash for longer
+// than retention and returns how many were removed.
+func (s *Service) PurgeExpired(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.db.PurgeDeletedBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, s.repositoryError(ctx, "purging expired design patterns", err)
+	}
+
+	return purged, nil
+}
+
+// RunPurge calls PurgeExpired right away and then every interval, until ctx is done.
+func (s *Service) RunPurge(ctx context.Context, retention, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		// Errors are logged by PurgeExpired and the next run retries.
+		if purged, err := s.PurgeExpired(ctx, retention); err == nil && purged > 0 {
+			s.logger.InfoContext(ctx, "expired design patterns purged", "count", purged, "retention", retention.String())
+		}
+	})
+}
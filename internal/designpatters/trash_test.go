@@ -0,0 +1,217 @@
+package designpatters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+func TestService_ListTrash(t *testing.T) {
+	tt := []struct {
+		name             string
+		params           TrashParams
+		expectedResponse ListResult
+		expectedError    error
+	}{
+		{
+			name:   "ok with defaults",
+			params: TrashParams{},
+			expectedResponse: ListResult{
+				Items: []DesignPattern{
+					{ID: "000000000000000000000000", Title: "ok", Status: StatusPublished, DeletedAt: &deletedAt},
+				},
+				Page:  1,
+				Limit: DefaultListLimit,
+				Total: 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error",
+			params:           TrashParams{Page: 2},
+			expectedResponse: ListResult{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.ListTrash(context.Background(), tc.params)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Restore(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		expectedResponse DesignPattern
+		expectedError    error
+	}{
+		{
+			name: "ok",
+			id:   "ok",
+			expectedResponse: DesignPattern{
+				ID:      "000000000000000000000000",
+				Status:  StatusPublished,
+				Title:   "ok",
+				Version: 2,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error not in trash",
+			id:               "not-found",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrNotInTrash,
+		},
+		{
+			name:             "error invalid id",
+			id:               "invalid-id",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrInvalidID,
+		},
+		{
+			name:             "error",
+			id:               "error",
+			expectedResponse: DesignPattern{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Restore(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		expectedError error
+	}{
+		{
+			name:          "ok",
+			id:            "ok",
+			expectedError: nil,
+		},
+		{
+			name:          "error not in trash",
+			id:            "not-found",
+			expectedError: ErrNotInTrash,
+		},
+		{
+			name:          "error",
+			id:            "error",
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			err := service.Purge(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_PurgeExpired(t *testing.T) {
+	tt := []struct {
+		name          string
+		retention     time.Duration
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "ok",
+			retention:     24 * time.Hour,
+			expectedCount: 3,
+			expectedError: nil,
+		},
+		{
+			name:          "error",
+			retention:     -time.Hour,
+			expectedCount: 0,
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := designPatternRepositoryMock{}
+			service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+			count, err := service.PurgeExpired(context.Background(), tc.retention)
+
+			require.Equal(t, tc.expectedCount, count)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+// purgeRecorderMock records the cutoff of every purge.
+type purgeRecorderMock struct {
+	designPatternRepositoryMock
+	cutoffs chan time.Time
+}
+
+func (p purgeRecorderMock) PurgeDeletedBefore(_ context.Context, cutoff time.Time) (int64, error) {
+	p.cutoffs <- cutoff
+	return 1, nil
+}
+
+func TestService_RunPurge(t *testing.T) {
+	db := purgeRecorderMock{cutoffs: make(chan time.Time)}
+	service := NewService(db, &revisionRepositoryMock{}, logging.Discard())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.RunPurge(ctx, time.Hour, time.Millisecond)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case cutoff := <-db.cutoffs:
+			require.WithinDuration(t, time.Now().Add(-time.Hour), cutoff, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("purge didn't run")
+		}
+	}
+
+	cancel()
+	// A purge may be waiting to record its cutoff when the context is cancelled.
+	go func() {
+		for range db.cutoffs {
+		}
+	}()
+
+	select {
+	case <-done:
+		close(db.cutoffs)
+	case <-time.After(time.Second):
+		t.Fatal("RunPurge didn't stop")
+	}
+}
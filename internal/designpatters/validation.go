@@ -0,0 +1,109 @@
+package designpatters
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxTitleLength        = 120
+	MaxSubtitleLength     = 250
+	MaxContentBlocks      = 50
+	MaxBlockTitleLength   = 120
+	MaxBlockTextLength    = 10000
+	MaxCodeLanguageLength = 30
+	MaxImageTextLength    = 250
+	MaxTags               = 20
+	MaxTagLength          = 40
+)
+
+// FieldError describes why a field is invalid. Field is a path such as contentData[1].title.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a DesignPattern breaks one or more rules.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return ErrInvalidDesignPattern.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidDesignPattern
+}
+
+// validate checks the DesignPattern against every rule and reports all the broken ones.
+func validate(designPattern DesignPattern) error {
+	var fields []FieldError
+	add := func(field, format string, args ...interface{}) {
+		fields = append(fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(designPattern.Title) == "" {
+		add("title", "is required")
+	} else if utf8.RuneCountInString(designPattern.Title) > MaxTitleLength {
+		add("title", "must have at most %d characters", MaxTitleLength)
+	}
+
+	if utf8.RuneCountInString(designPattern.Subtitle) > MaxSubtitleLength {
+		add("subtitle", "must have at most %d characters", MaxSubtitleLength)
+	}
+
+	if designPattern.Category != "" && !categories[designPattern.Category] {
+		add("category", "must be one of creational, structural or behavioral")
+	}
+
+	if len(designPattern.Tags) > MaxTags {
+		add("tags", "must have at most %d tags", MaxTags)
+	}
+
+	for i, tag := range designPattern.Tags {
+		if tag = normalizeTag(tag); tag == "" {
+			add(fmt.Sprintf("tags[%d]", i), "is required")
+		} else if utf8.RuneCountInString(tag) > MaxTagLength {
+			add(fmt.Sprintf("tags[%d]", i), "must have at most %d characters", MaxTagLength)
+		}
+	}
+
+	if len(designPattern.ContentData) > MaxContentBlocks {
+		add("contentData", "must have at most %d blocks", MaxContentBlocks)
+	}
+
+	blockTitles := map[string]int{}
+	for i, content := range designPattern.ContentData {
+		field := fmt.Sprintf("contentData[%d]", i)
+
+		// Block titles are optional, but the ones given must be unique.
+		title := strings.ToLower(strings.TrimSpace(content.Title))
+		if utf8.RuneCountInString(content.Title) > MaxBlockTitleLength {
+			add(field+".title", "must have at most %d characters", MaxBlockTitleLength)
+		} else if first, ok := blockTitles[title]; ok && title != "" {
+			add(field+".title", "duplicates the title of contentData[%d]", first)
+		} else if title != "" {
+			blockTitles[title] = i
+		}
+
+		validateBlock(field, content, add)
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+
+	return nil
+}
+
+func validImageURL(image string) bool {
+	parsed, err := url.Parse(image)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
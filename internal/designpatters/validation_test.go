@@ -0,0 +1,170 @@
+package designpatters
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+func TestValidate(t *testing.T) {
+	tt := []struct {
+		name           string
+		designPattern  DesignPattern
+		expectedFields []FieldError
+	}{
+		{
+			name: "valid",
+			designPattern: DesignPattern{
+				Title: "Singleton",
+				ContentData: []repository.Content{
+					{Type: repository.BlockMarkdown, Title: "Uso", Text: "Garantiza una única instancia"},
+					{Type: repository.BlockCode, Title: "Ejemplo", Language: "go", Code: "var once sync.Once"},
+					{Type: repository.BlockImage, URL: "https://waydevs.com/singleton.png", Alt: "Diagrama", Caption: "Clases"},
+					{Type: repository.BlockImage, MediaID: "5f9f1c5b9b9b9b9b9b9b9b9b", Alt: "Secuencia"},
+					{Type: repository.BlockCallout, Severity: "warning", Text: "Dificulta los tests"},
+					{Type: repository.BlockDiagram, Format: "mermaid", Source: "classDiagram\n  class Singleton"},
+				},
+			},
+			expectedFields: nil,
+		},
+		{
+			name:          "missing title",
+			designPattern: DesignPattern{Title: "  "},
+			expectedFields: []FieldError{
+				{Field: "title", Message: "is required"},
+			},
+		},
+		{
+			name: "too long",
+			designPattern: DesignPattern{
+				Title:    strings.Repeat("a", MaxTitleLength+1),
+				Subtitle: strings.Repeat("a", MaxSubtitleLength+1),
+			},
+			expectedFields: []FieldError{
+				{Field: "title", Message: "must have at most 120 characters"},
+				{Field: "subtitle", Message: "must have at most 250 characters"},
+			},
+		},
+		{
+			name: "too many blocks",
+			designPattern: DesignPattern{
+				Title:       "Singleton",
+				ContentData: markdownBlocks(MaxContentBlocks + 1),
+			},
+			expectedFields: []FieldError{
+				{Field: "contentData", Message: "must have at most 50 blocks"},
+			},
+		},
+		{
+			name: "invalid blocks",
+			designPattern: DesignPattern{
+				Title: "Singleton",
+				ContentData: []repository.Content{
+					{Type: repository.BlockImage, Title: "Uso", URL: "javascript:alert(1)", Alt: strings.Repeat("a", MaxImageTextLength+1)},
+					{Type: repository.BlockMarkdown, Title: "uso", Text: " "},
+					{Title: "Ejemplo"},
+					{Type: "video"},
+				},
+			},
+			expectedFields: []FieldError{
+				{Field: "contentData[0].url", Message: "must be an absolute http or https URL"},
+				{Field: "contentData[0].alt", Message: "must have at most 250 characters"},
+				{Field: "contentData[1].title", Message: "duplicates the title of contentData[0]"},
+				{Field: "contentData[1].text", Message: "is required"},
+				{Field: "contentData[2].type", Message: "is required"},
+				{Field: "contentData[3].type", Message: "must be one of markdown, code, image, callout or diagram"},
+			},
+		},
+		{
+			name: "invalid block fields",
+			designPattern: DesignPattern{
+				Title: "Singleton",
+				ContentData: []repository.Content{
+					{Type: repository.BlockCode, Code: "var once sync.Once", Text: "Ejemplo"},
+					{Type: repository.BlockCallout, Severity: "fatal", Text: strings.Repeat("a", MaxBlockTextLength+1)},
+					{Type: repository.BlockDiagram, Format: "svg"},
+					{Type: repository.BlockMarkdown, Text: "Uso", URL: "https://waydevs.com/a.png"},
+				},
+			},
+			expectedFields: []FieldError{
+				{Field: "contentData[0].text", Message: "is not allowed in code blocks"},
+				{Field: "contentData[0].language", Message: "is required"},
+				{Field: "contentData[1].severity", Message: "must be one of info, tip, warning or danger"},
+				{Field: "contentData[1].text", Message: "must have at most 10000 characters"},
+				{Field: "contentData[2].format", Message: "must be one of mermaid, plantuml or graphviz"},
+				{Field: "contentData[2].source", Message: "is required"},
+				{Field: "contentData[3].url", Message: "is not allowed in markdown blocks"},
+			},
+		},
+		{
+			name: "invalid image blocks",
+			designPattern: DesignPattern{
+				Title: "Singleton",
+				ContentData: []repository.Content{
+					{Type: repository.BlockImage, Alt: "Diagrama"},
+					{Type: repository.BlockImage, MediaID: "singleton.png"},
+					{Type: repository.BlockImage, URL: "https://waydevs.com/a.png", MediaID: "5f9f1c5b9b9b9b9b9b9b9b9b"},
+					{Type: repository.BlockCode, Language: "go", Code: "var once sync.Once", MediaID: "5f9f1c5b9b9b9b9b9b9b9b9b"},
+				},
+			},
+			expectedFields: []FieldError{
+				{Field: "contentData[0].url", Message: "must be an absolute http or https URL"},
+				{Field: "contentData[1].mediaId", Message: "must be the id of an uploaded media"},
+				{Field: "contentData[2].url", Message: "is not allowed along with mediaId"},
+				{Field: "contentData[3].mediaId", Message: "is not allowed in code blocks"},
+			},
+		},
+		{
+			name: "invalid category and tags",
+			designPattern: DesignPattern{
+				Title:    "Singleton",
+				Category: "functional",
+				Tags:     []string{"go idioms", "  ", strings.Repeat("a", MaxTagLength+1)},
+			},
+			expectedFields: []FieldError{
+				{Field: "category", Message: "must be one of creational, structural or behavioral"},
+				{Field: "tags[1]", Message: "is required"},
+				{Field: "tags[2]", Message: "must have at most 40 characters"},
+			},
+		},
+		{
+			name: "too many tags",
+			designPattern: DesignPattern{
+				Title: "Singleton",
+				Tags:  strings.Fields(strings.Repeat("gof ", MaxTags+1)),
+			},
+			expectedFields: []FieldError{
+				{Field: "tags", Message: "must have at most 20 tags"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate(tc.designPattern)
+
+			if tc.expectedFields == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			require.ErrorIs(t, err, ErrInvalidDesignPattern)
+			require.Equal(t, tc.expectedFields, validationErr.Fields)
+		})
+	}
+}
+
+// markdownBlocks returns n valid markdown blocks without titles.
+func markdownBlocks(n int) []repository.Content {
+	blocks := make([]repository.Content, n)
+	for i := range blocks {
+		blocks[i] = repository.Content{Type: repository.BlockMarkdown, Text: "Uso"}
+	}
+
+	return blocks
+}
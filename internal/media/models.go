@@ -0,0 +1,14 @@
+package media
+
+import "time"
+
+// Media is an uploaded file. Hash is the hex SHA-256 of its content, which is also its
+// entity tag, as the content of a Media never changes.
+type Media struct {
+	ID          string    `json:"id"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Hash        string    `json:"hash"`
+	Filename    string    `json:"filename,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,237 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"github.com/waydevs/sections-api/internal/platform/storage"
+)
+
+var (
+	// ErrSomethingWentWrong is returned when something went wrong.
+	ErrSomethingWentWrong = errors.New("Something went wrong")
+
+	// ErrMediaNotFound is returned when a Media is not found.
+	ErrMediaNotFound = errors.New("Media not found")
+
+	// ErrInvalidID is returned when an id is not a valid Media id.
+	ErrInvalidID = errors.New("Invalid media id")
+
+	// ErrEmpty is returned when an uploaded file has no content.
+	ErrEmpty = errors.New("Media is empty")
+
+	// ErrTooLarge is wrapped by the error returned when an uploaded file is larger than the
+	// maximum size.
+	ErrTooLarge = errors.New("Media is too large")
+
+	// ErrUnsupportedType is returned when an uploaded file is not an image of a supported
+	// type.
+	ErrUnsupportedType = errors.New("Unsupported media type, upload a PNG, JPEG, GIF or WebP image")
+
+	// ErrUnreadable is returned when an uploaded file can't be read from the request.
+	ErrUnreadable = errors.New("Media could not be read")
+
+	// ErrUnavailable is returned when the storage can't be reached, so the request may
+	// succeed if retried later.
+	ErrUnavailable = errors.New("Service temporarily unavailable")
+)
+
+// DefaultMaxSize is the largest file accepted, in bytes, unless WithMaxSize sets another.
+const DefaultMaxSize = 5 << 20
+
+// MaxFilenameLength is the longest filename kept. Longer ones are truncated.
+const MaxFilenameLength = 255
+
+// extensions are the supported content types, sniffed from the content instead of trusting
+// the one sent by clients, along with the extension of the files they are stored in. SVG is
+// left out on purpose, as it can carry scripts.
+var extensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// MediaRepository is a repository for the metadata of Media.
+type MediaRepository interface {
+	GetByID(ctx context.Context, id string) (repository.Media, error)
+	GetByHash(ctx context.Context, hash string) (repository.Media, error)
+	Create(ctx context.Context, media repository.Media) (repository.Media, error)
+}
+
+// Storage keeps the content of Media. It is a filesystem by default, but any backend that
+// stores content under a key can be plugged in.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+}
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithMaxSize sets the largest file accepted, in bytes.
+func WithMaxSize(size int64) Option {
+	return func(s *Service) {
+		s.maxSize = size
+	}
+}
+
+// Service handles the uploads of Media and the reads of their content.
+type Service struct {
+	db      MediaRepository
+	storage Storage
+	logger  *slog.Logger
+	maxSize int64
+}
+
+// NewService creates a new Media service that keeps the metadata in db and the content in
+// storage.
+func NewService(db MediaRepository, storage Storage, logger *slog.Logger, opts ...Option) *Service {
+	s := &Service{db: db, storage: storage, logger: logger, maxSize: DefaultMaxSize}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Upload stores content as a new Media and reports whether it was created. Content that was
+// already uploaded is not stored again: the Media it was stored as is returned instead.
+func (s *Service) Upload(ctx context.Context, filename string, content io.Reader) (Media, bool, error) {
+	data, err := io.ReadAll(io.LimitReader(content, s.maxSize+1))
+	if err != nil {
+		return Media{}, false, ErrUnreadable
+	}
+	if int64(len(data)) > s.maxSize {
+		return Media{}, false, fmt.Errorf("%w, the limit is %d bytes", ErrTooLarge, s.maxSize)
+	}
+	if len(data) == 0 {
+		return Media{}, false, ErrEmpty
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := extensions[contentType]
+	if !ok {
+		return Media{}, false, ErrUnsupportedType
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	existing, err := s.db.GetByHash(ctx, hash)
+	if err == nil {
+		return repositoryModelToServiceModel(existing), false, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return Media{}, false, s.repositoryError(ctx, "getting media by hash", err, "hash", hash)
+	}
+
+	// Keys are derived from the content, so a file left behind by a failed upload is
+	// overwritten with the same content when it is retried.
+	key := hash + extension
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		s.logger.ErrorContext(ctx, "storing media", "key", key, "error", err)
+		return Media{}, false, ErrSomethingWentWrong
+	}
+
+	created, err := s.db.Create(ctx, repository.Media{
+		Hash:        hash,
+		Key:         key,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Filename:    cleanFilename(filename),
+		CreatedAt:   time.Now().UTC(),
+	})
+	if repository.IsDuplicateKey(err) {
+		// The same content was uploaded concurrently and stored first.
+		existing, err = s.db.GetByHash(ctx, hash)
+		if err != nil {
+			return Media{}, false, s.repositoryError(ctx, "getting media by hash", err, "hash", hash)
+		}
+		return repositoryModelToServiceModel(existing), false, nil
+	}
+	if err != nil {
+		return Media{}, false, s.repositoryError(ctx, "creating media", err, "hash", hash)
+	}
+
+	return repositoryModelToServiceModel(created), true, nil
+}
+
+// Open returns a Media along with its content, which callers must close.
+func (s *Service) Open(ctx context.Context, id string) (Media, io.ReadSeekCloser, error) {
+	stored, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		return Media{}, nil, s.repositoryError(ctx, "getting media", err, "id", id)
+	}
+
+	content, err := s.storage.Open(ctx, stored.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			s.logger.WarnContext(ctx, "media content is missing", "id", id, "key", stored.Key)
+			return Media{}, nil, ErrMediaNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "opening media", "id", id, "key", stored.Key, "error", err)
+		return Media{}, nil, ErrSomethingWentWrong
+	}
+
+	return repositoryModelToServiceModel(stored), content, nil
+}
+
+// repositoryError converts an error from the repository into one of the errors of the
+// Service, logging the ones callers can't do anything about.
+func (s *Service) repositoryError(ctx context.Context, msg string, err error, args ...any) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrMediaNotFound
+	case errors.Is(err, repository.ErrInvalidID):
+		return ErrInvalidID
+	}
+
+	s.logger.ErrorContext(ctx, msg, append(args, "error", err)...)
+
+	if repository.IsUnavailable(err) {
+		return ErrUnavailable
+	}
+
+	return ErrSomethingWentWrong
+}
+
+// cleanFilename keeps the base name of the filename sent by a client, as some clients send
+// the full path, truncated to MaxFilenameLength bytes.
+func cleanFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	if filename == "." || filename == "/" {
+		return ""
+	}
+
+	for len(filename) > MaxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(filename)
+		filename = filename[:len(filename)-size]
+	}
+
+	return filename
+}
+
+func repositoryModelToServiceModel(media repository.Media) Media {
+	return Media{
+		ID:          media.MongoID.Hex(),
+		ContentType: media.ContentType,
+		Size:        media.Size,
+		Hash:        media.Hash,
+		Filename:    media.Filename,
+		CreatedAt:   media.CreatedAt,
+	}
+}
@@ -0,0 +1,345 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"github.com/waydevs/sections-api/internal/platform/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	someID  = "5f9f1c5b9b9b9b9b9b9b9b9b"
+	pngData = "\x89PNG\r\n\x1a\nsome-image"
+
+	// pngHash is the SHA-256 of pngData.
+	pngHash = "d8cccedb4f920a63b1ee26b95badd059a1953b4f504dcadd869e241ad08c3104"
+)
+
+var createdAt = time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+
+// mediaRepositoryMock stores nothing: the hashes it finds are the ones in stored, and
+// creating any of the hashes in duplicated fails as if it was stored concurrently.
+type mediaRepositoryMock struct {
+	stored     map[string]repository.Media
+	duplicated map[string]bool
+	err        error
+}
+
+func (m *mediaRepositoryMock) GetByID(_ context.Context, id string) (repository.Media, error) {
+	if m.err != nil {
+		return repository.Media{}, m.err
+	}
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return repository.Media{}, repository.ErrInvalidID
+	}
+	for _, media := range m.stored {
+		if media.MongoID.Hex() == id {
+			return media, nil
+		}
+	}
+
+	return repository.Media{}, repository.ErrNotFound
+}
+
+func (m *mediaRepositoryMock) GetByHash(_ context.Context, hash string) (repository.Media, error) {
+	if m.err != nil {
+		return repository.Media{}, m.err
+	}
+	media, ok := m.stored[hash]
+	if !ok {
+		return repository.Media{}, repository.ErrNotFound
+	}
+
+	return media, nil
+}
+
+func (m *mediaRepositoryMock) Create(_ context.Context, media repository.Media) (repository.Media, error) {
+	if m.duplicated[media.Hash] {
+		m.stored = map[string]repository.Media{media.Hash: storedMedia()}
+		return repository.Media{}, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+	}
+
+	media.MongoID, _ = primitive.ObjectIDFromHex(someID)
+	media.CreatedAt = createdAt
+	return media, nil
+}
+
+type storageMock struct {
+	files map[string]string
+	err   error
+}
+
+func (s *storageMock) Put(_ context.Context, key string, content io.Reader) error {
+	if s.err != nil {
+		return s.err
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	s.files[key] = string(data)
+
+	return nil
+}
+
+func (s *storageMock) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	data, ok := s.files[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	return nopCloser{strings.NewReader(data)}, nil
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func storedMedia() repository.Media {
+	id, _ := primitive.ObjectIDFromHex(someID)
+
+	return repository.Media{
+		MongoID:     id,
+		Hash:        pngHash,
+		Key:         pngHash + ".png",
+		ContentType: "image/png",
+		Size:        int64(len(pngData)),
+		Filename:    "first.png",
+		CreatedAt:   createdAt,
+	}
+}
+
+func TestService_Upload(t *testing.T) {
+	uploaded := Media{
+		ID:          someID,
+		ContentType: "image/png",
+		Size:        int64(len(pngData)),
+		Hash:        pngHash,
+		Filename:    "singleton.png",
+		CreatedAt:   createdAt,
+	}
+	existing := uploaded
+	existing.Filename = "first.png"
+
+	tt := []struct {
+		name            string
+		filename        string
+		content         io.Reader
+		repository      *mediaRepositoryMock
+		storage         *storageMock
+		expectedResult  Media
+		expectedCreated bool
+		expectedFiles   map[string]string
+		expectedError   error
+	}{
+		{
+			name:            "Ok - Upload",
+			filename:        `C:\Users\someone\singleton.png`,
+			content:         strings.NewReader(pngData),
+			repository:      &mediaRepositoryMock{},
+			storage:         &storageMock{files: map[string]string{}},
+			expectedResult:  uploaded,
+			expectedCreated: true,
+			expectedFiles:   map[string]string{pngHash + ".png": pngData},
+		},
+		{
+			name:           "Ok - Already uploaded",
+			filename:       "singleton.png",
+			content:        strings.NewReader(pngData),
+			repository:     &mediaRepositoryMock{stored: map[string]repository.Media{pngHash: storedMedia()}},
+			storage:        &storageMock{files: map[string]string{}},
+			expectedResult: existing,
+			expectedFiles:  map[string]string{},
+		},
+		{
+			name:           "Ok - Uploaded concurrently",
+			filename:       "singleton.png",
+			content:        strings.NewReader(pngData),
+			repository:     &mediaRepositoryMock{duplicated: map[string]bool{pngHash: true}},
+			storage:        &storageMock{files: map[string]string{}},
+			expectedResult: existing,
+			expectedFiles:  map[string]string{pngHash + ".png": pngData},
+		},
+		{
+			name:          "Error - Too large",
+			content:       strings.NewReader(pngData + strings.Repeat("x", 64)),
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedFiles: map[string]string{},
+			expectedError: ErrTooLarge,
+		},
+		{
+			name:          "Error - Empty",
+			content:       strings.NewReader(""),
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedFiles: map[string]string{},
+			expectedError: ErrEmpty,
+		},
+		{
+			name:          "Error - SVG",
+			content:       strings.NewReader(`<svg><script>alert(1)</script></svg>`),
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedFiles: map[string]string{},
+			expectedError: ErrUnsupportedType,
+		},
+		{
+			name:          "Error - Unreadable",
+			content:       iotest.ErrReader(errors.New("some-error")),
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedFiles: map[string]string{},
+			expectedError: ErrUnreadable,
+		},
+		{
+			name:          "Error - Repository",
+			content:       strings.NewReader(pngData),
+			repository:    &mediaRepositoryMock{err: errors.New("some-error")},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedFiles: map[string]string{},
+			expectedError: ErrSomethingWentWrong,
+		},
+		{
+			name:          "Error - Storage",
+			content:       strings.NewReader(pngData),
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}, err: errors.New("some-error")},
+			expectedFiles: map[string]string{},
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(tc.repository, tc.storage, logging.Discard(), WithMaxSize(64))
+
+			result, created, err := service.Upload(context.Background(), tc.filename, tc.content)
+
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expectedResult, result)
+			require.Equal(t, tc.expectedCreated, created)
+			require.Equal(t, tc.expectedFiles, tc.storage.files)
+		})
+	}
+}
+
+func TestService_Upload_TooLargeMessage(t *testing.T) {
+	service := NewService(&mediaRepositoryMock{}, &storageMock{}, logging.Discard(), WithMaxSize(4))
+
+	_, _, err := service.Upload(context.Background(), "", strings.NewReader(pngData))
+
+	require.EqualError(t, err, "Media is too large, the limit is 4 bytes")
+}
+
+func TestService_Open(t *testing.T) {
+	tt := []struct {
+		name            string
+		id              string
+		repository      *mediaRepositoryMock
+		storage         *storageMock
+		expectedResult  Media
+		expectedContent string
+		expectedError   error
+	}{
+		{
+			name:       "Ok - Open",
+			id:         someID,
+			repository: &mediaRepositoryMock{stored: map[string]repository.Media{pngHash: storedMedia()}},
+			storage:    &storageMock{files: map[string]string{pngHash + ".png": pngData}},
+			expectedResult: Media{
+				ID:          someID,
+				ContentType: "image/png",
+				Size:        int64(len(pngData)),
+				Hash:        pngHash,
+				Filename:    "first.png",
+				CreatedAt:   createdAt,
+			},
+			expectedContent: pngData,
+		},
+		{
+			name:          "Error - Not found",
+			id:            someID,
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedError: ErrMediaNotFound,
+		},
+		{
+			name:          "Error - Invalid id",
+			id:            "aaaa",
+			repository:    &mediaRepositoryMock{},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "Error - Content missing",
+			id:            someID,
+			repository:    &mediaRepositoryMock{stored: map[string]repository.Media{pngHash: storedMedia()}},
+			storage:       &storageMock{files: map[string]string{}},
+			expectedError: ErrMediaNotFound,
+		},
+		{
+			name:          "Error - Storage",
+			id:            someID,
+			repository:    &mediaRepositoryMock{stored: map[string]repository.Media{pngHash: storedMedia()}},
+			storage:       &storageMock{err: errors.New("some-error")},
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(tc.repository, tc.storage, logging.Discard())
+
+			result, content, err := service.Open(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedResult, result)
+			if tc.expectedError != nil {
+				require.Nil(t, content)
+				return
+			}
+			data, err := io.ReadAll(content)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedContent, string(data))
+		})
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	tt := []struct {
+		name     string
+		filename string
+		expected string
+	}{
+		{name: "base name", filename: "singleton.png", expected: "singleton.png"},
+		{name: "unix path", filename: "/home/someone/singleton.png", expected: "singleton.png"},
+		{name: "windows path", filename: `C:\singleton.png`, expected: "singleton.png"},
+		{name: "empty", filename: "", expected: ""},
+		{name: "directory", filename: "/", expected: ""},
+		{name: "long", filename: strings.Repeat("á", 200), expected: strings.Repeat("á", 127)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, cleanFilename(tc.filename))
+		})
+	}
+}
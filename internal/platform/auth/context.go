@@ -0,0 +1,25 @@
+package auth
+
+import "context"
+
+type principalKey struct{}
+
+// Principal is who a request is authenticated as, along with the roles that grant it
+// permissions. Permissions are granted directly, as the scopes of API keys are, instead of
+// through roles.
+type Principal struct {
+	Subject     string
+	Roles       []string
+	Permissions []Permission
+}
+
+// WithPrincipal returns a copy of ctx carrying principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the Principal carried by ctx, if the request was authenticated.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// jwk is a JSON Web Key. Only the members of RSA public keys are decoded.
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// LoadJWKS reads the RSA public keys of the JSON Web Key Set file at path, by key ID. Keys
+// that are not RSA signing keys are skipped, but the set must have at least one.
+func LoadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWKS file: %w", err)
+	}
+
+	return ParseJWKS(content)
+}
+
+// ParseJWKS decodes the RSA public keys of a JSON Web Key Set, by key ID.
+func ParseJWKS(content []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("decoding JWKS: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range set.Keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Algorithm != "" && key.Algorithm != "RS256") {
+			continue
+		}
+
+		publicKey, err := key.rsaPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("decoding JWKS key %q: %w", key.KeyID, err)
+		}
+		if _, ok := keys[key.KeyID]; ok {
+			return nil, fmt.Errorf("decoding JWKS: duplicated key %q", key.KeyID)
+		}
+		keys[key.KeyID] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("decoding JWKS: no RS256 signing keys")
+	}
+
+	return keys, nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+	if err != nil {
+		return nil, fmt.Errorf("modulus: %w", err)
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+	if err != nil {
+		return nil, fmt.Errorf("exponent: %w", err)
+	}
+
+	n := new(big.Int).SetBytes(modulus)
+	e := new(big.Int).SetBytes(exponent)
+	if n.BitLen() < 2048 {
+		return nil, errors.New("modulus must have at least 2048 bits")
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid exponent")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
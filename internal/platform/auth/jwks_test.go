@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func jwkJSON(kid string, key *rsa.PublicKey, extra string) string {
+	return fmt.Sprintf(`{"kty":"RSA","kid":%q,"n":%q,"e":%q%s}`, kid,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		extra,
+	)
+}
+
+func TestLoadJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	content := fmt.Sprintf(`{"keys":[%s,%s,{"kty":"EC","kid":"ec","crv":"P-256"}]}`,
+		jwkJSON("key-1", &key.PublicKey, `,"use":"sig","alg":"RS256"`),
+		jwkJSON("key-enc", &key.PublicKey, `,"use":"enc"`),
+	)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	keys, err := LoadJWKS(path)
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]*rsa.PublicKey{"key-1": &key.PublicKey}, keys)
+}
+
+func TestParseJWKS_Errors(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	tt := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "not json",
+			content:       "keys",
+			expectedError: "decoding JWKS: invalid character 'k' looking for beginning of value",
+		},
+		{
+			name:          "no signing keys",
+			content:       `{"keys":[]}`,
+			expectedError: "decoding JWKS: no RS256 signing keys",
+		},
+		{
+			name:          "weak key",
+			content:       fmt.Sprintf(`{"keys":[%s]}`, jwkJSON("weak", &weakKey.PublicKey, "")),
+			expectedError: `decoding JWKS key "weak": modulus must have at least 2048 bits`,
+		},
+		{
+			name:          "duplicated key id",
+			content:       fmt.Sprintf(`{"keys":[%s,%s]}`, jwkJSON("key-1", &key.PublicKey, ""), jwkJSON("key-1", &key.PublicKey, "")),
+			expectedError: `decoding JWKS: duplicated key "key-1"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseJWKS([]byte(tc.content))
+
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxTokenLength bounds the tokens parsed, so oversized headers are rejected before decoding.
+const maxTokenLength = 8 << 10
+
+var (
+	// ErrInvalidToken is wrapped by the errors of tokens that are malformed, signed with an
+	// unexpected algorithm or key, or whose claims don't match.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrTokenExpired is returned when a token is past its expiration time.
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Claims are the registered claims of a verified token, along with the roles of its subject
+// from the private roles claim.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	Roles     []string
+}
+
+// Verifier verifies signed JSON Web Tokens: HS256 tokens with a shared secret and RS256
+// tokens with the RSA public keys of a JSON Web Key Set. Tokens must have a subject and an
+// expiration time.
+type Verifier struct {
+	secret   []byte
+	keys     map[string]*rsa.PublicKey
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// VerifierOption configures a Verifier.
+type VerifierOption func(*Verifier)
+
+// WithIssuer makes the Verifier reject tokens whose iss claim is not issuer.
+func WithIssuer(issuer string) VerifierOption {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience makes the Verifier reject tokens whose aud claim doesn't include audience.
+func WithAudience(audience string) VerifierOption {
+	return func(v *Verifier) {
+		v.audience = audience
+	}
+}
+
+// WithLeeway tolerates clock skew between the issuer and the API when checking the exp and
+// nbf claims.
+func WithLeeway(leeway time.Duration) VerifierOption {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}
+
+// NewVerifier creates a Verifier of HS256 tokens signed with secret and of RS256 tokens
+// signed with keys, by key ID. An algorithm is rejected when it has no key, so an empty
+// secret disables HS256 and no keys disable RS256.
+func NewVerifier(secret []byte, keys map[string]*rsa.PublicKey, opts ...VerifierOption) *Verifier {
+	v := &Verifier{secret: secret, keys: keys, now: time.Now}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type payload struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	Roles     []string `json:"roles"`
+}
+
+// audience is the aud claim, which is either a single string or a list of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+
+	return nil
+}
+
+// Verify checks the signature and the claims of token and returns its claims.
+func (v *Verifier) Verify(token string) (Claims, error) {
+	if len(token) > maxTokenLength {
+		return Claims{}, fmt.Errorf("%w: too long", ErrInvalidToken)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, fmt.Errorf("%w: not a signed JWT", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Claims{}, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], signature); err != nil {
+		return Claims{}, err
+	}
+
+	var p payload
+	if err := decodeSegment(parts[1], &p); err != nil {
+		return Claims{}, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+
+	return v.validate(p)
+}
+
+// verifySignature checks signature with the key of the algorithm named in the header. The
+// algorithm is only trusted to pick among the configured keys, so a token can't make an RSA
+// public key be used as an HMAC secret.
+func (v *Verifier) verifySignature(h header, signed string, signature []byte) error {
+	switch h.Algorithm {
+	case "HS256":
+		if len(v.secret) == 0 {
+			break
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+
+	case "RS256":
+		key, ok := v.keys[h.KeyID]
+		if !ok && h.KeyID == "" && len(v.keys) == 1 {
+			for _, only := range v.keys {
+				key, ok = only, true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrInvalidToken, h.KeyID)
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, h.Algorithm)
+}
+
+func (v *Verifier) validate(p payload) (Claims, error) {
+	now := v.now()
+
+	if p.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+	if p.ExpiresAt == nil {
+		return Claims{}, fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+
+	expiresAt := numericDate(*p.ExpiresAt)
+	if !now.Before(expiresAt.Add(v.leeway)) {
+		return Claims{}, ErrTokenExpired
+	}
+	if p.NotBefore != nil && now.Add(v.leeway).Before(numericDate(*p.NotBefore)) {
+		return Claims{}, fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	}
+	if v.issuer != "" && p.Issuer != v.issuer {
+		return Claims{}, fmt.Errorf("%w: unexpected iss %q", ErrInvalidToken, p.Issuer)
+	}
+	if v.audience != "" && !slices.Contains(p.Audience, v.audience) {
+		return Claims{}, fmt.Errorf("%w: unexpected aud", ErrInvalidToken)
+	}
+
+	return Claims{
+		Subject:   p.Subject,
+		Issuer:    p.Issuer,
+		Audience:  p.Audience,
+		ExpiresAt: expiresAt,
+		Roles:     p.Roles,
+	}, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts seconds since the epoch, which may have a fraction, to a time.
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC()
+}
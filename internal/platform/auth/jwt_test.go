@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	secret = []byte("0123456789abcdef0123456789abcdef")
+	now    = time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func sign(t *testing.T, header, claims map[string]interface{}, key interface{}) string {
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signed := encode(header) + "." + encode(claims)
+
+	var signature []byte
+	switch key := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func claims(overrides map[string]interface{}) map[string]interface{} {
+	claims := map[string]interface{}{
+		"sub": "ada",
+		"iss": "https://auth.waydevs.com",
+		"aud": "sections-api",
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+			continue
+		}
+		claims[name] = value
+	}
+
+	return claims
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	hs256 := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	rs256 := map[string]interface{}{"alg": "RS256", "kid": "key-1"}
+
+	tt := []struct {
+		name          string
+		token         string
+		expected      Claims
+		expectedError error
+	}{
+		{
+			name:  "Ok - HS256",
+			token: sign(t, hs256, claims(nil), secret),
+			expected: Claims{
+				Subject:   "ada",
+				Issuer:    "https://auth.waydevs.com",
+				Audience:  []string{"sections-api"},
+				ExpiresAt: now.Add(time.Hour),
+			},
+		},
+		{
+			name:  "Ok - Roles",
+			token: sign(t, hs256, claims(map[string]interface{}{"roles": []string{"editor", "reviewer"}}), secret),
+			expected: Claims{
+				Subject:   "ada",
+				Issuer:    "https://auth.waydevs.com",
+				Audience:  []string{"sections-api"},
+				ExpiresAt: now.Add(time.Hour),
+				Roles:     []string{"editor", "reviewer"},
+			},
+		},
+		{
+			name:  "Ok - RS256 with audience list",
+			token: sign(t, rs256, claims(map[string]interface{}{"aud": []string{"web", "sections-api"}}), rsaKey),
+			expected: Claims{
+				Subject:   "ada",
+				Issuer:    "https://auth.waydevs.com",
+				Audience:  []string{"web", "sections-api"},
+				ExpiresAt: now.Add(time.Hour),
+			},
+		},
+		{
+			name:  "Ok - Expired within leeway",
+			token: sign(t, hs256, claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()}), secret),
+			expected: Claims{
+				Subject:   "ada",
+				Issuer:    "https://auth.waydevs.com",
+				Audience:  []string{"sections-api"},
+				ExpiresAt: now.Add(-30 * time.Second),
+			},
+		},
+		{
+			name:          "Error - Expired",
+			token:         sign(t, hs256, claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()}), secret),
+			expectedError: ErrTokenExpired,
+		},
+		{
+			name:          "Error - Not valid yet",
+			token:         sign(t, hs256, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}), secret),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Wrong secret",
+			token:         sign(t, hs256, claims(nil), []byte("another-secret")),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Wrong RSA key",
+			token:         sign(t, rs256, claims(nil), otherKey),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Unknown key id",
+			token:         sign(t, map[string]interface{}{"alg": "RS256", "kid": "key-2"}, claims(nil), rsaKey),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Algorithm none",
+			token:         sign(t, map[string]interface{}{"alg": "none"}, claims(nil), nil),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Missing subject",
+			token:         sign(t, hs256, claims(map[string]interface{}{"sub": nil}), secret),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Missing expiration",
+			token:         sign(t, hs256, claims(map[string]interface{}{"exp": nil}), secret),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Wrong issuer",
+			token:         sign(t, hs256, claims(map[string]interface{}{"iss": "https://evil.com"}), secret),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Wrong audience",
+			token:         sign(t, hs256, claims(map[string]interface{}{"aud": "another-api"}), secret),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "Error - Malformed",
+			token:         "not-a-token",
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := NewVerifier(secret, map[string]*rsa.PublicKey{"key-1": &rsaKey.PublicKey},
+				WithIssuer("https://auth.waydevs.com"),
+				WithAudience("sections-api"),
+				WithLeeway(time.Minute),
+			)
+			verifier.now = func() time.Time { return now }
+
+			result, err := verifier.Verify(tc.token)
+
+			require.ErrorIs(t, err, tc.expectedError)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVerifier_Verify_DisabledAlgorithm(t *testing.T) {
+	verifier := NewVerifier(nil, nil)
+	verifier.now = func() time.Time { return now }
+
+	_, err := verifier.Verify(sign(t, map[string]interface{}{"alg": "HS256"}, claims(nil), []byte{}))
+
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
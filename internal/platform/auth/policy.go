@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Permission allows an action on a kind of content.
+type Permission string
+
+const (
+	ReadDrafts      Permission = "read:drafts"
+	CreatePatterns  Permission = "create:patterns"
+	UpdatePatterns  Permission = "update:patterns"
+	DeletePatterns  Permission = "delete:patterns"
+	PurgePatterns   Permission = "purge:patterns"
+	PublishPatterns Permission = "publish:patterns"
+	ApprovePatterns Permission = "approve:patterns"
+	WriteSections   Permission = "write:sections"
+	UploadMedia     Permission = "upload:media"
+	ManageAPIKeys   Permission = "manage:apikeys"
+	ReadStats       Permission = "read:stats"
+)
+
+// allPermissions grants every Permission to a role.
+const allPermissions = "*"
+
+// Permissions are every Permission known to the API.
+var Permissions = []Permission{
+	ReadDrafts,
+	CreatePatterns,
+	UpdatePatterns,
+	DeletePatterns,
+	PurgePatterns,
+	PublishPatterns,
+	ApprovePatterns,
+	WriteSections,
+	UploadMedia,
+	ManageAPIKeys,
+	ReadStats,
+}
+
+// scopeAliases are scopes that grant several Permissions at once.
+var scopeAliases = map[string][]Permission{
+	"write:patterns": {CreatePatterns, UpdatePatterns, DeletePatterns},
+}
+
+// ScopePermissions returns the Permissions granted by scopes, which are names of Permissions
+// or aliases such as write:patterns for creating, updating and deleting Design Patterns. It
+// fails on scopes it doesn't know.
+func ScopePermissions(scopes []string) ([]Permission, error) {
+	permissions := []Permission{}
+	for _, scope := range scopes {
+		granted, ok := scopeAliases[scope]
+		if !ok {
+			if !slices.Contains(Permissions, Permission(scope)) {
+				return nil, fmt.Errorf("unknown scope %q", scope)
+			}
+			granted = []Permission{Permission(scope)}
+		}
+
+		for _, permission := range granted {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+// Policy tells which Permissions each role has.
+type Policy struct {
+	roles map[string]map[Permission]bool
+}
+
+// NewPolicy creates a Policy from the names of the permissions of each role, where * stands
+// for every permission. It fails on permissions it doesn't know, so a typo can't silently
+// deny or grant access.
+func NewPolicy(roles map[string][]string) (*Policy, error) {
+	known := map[Permission]bool{}
+	for _, permission := range Permissions {
+		known[permission] = true
+	}
+
+	policy := &Policy{roles: map[string]map[Permission]bool{}}
+	for role, names := range roles {
+		granted := map[Permission]bool{}
+		for _, name := range names {
+			if name == allPermissions {
+				granted = known
+				break
+			}
+			if !known[Permission(name)] {
+				return nil, fmt.Errorf("role %s has an unknown permission %q", role, name)
+			}
+			granted[Permission(name)] = true
+		}
+		policy.roles[role] = granted
+	}
+
+	return policy, nil
+}
+
+// Allows reports whether principal was granted permission, either directly or by any of its
+// roles. Unknown roles have no permissions.
+func (p *Policy) Allows(principal Principal, permission Permission) bool {
+	if slices.Contains(principal.Permissions, permission) {
+		return true
+	}
+
+	for _, role := range principal.Roles {
+		if p.roles[role][permission] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	policy, err := NewPolicy(map[string][]string{
+		"editor":   {"create:patterns", "update:patterns"},
+		"reviewer": {"approve:patterns"},
+		"admin":    {"*"},
+		"reader":   {},
+	})
+	require.NoError(t, err)
+
+	tt := []struct {
+		name        string
+		roles       []string
+		permissions []Permission
+		permission  Permission
+		expected    bool
+	}{
+		{name: "granted", roles: []string{"editor"}, permission: UpdatePatterns, expected: true},
+		{name: "not granted", roles: []string{"editor"}, permission: PurgePatterns, expected: false},
+		{name: "granted by any role", roles: []string{"reader", "reviewer"}, permission: ApprovePatterns, expected: true},
+		{name: "every permission", roles: []string{"admin"}, permission: PublishPatterns, expected: true},
+		{name: "unknown role", roles: []string{"owner"}, permission: CreatePatterns, expected: false},
+		{name: "no roles", roles: nil, permission: ReadDrafts, expected: false},
+		{name: "granted directly", permissions: []Permission{ReadDrafts}, permission: ReadDrafts, expected: true},
+		{name: "not granted directly", permissions: []Permission{ReadDrafts}, permission: CreatePatterns, expected: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, policy.Allows(Principal{Subject: "ada", Roles: tc.roles, Permissions: tc.permissions}, tc.permission))
+		})
+	}
+}
+
+func TestNewPolicy_UnknownPermission(t *testing.T) {
+	_, err := NewPolicy(map[string][]string{"editor": {"create:patterns", "fly:patterns"}})
+
+	require.EqualError(t, err, `role editor has an unknown permission "fly:patterns"`)
+}
+
+func TestScopePermissions(t *testing.T) {
+	tt := []struct {
+		name          string
+		scopes        []string
+		expected      []Permission
+		expectedError string
+	}{
+		{name: "permissions", scopes: []string{"read:drafts", "upload:media"}, expected: []Permission{ReadDrafts, UploadMedia}},
+		{name: "alias", scopes: []string{"update:patterns", "write:patterns"}, expected: []Permission{UpdatePatterns, CreatePatterns, DeletePatterns}},
+		{name: "no scopes", scopes: nil, expected: []Permission{}},
+		{name: "unknown scope", scopes: []string{"read:drafts", "*"}, expectedError: `unknown scope "*"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			permissions, err := ScopePermissions(tc.scopes)
+
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, permissions)
+		})
+	}
+}
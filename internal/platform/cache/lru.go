@@ -0,0 +1,107 @@
+// Package cache keeps values in memory, so they don't have to be read or computed again.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU keeps the most recently used values up to a size, for up to a TTL since they were set.
+// It is safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[K]*list.Element
+	now     func() time.Time
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// NewLRU creates an LRU keeping up to size values, each for up to ttl. Values don't expire
+// when ttl is zero, and none are kept when size is zero.
+func NewLRU[K comparable, V any](size int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: map[K]*list.Element{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the value of key, unless it is not kept or expired.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	e := element.Value.(*entry[K, V])
+	if c.ttl > 0 && !c.now().Before(e.expires) {
+		c.remove(element)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+
+	return e.value, true
+}
+
+// Set keeps value as the one of key, evicting the least recently used value when the LRU is
+// full.
+func (c *LRU[K, V]) Set(key K, value V) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value = value
+		e.expires = expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete drops the value of key, if it is kept.
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len returns how many values are kept, including expired ones not dropped yet.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	lru := NewLRU[string, int](2, 0)
+
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+	// Reading a makes b the least recently used.
+	_, ok := lru.Get("a")
+	require.True(t, ok)
+	lru.Set("c", 3)
+
+	_, ok = lru.Get("b")
+	require.False(t, ok)
+	value, ok := lru.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	value, ok = lru.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+	require.Equal(t, 2, lru.Len())
+}
+
+func TestLRU_TTL(t *testing.T) {
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	lru := NewLRU[string, int](10, time.Minute)
+	lru.now = func() time.Time { return now }
+
+	lru.Set("a", 1)
+	now = now.Add(30 * time.Second)
+	lru.Set("b", 2)
+
+	now = now.Add(30 * time.Second)
+	_, ok := lru.Get("a")
+	require.False(t, ok)
+	value, ok := lru.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+	require.Equal(t, 1, lru.Len())
+
+	// Setting a value again restarts its TTL.
+	lru.Set("b", 3)
+	now = now.Add(59 * time.Second)
+	value, ok = lru.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+}
+
+func TestLRU_Delete(t *testing.T) {
+	lru := NewLRU[string, int](10, 0)
+
+	lru.Set("a", 1)
+	lru.Delete("a")
+	lru.Delete("b")
+
+	_, ok := lru.Get("a")
+	require.False(t, ok)
+	require.Zero(t, lru.Len())
+}
+
+func TestLRU_ZeroSize(t *testing.T) {
+	lru := NewLRU[string, int](0, 0)
+
+	lru.Set("a", 1)
+
+	_, ok := lru.Get("a")
+	require.False(t, ok)
+	require.Zero(t, lru.Len())
+}
@@ -1 +1,566 @@
-package configs
\ No newline at end of file
+package configs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/waydevs/sections-api/internal/platform/auth"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of every environment variable read by Load.
+const EnvPrefix = "SECTIONS_"
+
+// ConfigFileEnv is the environment variable holding the optional configuration file path.
+const ConfigFileEnv = EnvPrefix + "CONFIG_FILE"
+
+var logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+
+// localePattern matches lowercase language tags such as es or en-us.
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// Config is the configuration of the API.
+type Config struct {
+	Server         ServerConfig         `yaml:"server"`
+	Mongo          MongoConfig          `yaml:"mongo"`
+	Log            LogConfig            `yaml:"log"`
+	CORS           CORSConfig           `yaml:"cors"`
+	Health         HealthConfig         `yaml:"health"`
+	DesignPatterns DesignPatternsConfig `yaml:"designPatterns"`
+	I18n           I18nConfig           `yaml:"i18n"`
+	Media          MediaConfig          `yaml:"media"`
+	Auth           AuthConfig           `yaml:"auth"`
+	RateLimit      RateLimitConfig      `yaml:"rateLimit"`
+}
+
+// ServerConfig configures the HTTP server.
+type ServerConfig struct {
+	Address      string        `yaml:"address"`
+	ReadTimeout  time.Duration `yaml:"readTimeout"`
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+	// DrainDelay is how long readiness fails before the server stops accepting
+	// connections, so load balancers stop routing to it first.
+	DrainDelay time.Duration `yaml:"drainDelay"`
+	// ShutdownTimeout is the grace period for in-flight requests to finish.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// TrustedProxies are the IP addresses or CIDR ranges of the proxies whose
+	// X-Forwarded-For header tells the IP address of clients. Other clients could spoof it.
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+// MongoConfig configures the connection to MongoDB.
+type MongoConfig struct {
+	URI      string `yaml:"uri"`
+	Database string `yaml:"database"`
+	// Timeout bounds connecting and selecting a server.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// LogConfig configures logging.
+type LogConfig struct {
+	// Level is one of debug, info, warn or error.
+	Level string `yaml:"level"`
+}
+
+// CORSConfig configures Cross-Origin Resource Sharing. CORS is disabled when no origins
+// are allowed.
+type CORSConfig struct {
+	// AllowedOrigins are full origins such as https://waydevs.com, or * to allow any origin.
+	AllowedOrigins []string      `yaml:"allowedOrigins"`
+	AllowedMethods []string      `yaml:"allowedMethods"`
+	AllowedHeaders []string      `yaml:"allowedHeaders"`
+	MaxAge         time.Duration `yaml:"maxAge"`
+}
+
+// HealthConfig configures the readiness checks.
+type HealthConfig struct {
+	// Timeout bounds each dependency check.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DesignPatternsConfig configures the Design Patterns endpoints.
+type DesignPatternsConfig struct {
+	// RequireIfMatch rejects updates and deletes that don't say which version they were
+	// made against, so they can't overwrite changes they haven't seen.
+	RequireIfMatch bool `yaml:"requireIfMatch"`
+	// TrashRetention is how long deleted Design Patterns stay in the trash before they are
+	// purged. Zero keeps them until they are purged by hand.
+	TrashRetention time.Duration `yaml:"trashRetention"`
+	// PurgeInterval is how often the trash is checked for expired Design Patterns.
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+	// SchedulerInterval is how often Design Patterns scheduled for publication are checked.
+	SchedulerInterval time.Duration `yaml:"schedulerInterval"`
+	// RenderCacheSize is how many Design Patterns rendered to HTML are kept in memory. Zero
+	// renders them on every read.
+	RenderCacheSize int `yaml:"renderCacheSize"`
+	// CacheSize is how many Design Patterns and pages of lists read from Mongo are kept in
+	// memory. Zero reads them from Mongo every time.
+	CacheSize int `yaml:"cacheSize"`
+	// CacheTTL is how long what is kept in memory is read from there, which bounds how long
+	// other instances take to see a write.
+	CacheTTL time.Duration `yaml:"cacheTTL"`
+}
+
+// I18nConfig configures the locales content is available in.
+type I18nConfig struct {
+	// DefaultLocale is the locale content is written in and served in when none of the
+	// locales a request prefers is available.
+	DefaultLocale string `yaml:"defaultLocale"`
+	// Locales are the locales content can be translated to, including DefaultLocale.
+	Locales []string `yaml:"locales"`
+}
+
+// MediaConfig configures the uploads of media.
+type MediaConfig struct {
+	// Dir is the directory uploaded files are stored in. It is created when it doesn't exist.
+	Dir string `yaml:"dir"`
+	// MaxSize is the largest file accepted, in bytes.
+	MaxSize int `yaml:"maxSize"`
+	// CacheMaxAge is how long clients may cache media. Zero makes them revalidate every time.
+	CacheMaxAge time.Duration `yaml:"cacheMaxAge"`
+}
+
+// AuthConfig configures the JSON Web Tokens required by the routes that change content.
+// HMACSecret or JWKSFile must be set, unless Disabled leaves those routes open to anyone.
+type AuthConfig struct {
+	// Disabled runs the API without authentication, for local development. It can't be set
+	// along with HMACSecret or JWKSFile.
+	Disabled bool `yaml:"disabled"`
+	// HMACSecret verifies HS256 tokens. It must have at least 32 bytes.
+	HMACSecret string `yaml:"hmacSecret"`
+	// JWKSFile is a JSON Web Key Set file with the RSA public keys verifying RS256 tokens.
+	JWKSFile string `yaml:"jwksFile"`
+	// Issuer and Audience, when set, must match the iss and aud claims of tokens.
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// Leeway tolerates clock skew when checking the expiration of tokens.
+	Leeway time.Duration `yaml:"leeway"`
+	// Roles are the permissions of each role, by role name, where * grants every permission.
+	// Tokens list the roles of their subject in a roles claim.
+	Roles map[string][]string `yaml:"roles"`
+	// APIKeyRotationGrace is how long the previous secret of a rotated API key keeps working.
+	APIKeyRotationGrace time.Duration `yaml:"apiKeyRotationGrace"`
+}
+
+// Enabled reports whether tokens can be verified, so routes that change content require them.
+func (c AuthConfig) Enabled() bool {
+	return c.HMACSecret != "" || c.JWKSFile != ""
+}
+
+// RateLimitConfig configures how many requests each client can make to the Design Patterns
+// endpoints per period, reads and writes separately. Zero requests don't limit them.
+type RateLimitConfig struct {
+	ReadRequests  int           `yaml:"readRequests"`
+	ReadPeriod    time.Duration `yaml:"readPeriod"`
+	WriteRequests int           `yaml:"writeRequests"`
+	WritePeriod   time.Duration `yaml:"writePeriod"`
+}
+
+// Default returns the configuration used for anything not set by a file or the environment.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:         ":8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			DrainDelay:      5 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Mongo: MongoConfig{
+			URI:      "mongodb://localhost:27017",
+			Database: "sections-db",
+			Timeout:  10 * time.Second,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "If-Match", "X-Change-Note"},
+			MaxAge:         12 * time.Hour,
+		},
+		Health: HealthConfig{
+			Timeout: 2 * time.Second,
+		},
+		DesignPatterns: DesignPatternsConfig{
+			TrashRetention:    30 * 24 * time.Hour,
+			PurgeInterval:     time.Hour,
+			SchedulerInterval: time.Minute,
+			RenderCacheSize:   1000,
+			CacheSize:         1000,
+			CacheTTL:          30 * time.Second,
+		},
+		I18n: I18nConfig{
+			DefaultLocale: "es",
+			Locales:       []string{"es", "en"},
+		},
+		Media: MediaConfig{
+			Dir:         "media",
+			MaxSize:     5 << 20,
+			CacheMaxAge: 365 * 24 * time.Hour,
+		},
+		Auth: AuthConfig{
+			Leeway:              time.Minute,
+			APIKeyRotationGrace: 24 * time.Hour,
+			Roles: map[string][]string{
+				"editor":   {"read:drafts", "create:patterns", "update:patterns", "delete:patterns", "write:sections", "upload:media"},
+				"reviewer": {"read:drafts", "approve:patterns"},
+				"admin":    {"*"},
+			},
+		},
+		RateLimit: RateLimitConfig{
+			ReadRequests:  300,
+			ReadPeriod:    time.Minute,
+			WriteRequests: 60,
+			WritePeriod:   time.Minute,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, then the YAML or JSON file at path when
+// it is not empty, then the SECTIONS_* environment variables, and validates the result.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	problems := loadEnv(&cfg)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return Config{}, fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return cfg, nil
+}
+
+// loadFile decodes the file at path into cfg. JSON is a subset of YAML, so both formats
+// go through the YAML decoder. Roles set in the file replace the default ones rather than
+// being merged into them, like SECTIONS_AUTH_ROLES does, so roles left out are not granted.
+func loadFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading configuration file: %w", err)
+	}
+
+	roles := cfg.Auth.Roles
+	cfg.Auth.Roles = nil
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decoding configuration file %s: %w", path, err)
+	}
+
+	if cfg.Auth.Roles == nil {
+		cfg.Auth.Roles = roles
+	}
+
+	return nil
+}
+
+// loadEnv overrides cfg with the environment variables that are set and returns a problem
+// for each one that can't be parsed.
+func loadEnv(cfg *Config) []string {
+	problems := []string{}
+
+	str := func(name string, target *string) {
+		if value, ok := os.LookupEnv(EnvPrefix + name); ok {
+			*target = value
+		}
+	}
+
+	list := func(name string, target *[]string) {
+		if value, ok := os.LookupEnv(EnvPrefix + name); ok {
+			*target = splitList(value)
+		}
+	}
+
+	boolean := func(name string, target *bool) {
+		value, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok {
+			return
+		}
+
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s%s must be true or false", EnvPrefix, name))
+			return
+		}
+		*target = parsed
+	}
+
+	integer := func(name string, target *int) {
+		value, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok {
+			return
+		}
+
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s%s must be an integer", EnvPrefix, name))
+			return
+		}
+		*target = parsed
+	}
+
+	// roles are read as role=permission,permission;role=permission and replace every role.
+	roles := func(name string, target *map[string][]string) {
+		value, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok {
+			return
+		}
+
+		parsed := map[string][]string{}
+		for _, entry := range strings.Split(value, ";") {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+			role, permissions, ok := strings.Cut(entry, "=")
+			if !ok || strings.TrimSpace(role) == "" {
+				problems = append(problems, fmt.Sprintf("%s%s must be a list of role=permission,permission separated by ;", EnvPrefix, name))
+				return
+			}
+			parsed[strings.TrimSpace(role)] = splitList(permissions)
+		}
+		*target = parsed
+	}
+
+	duration := func(name string, target *time.Duration) {
+		value, ok := os.LookupEnv(EnvPrefix + name)
+		if !ok {
+			return
+		}
+
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s%s must be a duration such as 10s", EnvPrefix, name))
+			return
+		}
+		*target = parsed
+	}
+
+	str("SERVER_ADDRESS", &cfg.Server.Address)
+	duration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	duration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	duration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	duration("SERVER_DRAIN_DELAY", &cfg.Server.DrainDelay)
+	duration("SERVER_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	list("SERVER_TRUSTED_PROXIES", &cfg.Server.TrustedProxies)
+	str("MONGO_URI", &cfg.Mongo.URI)
+	str("MONGO_DATABASE", &cfg.Mongo.Database)
+	duration("MONGO_TIMEOUT", &cfg.Mongo.Timeout)
+	str("LOG_LEVEL", &cfg.Log.Level)
+	list("CORS_ALLOWED_ORIGINS", &cfg.CORS.AllowedOrigins)
+	list("CORS_ALLOWED_METHODS", &cfg.CORS.AllowedMethods)
+	list("CORS_ALLOWED_HEADERS", &cfg.CORS.AllowedHeaders)
+	duration("CORS_MAX_AGE", &cfg.CORS.MaxAge)
+	duration("HEALTH_TIMEOUT", &cfg.Health.Timeout)
+	boolean("DESIGN_PATTERNS_REQUIRE_IF_MATCH", &cfg.DesignPatterns.RequireIfMatch)
+	duration("DESIGN_PATTERNS_TRASH_RETENTION", &cfg.DesignPatterns.TrashRetention)
+	duration("DESIGN_PATTERNS_PURGE_INTERVAL", &cfg.DesignPatterns.PurgeInterval)
+	duration("DESIGN_PATTERNS_SCHEDULER_INTERVAL", &cfg.DesignPatterns.SchedulerInterval)
+	integer("DESIGN_PATTERNS_RENDER_CACHE_SIZE", &cfg.DesignPatterns.RenderCacheSize)
+	integer("DESIGN_PATTERNS_CACHE_SIZE", &cfg.DesignPatterns.CacheSize)
+	duration("DESIGN_PATTERNS_CACHE_TTL", &cfg.DesignPatterns.CacheTTL)
+	str("I18N_DEFAULT_LOCALE", &cfg.I18n.DefaultLocale)
+	list("I18N_LOCALES", &cfg.I18n.Locales)
+	str("MEDIA_DIR", &cfg.Media.Dir)
+	integer("MEDIA_MAX_SIZE", &cfg.Media.MaxSize)
+	duration("MEDIA_CACHE_MAX_AGE", &cfg.Media.CacheMaxAge)
+	boolean("AUTH_DISABLED", &cfg.Auth.Disabled)
+	str("AUTH_HMAC_SECRET", &cfg.Auth.HMACSecret)
+	str("AUTH_JWKS_FILE", &cfg.Auth.JWKSFile)
+	str("AUTH_ISSUER", &cfg.Auth.Issuer)
+	str("AUTH_AUDIENCE", &cfg.Auth.Audience)
+	duration("AUTH_LEEWAY", &cfg.Auth.Leeway)
+	roles("AUTH_ROLES", &cfg.Auth.Roles)
+	duration("AUTH_API_KEY_ROTATION_GRACE", &cfg.Auth.APIKeyRotationGrace)
+	integer("RATE_LIMIT_READ_REQUESTS", &cfg.RateLimit.ReadRequests)
+	duration("RATE_LIMIT_READ_PERIOD", &cfg.RateLimit.ReadPeriod)
+	integer("RATE_LIMIT_WRITE_REQUESTS", &cfg.RateLimit.WriteRequests)
+	duration("RATE_LIMIT_WRITE_PERIOD", &cfg.RateLimit.WritePeriod)
+
+	return problems
+}
+
+func (c Config) validate() []string {
+	problems := []string{}
+
+	if _, port, err := net.SplitHostPort(c.Server.Address); err != nil {
+		problems = append(problems, "server.address must be host:port")
+	} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		problems = append(problems, "server.address must have a numeric port")
+	}
+
+	if c.Server.ReadTimeout <= 0 || c.Server.WriteTimeout <= 0 || c.Server.IdleTimeout <= 0 {
+		problems = append(problems, "server timeouts must be positive")
+	}
+
+	if c.Server.DrainDelay < 0 {
+		problems = append(problems, "server.drainDelay can't be negative")
+	}
+
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdownTimeout must be positive")
+	}
+
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			problems = append(problems, fmt.Sprintf("server.trustedProxies has an invalid IP address or CIDR range %q", proxy))
+		}
+	}
+
+	if !strings.HasPrefix(c.Mongo.URI, "mongodb://") && !strings.HasPrefix(c.Mongo.URI, "mongodb+srv://") {
+		problems = append(problems, "mongo.uri must start with mongodb:// or mongodb+srv://")
+	}
+
+	if c.Mongo.Database == "" {
+		problems = append(problems, "mongo.database is required")
+	}
+
+	if c.Mongo.Timeout <= 0 {
+		problems = append(problems, "mongo.timeout must be positive")
+	}
+
+	if !logLevels[c.Log.Level] {
+		problems = append(problems, "log.level must be one of debug, info, warn or error")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+
+		parsed, err := url.Parse(origin)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.Path != "" {
+			problems = append(problems, fmt.Sprintf("cors.allowedOrigins has an invalid origin %q", origin))
+		}
+	}
+
+	for _, method := range c.CORS.AllowedMethods {
+		if method == "" || strings.ToUpper(method) != method {
+			problems = append(problems, fmt.Sprintf("cors.allowedMethods has an invalid method %q", method))
+		}
+	}
+
+	if c.CORS.MaxAge < 0 {
+		problems = append(problems, "cors.maxAge can't be negative")
+	}
+
+	if c.Health.Timeout <= 0 {
+		problems = append(problems, "health.timeout must be positive")
+	}
+
+	if c.DesignPatterns.TrashRetention < 0 {
+		problems = append(problems, "designPatterns.trashRetention can't be negative")
+	}
+
+	if c.DesignPatterns.PurgeInterval <= 0 {
+		problems = append(problems, "designPatterns.purgeInterval must be positive")
+	}
+
+	if c.DesignPatterns.SchedulerInterval <= 0 {
+		problems = append(problems, "designPatterns.schedulerInterval must be positive")
+	}
+
+	if c.DesignPatterns.RenderCacheSize < 0 {
+		problems = append(problems, "designPatterns.renderCacheSize can't be negative")
+	}
+
+	if c.DesignPatterns.CacheSize < 0 {
+		problems = append(problems, "designPatterns.cacheSize can't be negative")
+	}
+
+	if c.DesignPatterns.CacheTTL <= 0 {
+		problems = append(problems, "designPatterns.cacheTTL must be positive")
+	}
+
+	defaultListed := false
+	for _, locale := range c.I18n.Locales {
+		if !localePattern.MatchString(locale) {
+			problems = append(problems, fmt.Sprintf("i18n.locales has an invalid locale %q, use lowercase tags such as en or en-us", locale))
+		}
+		if locale == c.I18n.DefaultLocale {
+			defaultListed = true
+		}
+	}
+
+	if !defaultListed {
+		problems = append(problems, "i18n.defaultLocale must be one of i18n.locales")
+	}
+
+	if c.Media.Dir == "" {
+		problems = append(problems, "media.dir is required")
+	}
+
+	if c.Media.MaxSize <= 0 {
+		problems = append(problems, "media.maxSize must be positive")
+	}
+
+	if c.Media.CacheMaxAge < 0 {
+		problems = append(problems, "media.cacheMaxAge can't be negative")
+	}
+
+	// Without keys to verify tokens with, every write would be open to anyone.
+	if !c.Auth.Enabled() && !c.Auth.Disabled {
+		problems = append(problems, "auth.hmacSecret or auth.jwksFile is required, or auth.disabled to run without authentication")
+	}
+
+	if c.Auth.Enabled() && c.Auth.Disabled {
+		problems = append(problems, "auth.disabled can't be set along with auth.hmacSecret or auth.jwksFile")
+	}
+
+	// Shorter secrets can be brute forced from a single token.
+	if c.Auth.HMACSecret != "" && len(c.Auth.HMACSecret) < 32 {
+		problems = append(problems, "auth.hmacSecret must have at least 32 bytes")
+	}
+
+	if c.Auth.Leeway < 0 {
+		problems = append(problems, "auth.leeway can't be negative")
+	}
+
+	if c.Auth.APIKeyRotationGrace < 0 {
+		problems = append(problems, "auth.apiKeyRotationGrace can't be negative")
+	}
+
+	if _, err := auth.NewPolicy(c.Auth.Roles); err != nil {
+		problems = append(problems, fmt.Sprintf("auth.roles: %v", err))
+	}
+
+	if c.RateLimit.ReadRequests < 0 || c.RateLimit.WriteRequests < 0 {
+		problems = append(problems, "rateLimit requests can't be negative")
+	}
+
+	if (c.RateLimit.ReadRequests > 0 && c.RateLimit.ReadPeriod <= 0) || (c.RateLimit.WriteRequests > 0 && c.RateLimit.WritePeriod <= 0) {
+		problems = append(problems, "rateLimit periods must be positive")
+	}
+
+	return problems
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,305 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	// Authentication must be configured or disabled explicitly.
+	t.Setenv("SECTIONS_AUTH_DISABLED", "true")
+
+	cfg, err := Load("")
+
+	require.NoError(t, err)
+	expected := Default()
+	expected.Auth.Disabled = true
+	require.Equal(t, expected, cfg)
+}
+
+func TestLoad_File(t *testing.T) {
+	tt := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{
+			name:     "yaml",
+			fileName: "config.yaml",
+			content: `
+server:
+  address: ":9090"
+  readTimeout: 5s
+mongo:
+  database: sections-test
+log:
+  level: debug
+cors:
+  allowedOrigins:
+    - https://waydevs.com
+`,
+		},
+		{
+			name:     "json",
+			fileName: "config.json",
+			content: `{
+				"server": {"address": ":9090", "readTimeout": "5s"},
+				"mongo": {"database": "sections-test"},
+				"log": {"level": "debug"},
+				"cors": {"allowedOrigins": ["https://waydevs.com"]}
+			}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("SECTIONS_AUTH_DISABLED", "true")
+			path := writeFile(t, tc.fileName, tc.content)
+
+			cfg, err := Load(path)
+
+			require.NoError(t, err)
+			require.Equal(t, ":9090", cfg.Server.Address)
+			require.Equal(t, 5*time.Second, cfg.Server.ReadTimeout)
+			require.Equal(t, Default().Server.WriteTimeout, cfg.Server.WriteTimeout)
+			require.Equal(t, "sections-test", cfg.Mongo.Database)
+			require.Equal(t, Default().Mongo.URI, cfg.Mongo.URI)
+			require.Equal(t, "debug", cfg.Log.Level)
+			require.Equal(t, []string{"https://waydevs.com"}, cfg.CORS.AllowedOrigins)
+		})
+	}
+}
+
+func TestLoad_FileRoles(t *testing.T) {
+	t.Setenv("SECTIONS_AUTH_DISABLED", "true")
+	path := writeFile(t, "config.yaml", `
+auth:
+  roles:
+    editor: ["read:drafts", "update:patterns"]
+`)
+
+	cfg, err := Load(path)
+
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"editor": {"read:drafts", "update:patterns"}}, cfg.Auth.Roles)
+
+	// Files that don't set roles keep the default ones.
+	cfg, err = Load(writeFile(t, "config.yaml", "log:\n  level: debug\n"))
+
+	require.NoError(t, err)
+	require.Equal(t, Default().Auth.Roles, cfg.Auth.Roles)
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", "mongo:\n  uri: mongodb://file:27017\n")
+	t.Setenv("SECTIONS_MONGO_URI", "mongodb://env:27017")
+	t.Setenv("SECTIONS_MONGO_TIMEOUT", "3s")
+	t.Setenv("SECTIONS_CORS_ALLOWED_ORIGINS", "https://a.com, https://b.com")
+	t.Setenv("SECTIONS_DESIGN_PATTERNS_REQUIRE_IF_MATCH", "true")
+	t.Setenv("SECTIONS_DESIGN_PATTERNS_TRASH_RETENTION", "168h")
+	t.Setenv("SECTIONS_DESIGN_PATTERNS_RENDER_CACHE_SIZE", "0")
+	t.Setenv("SECTIONS_DESIGN_PATTERNS_CACHE_TTL", "5s")
+	t.Setenv("SECTIONS_I18N_DEFAULT_LOCALE", "en")
+	t.Setenv("SECTIONS_I18N_LOCALES", "en, es, pt-br")
+	t.Setenv("SECTIONS_MEDIA_DIR", "/var/lib/sections/media")
+	t.Setenv("SECTIONS_MEDIA_MAX_SIZE", "1048576")
+	t.Setenv("SECTIONS_AUTH_JWKS_FILE", "/etc/sections/jwks.json")
+	t.Setenv("SECTIONS_AUTH_AUDIENCE", "sections-api")
+	t.Setenv("SECTIONS_AUTH_ROLES", "writer=create:patterns, update:patterns;owner=*")
+	t.Setenv("SECTIONS_AUTH_API_KEY_ROTATION_GRACE", "1h")
+	t.Setenv("SECTIONS_SERVER_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.1")
+	t.Setenv("SECTIONS_RATE_LIMIT_READ_REQUESTS", "0")
+	t.Setenv("SECTIONS_RATE_LIMIT_WRITE_PERIOD", "1h")
+
+	cfg, err := Load(path)
+
+	require.NoError(t, err)
+	require.True(t, cfg.DesignPatterns.RequireIfMatch)
+	require.Equal(t, 7*24*time.Hour, cfg.DesignPatterns.TrashRetention)
+	require.Zero(t, cfg.DesignPatterns.RenderCacheSize)
+	require.Equal(t, 1000, cfg.DesignPatterns.CacheSize)
+	require.Equal(t, 5*time.Second, cfg.DesignPatterns.CacheTTL)
+	require.Equal(t, "en", cfg.I18n.DefaultLocale)
+	require.Equal(t, []string{"en", "es", "pt-br"}, cfg.I18n.Locales)
+	require.Equal(t, "/var/lib/sections/media", cfg.Media.Dir)
+	require.Equal(t, 1<<20, cfg.Media.MaxSize)
+	require.True(t, cfg.Auth.Enabled())
+	require.Equal(t, "/etc/sections/jwks.json", cfg.Auth.JWKSFile)
+	require.Equal(t, "sections-api", cfg.Auth.Audience)
+	require.Equal(t, time.Minute, cfg.Auth.Leeway)
+	require.Equal(t, map[string][]string{
+		"writer": {"create:patterns", "update:patterns"},
+		"owner":  {"*"},
+	}, cfg.Auth.Roles)
+	require.Equal(t, time.Hour, cfg.Auth.APIKeyRotationGrace)
+	require.Equal(t, []string{"10.0.0.0/8", "192.168.1.1"}, cfg.Server.TrustedProxies)
+	require.Zero(t, cfg.RateLimit.ReadRequests)
+	require.Equal(t, 60, cfg.RateLimit.WriteRequests)
+	require.Equal(t, time.Hour, cfg.RateLimit.WritePeriod)
+	require.Equal(t, "mongodb://env:27017", cfg.Mongo.URI)
+	require.Equal(t, 3*time.Second, cfg.Mongo.Timeout)
+	require.Equal(t, []string{"https://a.com", "https://b.com"}, cfg.CORS.AllowedOrigins)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tt := []struct {
+		name          string
+		env           map[string]string
+		file          string
+		expectedError string
+	}{
+		{
+			name:          "missing file",
+			file:          "does-not-exist.yaml",
+			expectedError: "reading configuration file",
+		},
+		{
+			name:          "unknown field",
+			file:          writeFile(t, "unknown.yaml", "mongo:\n  url: mongodb://localhost\n"),
+			expectedError: "field url not found",
+		},
+		{
+			name:          "invalid duration",
+			env:           map[string]string{"SECTIONS_SERVER_READ_TIMEOUT": "soon"},
+			expectedError: "SECTIONS_SERVER_READ_TIMEOUT must be a duration such as 10s",
+		},
+		{
+			name:          "invalid boolean",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_REQUIRE_IF_MATCH": "sometimes"},
+			expectedError: "SECTIONS_DESIGN_PATTERNS_REQUIRE_IF_MATCH must be true or false",
+		},
+		{
+			name:          "invalid integer",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_RENDER_CACHE_SIZE": "many"},
+			expectedError: "SECTIONS_DESIGN_PATTERNS_RENDER_CACHE_SIZE must be an integer",
+		},
+		{
+			name: "every invalid value is reported",
+			env: map[string]string{
+				"SECTIONS_SERVER_ADDRESS": "localhost",
+				"SECTIONS_MONGO_URI":      "localhost:27017",
+				"SECTIONS_LOG_LEVEL":      "verbose",
+			},
+			expectedError: "invalid configuration: server.address must be host:port; mongo.uri must start with mongodb:// or mongodb+srv://; log.level must be one of debug, info, warn or error",
+		},
+		{
+			name:          "negative trash retention",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_TRASH_RETENTION": "-1h"},
+			expectedError: "designPatterns.trashRetention can't be negative",
+		},
+		{
+			name:          "zero scheduler interval",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_SCHEDULER_INTERVAL": "0s"},
+			expectedError: "designPatterns.schedulerInterval must be positive",
+		},
+		{
+			name:          "negative render cache size",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_RENDER_CACHE_SIZE": "-1"},
+			expectedError: "designPatterns.renderCacheSize can't be negative",
+		},
+		{
+			name:          "negative cache size",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_CACHE_SIZE": "-1"},
+			expectedError: "designPatterns.cacheSize can't be negative",
+		},
+		{
+			name:          "zero cache ttl",
+			env:           map[string]string{"SECTIONS_DESIGN_PATTERNS_CACHE_TTL": "0s"},
+			expectedError: "designPatterns.cacheTTL must be positive",
+		},
+		{
+			name:          "default locale not listed",
+			env:           map[string]string{"SECTIONS_I18N_DEFAULT_LOCALE": "pt"},
+			expectedError: "i18n.defaultLocale must be one of i18n.locales",
+		},
+		{
+			name:          "invalid locale",
+			env:           map[string]string{"SECTIONS_I18N_LOCALES": "es, en_US"},
+			expectedError: `i18n.locales has an invalid locale "en_US"`,
+		},
+		{
+			name:          "invalid origin",
+			env:           map[string]string{"SECTIONS_CORS_ALLOWED_ORIGINS": "waydevs.com"},
+			expectedError: `cors.allowedOrigins has an invalid origin "waydevs.com"`,
+		},
+		{
+			name:          "non positive media max size",
+			env:           map[string]string{"SECTIONS_MEDIA_MAX_SIZE": "0"},
+			expectedError: "media.maxSize must be positive",
+		},
+		{
+			name:          "empty media dir",
+			env:           map[string]string{"SECTIONS_MEDIA_DIR": ""},
+			expectedError: "media.dir is required",
+		},
+		{
+			name:          "short hmac secret",
+			env:           map[string]string{"SECTIONS_AUTH_HMAC_SECRET": "secret"},
+			expectedError: "auth.hmacSecret must have at least 32 bytes",
+		},
+		{
+			name:          "authentication not configured",
+			expectedError: "auth.hmacSecret or auth.jwksFile is required, or auth.disabled to run without authentication",
+		},
+		{
+			name:          "authentication disabled and configured",
+			env:           map[string]string{"SECTIONS_AUTH_DISABLED": "true", "SECTIONS_AUTH_HMAC_SECRET": "a-secret-of-at-least-thirty-two-bytes"},
+			expectedError: "auth.disabled can't be set along with auth.hmacSecret or auth.jwksFile",
+		},
+		{
+			name:          "negative api key rotation grace",
+			env:           map[string]string{"SECTIONS_AUTH_API_KEY_ROTATION_GRACE": "-1h"},
+			expectedError: "auth.apiKeyRotationGrace can't be negative",
+		},
+		{
+			name:          "invalid trusted proxy",
+			env:           map[string]string{"SECTIONS_SERVER_TRUSTED_PROXIES": "10.0.0.0/8, proxy.local"},
+			expectedError: `server.trustedProxies has an invalid IP address or CIDR range "proxy.local"`,
+		},
+		{
+			name:          "negative rate limit",
+			env:           map[string]string{"SECTIONS_RATE_LIMIT_WRITE_REQUESTS": "-1"},
+			expectedError: "rateLimit requests can't be negative",
+		},
+		{
+			name:          "zero rate limit period",
+			env:           map[string]string{"SECTIONS_RATE_LIMIT_READ_PERIOD": "0s"},
+			expectedError: "rateLimit periods must be positive",
+		},
+		{
+			name:          "malformed roles",
+			env:           map[string]string{"SECTIONS_AUTH_ROLES": "editor"},
+			expectedError: "SECTIONS_AUTH_ROLES must be a list of role=permission,permission separated by ;",
+		},
+		{
+			name:          "unknown permission",
+			env:           map[string]string{"SECTIONS_AUTH_ROLES": "editor=create:patterns,fly:patterns"},
+			expectedError: `auth.roles: role editor has an unknown permission "fly:patterns"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load(tc.file)
+
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
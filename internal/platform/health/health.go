@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status is the status of a dependency or of the whole service.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// CheckFunc reports whether a dependency is usable. It must honor the context deadline.
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of checking a single dependency. Errors are reported as
+// unavailable, since the readiness probe is public; what went wrong is logged instead.
+type CheckResult struct {
+	Status    Status  `json:"status"`
+	LatencyMS float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of checking every registered dependency. Its status is down when
+// any dependency is down.
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs the readiness checks of the service dependencies.
+type Checker struct {
+	timeout  time.Duration
+	logger   *slog.Logger
+	draining atomic.Bool
+
+	mu     sync.RWMutex
+	checks map[string]CheckFunc
+}
+
+// errUnavailable is the error reported for a dependency whose check failed.
+const errUnavailable = "unavailable"
+
+// NewChecker creates a Checker that gives every check up to timeout to complete and logs
+// their errors to logger.
+func NewChecker(timeout time.Duration, logger *slog.Logger) *Checker {
+	return &Checker{
+		timeout: timeout,
+		logger:  logger,
+		checks:  map[string]CheckFunc{},
+	}
+}
+
+// Register adds a dependency check under the given name, replacing any previous one.
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = check
+}
+
+// Drain makes every following Check report the service as down, so it stops receiving
+// new traffic before shutting down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Check runs every registered check concurrently and reports their results. Once draining,
+// it reports the service as down without running the checks.
+func (c *Checker) Check(ctx context.Context) Report {
+	if c.draining.Load() {
+		return Report{
+			Status: StatusDown,
+			Checks: map[string]CheckResult{
+				"server": {Status: StatusDown, Error: "shutting down"},
+			},
+		}
+	}
+
+	c.mu.RLock()
+	checks := make(map[string]CheckFunc, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+
+			result := c.run(ctx, name, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status == StatusDown {
+				report.Status = StatusDown
+			}
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, name string, check CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	latency := time.Since(start)
+
+	result := CheckResult{
+		Status:    StatusUp,
+		LatencyMS: float64(latency.Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		c.logger.ErrorContext(ctx, "readiness check failed", "check", name, "error", err)
+		result.Status = StatusDown
+		result.Error = errUnavailable
+	}
+
+	return result
+}
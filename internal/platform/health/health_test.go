@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+func TestChecker_Check(t *testing.T) {
+	tt := []struct {
+		name           string
+		checks         map[string]CheckFunc
+		expectedStatus Status
+		expectedErrors map[string]string
+	}{
+		{
+			name:           "no checks",
+			checks:         map[string]CheckFunc{},
+			expectedStatus: StatusUp,
+			expectedErrors: map[string]string{},
+		},
+		{
+			name: "every check up",
+			checks: map[string]CheckFunc{
+				"mongo": func(ctx context.Context) error { return nil },
+			},
+			expectedStatus: StatusUp,
+			expectedErrors: map[string]string{"mongo": ""},
+		},
+		{
+			name: "one check down",
+			checks: map[string]CheckFunc{
+				"mongo": func(ctx context.Context) error { return errors.New("some-error") },
+				"cache": func(ctx context.Context) error { return nil },
+			},
+			expectedStatus: StatusDown,
+			expectedErrors: map[string]string{"mongo": "unavailable", "cache": ""},
+		},
+		{
+			name: "check times out",
+			checks: map[string]CheckFunc{
+				"mongo": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			expectedStatus: StatusDown,
+			expectedErrors: map[string]string{"mongo": "unavailable"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(10*time.Millisecond, logging.Discard())
+			for name, check := range tc.checks {
+				checker.Register(name, check)
+			}
+
+			report := checker.Check(context.Background())
+
+			require.Equal(t, tc.expectedStatus, report.Status)
+			require.Len(t, report.Checks, len(tc.expectedErrors))
+			for name, expectedError := range tc.expectedErrors {
+				require.Equal(t, expectedError, report.Checks[name].Error)
+			}
+		})
+	}
+}
+
+func TestChecker_Drain(t *testing.T) {
+	checker := NewChecker(time.Second, logging.Discard())
+	checker.Register("mongo", func(ctx context.Context) error { return nil })
+
+	require.Equal(t, StatusUp, checker.Check(context.Background()).Status)
+
+	checker.Drain()
+	report := checker.Check(context.Background())
+
+	require.Equal(t, StatusDown, report.Status)
+	require.Equal(t, map[string]CheckResult{"server": {Status: StatusDown, Error: "shutting down"}}, report.Checks)
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_AddsContextAttributes(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := New("info", &buffer)
+
+	ctx := WithRequestID(context.Background(), "some-request")
+	ctx = WithAttrs(ctx, slog.String("method", "GET"))
+	ctx = WithAttrs(ctx, slog.String("route", "/designpatters/:id"))
+
+	logger.InfoContext(ctx, "something happened", "error", "some-error")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	require.Equal(t, "something happened", record["msg"])
+	require.Equal(t, "some-request", record["request_id"])
+	require.Equal(t, "GET", record["method"])
+	require.Equal(t, "/designpatters/:id", record["route"])
+	require.Equal(t, "some-error", record["error"])
+}
+
+func TestNew_Level(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := New("warn", &buffer)
+
+	logger.Info("ignored")
+	require.Empty(t, buffer.String())
+
+	logger.Warn("logged")
+	require.Contains(t, buffer.String(), "logged")
+}
+
+func TestParseLevel(t *testing.T) {
+	require.Equal(t, slog.LevelDebug, ParseLevel("debug"))
+	require.Equal(t, slog.LevelWarn, ParseLevel("WARN"))
+	require.Equal(t, slog.LevelError, ParseLevel("error"))
+	require.Equal(t, slog.LevelInfo, ParseLevel("unknown"))
+}
+
+func TestRequestID(t *testing.T) {
+	require.Empty(t, RequestID(context.Background()))
+	require.Equal(t, "some-request", RequestID(WithRequestID(context.Background(), "some-request")))
+}
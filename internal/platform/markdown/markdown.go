@@ -0,0 +1,667 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Heading is a heading of a rendered document, as listed in its table of contents.
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// fallbackAnchor is the ID of headings without letters or digits.
+const fallbackAnchor = "section"
+
+// Anchors hands out heading IDs that are unique within a document, so a document rendered
+// from several sources doesn't repeat them.
+type Anchors struct {
+	used map[string]bool
+}
+
+// NewAnchors returns Anchors for a new document.
+func NewAnchors() *Anchors {
+	return &Anchors{used: map[string]bool{}}
+}
+
+// ID returns the ID of a heading with the given text: its letters and digits in lowercase
+// separated by single dashes, followed by the lowest number from 2 that makes it unique when
+// it is taken.
+func (a *Anchors) ID(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if unicode.IsSpace(r) || r == '-' || r == '_' {
+			dash = true
+		}
+	}
+
+	base := b.String()
+	if base == "" {
+		base = fallbackAnchor
+	}
+
+	id := base
+	for n := 2; a.used[id]; n++ {
+		id = base + "-" + strconv.Itoa(n)
+	}
+	a.used[id] = true
+
+	return id
+}
+
+// Render renders the markdown in source to HTML and returns it along with its headings, whose
+// IDs come from anchors. Raw HTML in source is escaped rather than rendered and links and
+// images are only kept when their URL is safe, so the result can be embedded in a page as is.
+func Render(source string, anchors *Anchors) (string, []Heading) {
+	r := &renderer{anchors: anchors}
+	lines := strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(source), "\n")
+	r.blocks(lines)
+
+	return strings.TrimSuffix(r.out.String(), "\n"), r.headings
+}
+
+// renderer renders the blocks of a document. Tight renderers render paragraphs without <p>
+// tags, as in the items of lists without blank lines.
+type renderer struct {
+	anchors  *Anchors
+	headings []Heading
+	tight    bool
+	out      strings.Builder
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	fencePattern    = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	listItemPattern = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])( +|$)`)
+	languagePattern = regexp.MustCompile(`^[a-zA-Z0-9_+#.-]{1,30}$`)
+)
+
+func (r *renderer) blocks(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case fencePattern.MatchString(line):
+			i = r.fence(lines, i)
+
+		case headingPattern.MatchString(line):
+			r.heading(line)
+			i++
+
+		case isThematicBreak(line):
+			r.out.WriteString("<hr>\n")
+			i++
+
+		case isBlockquote(line):
+			i = r.blockquote(lines, i)
+
+		case listItemPattern.MatchString(line):
+			i = r.list(lines, i)
+
+		default:
+			i = r.paragraph(lines, i)
+		}
+	}
+}
+
+func (r *renderer) fence(lines []string, start int) int {
+	match := fencePattern.FindStringSubmatch(lines[start])
+	indent, marker, info := len(match[1]), match[2], strings.Fields(match[3])
+
+	var code strings.Builder
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, marker) && strings.Trim(trimmed, marker[:1]) == "" {
+			i++
+			break
+		}
+		code.WriteString(trimIndent(lines[i], indent))
+		code.WriteByte('\n')
+	}
+
+	r.out.WriteString("<pre><code")
+	if len(info) > 0 && languagePattern.MatchString(info[0]) {
+		r.out.WriteString(` class="language-` + escape(info[0]) + `"`)
+	}
+	r.out.WriteString(">" + escape(code.String()) + "</code></pre>\n")
+
+	return i
+}
+
+func (r *renderer) heading(line string) {
+	match := headingPattern.FindStringSubmatch(line)
+	level := len(match[1])
+	content := inline(strings.TrimSpace(match[2]))
+	text := plainText(content)
+	id := r.anchors.ID(text)
+
+	r.headings = append(r.headings, Heading{Level: level, ID: id, Text: text})
+	r.out.WriteString("<h" + strconv.Itoa(level) + ` id="` + escape(id) + `">` + content + "</h" + strconv.Itoa(level) + ">\n")
+}
+
+func (r *renderer) blockquote(lines []string, start int) int {
+	var quoted []string
+	i := start
+	for ; i < len(lines) && isBlockquote(lines[i]); i++ {
+		line := strings.TrimLeft(lines[i], " ")[1:]
+		quoted = append(quoted, strings.TrimPrefix(line, " "))
+	}
+
+	r.out.WriteString("<blockquote>\n")
+	r.nested(quoted, false)
+	r.out.WriteString("</blockquote>\n")
+
+	return i
+}
+
+// list renders the list starting at lines[start]. An item goes on while its lines are
+// indented past its marker, or continue its paragraph. Lists with blank lines between their
+// items or inside them are loose, and their paragraphs are wrapped in <p> tags.
+func (r *renderer) list(lines []string, start int) int {
+	first := listItemPattern.FindStringSubmatch(lines[start])
+	ordered := !strings.ContainsAny(first[2], "-*+")
+	delimiter := first[2][len(first[2])-1]
+
+	sameList := func(line string) ([]string, bool) {
+		match := listItemPattern.FindStringSubmatch(line)
+		if match == nil || match[2][len(match[2])-1] != delimiter {
+			return nil, false
+		}
+		return match, ordered == !strings.ContainsAny(match[2], "-*+")
+	}
+
+	var items [][]string
+	loose := false
+	i := start
+	for i < len(lines) {
+		match, ok := sameList(lines[i])
+		if !ok {
+			break
+		}
+
+		width := len(match[0])
+		if match[3] == "" {
+			width++
+		}
+		item := []string{lines[i][len(match[0]):]}
+
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				next := nextNonBlank(lines, i)
+				if next == len(lines) || indentation(lines[next]) < width {
+					break
+				}
+				item = append(item, "")
+				loose = true
+				i = next - 1
+				continue
+			}
+
+			if indentation(line) >= width {
+				item = append(item, trimIndent(line, width))
+			} else if !startsBlock(line) {
+				item = append(item, strings.TrimSpace(line))
+			} else {
+				break
+			}
+		}
+		items = append(items, item)
+
+		if next := nextNonBlank(lines, i); next > i && next < len(lines) {
+			if _, ok := sameList(lines[next]); !ok {
+				break
+			}
+			loose = true
+			i = next
+		}
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	if n, _ := strconv.Atoi(strings.TrimRight(first[2], ".)")); ordered && n != 1 {
+		r.out.WriteString(`<ol start="` + strconv.Itoa(n) + `">` + "\n")
+	} else {
+		r.out.WriteString("<" + tag + ">\n")
+	}
+
+	for _, item := range items {
+		r.out.WriteString("<li>")
+		r.nested(item, !loose)
+		r.out.WriteString("</li>\n")
+	}
+	r.out.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+// nextNonBlank returns the index of the first line from start that is not blank, or
+// len(lines) when there is none.
+func nextNonBlank(lines []string, start int) int {
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+
+	return start
+}
+
+// nested renders lines inside the current block, sharing the anchors and headings of the
+// document.
+func (r *renderer) nested(lines []string, tight bool) {
+	inner := &renderer{anchors: r.anchors, tight: tight}
+	inner.blocks(lines)
+	r.headings = append(r.headings, inner.headings...)
+
+	content := inner.out.String()
+	if tight {
+		content = strings.TrimSuffix(content, "\n")
+	}
+	r.out.WriteString(content)
+}
+
+func (r *renderer) paragraph(lines []string, start int) int {
+	text := []string{strings.TrimLeft(lines[start], " \t")}
+	i := start + 1
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]); i++ {
+		text = append(text, strings.TrimLeft(lines[i], " \t"))
+	}
+
+	content := inline(strings.TrimRight(strings.Join(text, "\n"), " \t"))
+	if r.tight {
+		r.out.WriteString(content + "\n")
+	} else {
+		r.out.WriteString("<p>" + content + "</p>\n")
+	}
+
+	return i
+}
+
+// startsBlock reports whether line starts a block that interrupts a paragraph.
+func startsBlock(line string) bool {
+	return fencePattern.MatchString(line) || headingPattern.MatchString(line) || isThematicBreak(line) ||
+		isBlockquote(line) || listItemPattern.MatchString(line)
+}
+
+func isThematicBreak(line string) bool {
+	if indentation(line) > 3 {
+		return false
+	}
+
+	compact := strings.Join(strings.Fields(line), "")
+	return len(compact) >= 3 && strings.Trim(compact, compact[:1]) == "" && strings.ContainsAny(compact[:1], "-*_")
+}
+
+func isBlockquote(line string) bool {
+	return indentation(line) <= 3 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+// indentation returns how many columns of leading whitespace line has, with tabs as 4.
+func indentation(line string) int {
+	columns := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			columns++
+		case '\t':
+			columns += 4 - columns%4
+		default:
+			return columns
+		}
+	}
+
+	return columns
+}
+
+// trimIndent removes up to columns of leading whitespace from line.
+func trimIndent(line string, columns int) string {
+	removed := 0
+	for i, r := range line {
+		if removed >= columns || (r != ' ' && r != '\t') {
+			return line[i:]
+		}
+		if r == '\t' {
+			removed += 4 - removed%4
+		} else {
+			removed++
+		}
+	}
+
+	return ""
+}
+
+// inline renders the spans of text: code, emphasis, links, images and line breaks. Anything
+// else is escaped.
+func inline(text string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch c {
+		case '\\':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				b.WriteString("<br>\n")
+				i += 2
+				continue
+			}
+			if i+1 < len(text) && isPunctuation(text[i+1]) {
+				b.WriteString(escape(text[i+1 : i+2]))
+				i += 2
+				continue
+			}
+
+		case '`':
+			if rendered, end, ok := codeSpan(text, i); ok {
+				b.WriteString(rendered)
+				i = end
+				continue
+			}
+			run := delimiterRun(text, i)
+			b.WriteString(text[i : i+run])
+			i += run
+			continue
+
+		case '*', '_':
+			if rendered, end, ok := emphasis(text, i); ok {
+				b.WriteString(rendered)
+				i = end
+				continue
+			}
+			run := delimiterRun(text, i)
+			b.WriteString(text[i : i+run])
+			i += run
+			continue
+
+		case '!':
+			if i+1 < len(text) && text[i+1] == '[' {
+				if rendered, end, ok := link(text, i+1, true); ok {
+					b.WriteString(rendered)
+					i = end
+					continue
+				}
+			}
+
+		case '[':
+			if rendered, end, ok := link(text, i, false); ok {
+				b.WriteString(rendered)
+				i = end
+				continue
+			}
+
+		case '<':
+			if rendered, end, ok := autolink(text, i); ok {
+				b.WriteString(rendered)
+				i = end
+				continue
+			}
+
+		case ' ':
+			spaces := i
+			for spaces < len(text) && text[spaces] == ' ' {
+				spaces++
+			}
+			if spaces < len(text) && text[spaces] == '\n' {
+				if spaces-i >= 2 {
+					b.WriteString("<br>")
+				}
+			} else {
+				b.WriteString(text[i:spaces])
+			}
+			i = spaces
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		b.WriteString(escape(text[i : i+size]))
+		i += size
+	}
+
+	return b.String()
+}
+
+// codeSpan renders the code span opened by the backticks at text[start].
+func codeSpan(text string, start int) (string, int, bool) {
+	run := delimiterRun(text, start)
+	for i := start + run; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		closing := delimiterRun(text, i)
+		if closing != run {
+			i += closing
+			continue
+		}
+
+		code := strings.ReplaceAll(text[start+run:i], "\n", " ")
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+		return "<code>" + escape(code) + "</code>", i + run, true
+	}
+
+	return "", 0, false
+}
+
+// emphasis renders the emphasis opened by the delimiter run at text[start]: em for one
+// delimiter, strong for two and both for three.
+func emphasis(text string, start int) (string, int, bool) {
+	delimiter := text[start]
+	run := delimiterRun(text, start)
+	if run > 3 || start+run >= len(text) || isSpace(text[start+run]) {
+		return "", 0, false
+	}
+	if delimiter == '_' && start > 0 && isWordByte(text[start-1]) {
+		return "", 0, false
+	}
+
+	for i := start + run; i < len(text); {
+		switch {
+		case text[i] == '\\':
+			i += 2
+			continue
+		case text[i] == '`':
+			if _, end, ok := codeSpan(text, i); ok {
+				i = end
+				continue
+			}
+		case text[i] == delimiter:
+			closing := delimiterRun(text, i)
+			if closing == run && !isSpace(text[i-1]) && (delimiter != '_' || i+closing >= len(text) || !isWordByte(text[i+closing])) {
+				content := inline(text[start+run : i])
+				switch run {
+				case 1:
+					content = "<em>" + content + "</em>"
+				case 2:
+					content = "<strong>" + content + "</strong>"
+				default:
+					content = "<em><strong>" + content + "</strong></em>"
+				}
+				return content, i + closing, true
+			}
+			i += closing
+			continue
+		}
+		i++
+	}
+
+	return "", 0, false
+}
+
+// link renders the link, or the image when image is true, whose label is opened by the
+// bracket at text[start]. Links with unsafe URLs are rendered as their label and images with
+// unsafe URLs are not rendered at all.
+func link(text string, start int, image bool) (string, int, bool) {
+	depth := 0
+	labelEnd := -1
+	for i := start; i < len(text) && labelEnd < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				labelEnd = i
+			}
+		}
+	}
+	if labelEnd < 0 || labelEnd+1 >= len(text) || text[labelEnd+1] != '(' {
+		return "", 0, false
+	}
+
+	closing := strings.IndexByte(text[labelEnd:], ')')
+	if closing < 0 {
+		return "", 0, false
+	}
+	end := labelEnd + closing + 1
+
+	destination, title := parseDestination(text[labelEnd+2 : end-1])
+	label := text[start+1 : labelEnd]
+	safe, ok := safeURL(destination, image)
+
+	if image {
+		if !ok {
+			return "", end, true
+		}
+		rendered := `<img src="` + escape(safe) + `" alt="` + escape(plainText(inline(label))) + `"`
+		if title != "" {
+			rendered += ` title="` + escape(title) + `"`
+		}
+		return rendered + ">", end, true
+	}
+
+	content := inline(label)
+	if !ok {
+		return content, end, true
+	}
+
+	rendered := `<a href="` + escape(safe) + `"`
+	if title != "" {
+		rendered += ` title="` + escape(title) + `"`
+	}
+	return rendered + ` rel="nofollow noopener">` + content + "</a>", end, true
+}
+
+// parseDestination splits the inside of the parentheses of a link into its URL and its
+// optional quoted title.
+func parseDestination(value string) (string, string) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "<") {
+		if end := strings.IndexByte(value, '>'); end > 0 {
+			return value[1:end], unquote(strings.TrimSpace(value[end+1:]))
+		}
+	}
+
+	destination, title, _ := strings.Cut(value, " ")
+	return destination, unquote(strings.TrimSpace(title))
+}
+
+func unquote(title string) string {
+	if len(title) >= 2 && (title[0] == '"' || title[0] == '\'') && title[len(title)-1] == title[0] {
+		return title[1 : len(title)-1]
+	}
+
+	return ""
+}
+
+// autolink renders the URL between the angle brackets opened at text[start].
+func autolink(text string, start int) (string, int, bool) {
+	end := strings.IndexAny(text[start+1:], "<> \n")
+	if end < 0 || text[start+1+end] != '>' {
+		return "", 0, false
+	}
+
+	raw := text[start+1 : start+1+end]
+	safe, ok := safeURL(raw, false)
+	if !ok || !strings.Contains(raw, ":") {
+		return "", 0, false
+	}
+
+	return `<a href="` + escape(safe) + `" rel="nofollow noopener">` + escape(raw) + "</a>", start + end + 2, true
+}
+
+// safeURL returns raw when it is safe to link to: a relative URL or an absolute http, https
+// or mailto one. Images can only be absolute http or https URLs.
+func safeURL(raw string, image bool) (string, bool) {
+	parsed, err := url.Parse(raw)
+	if err != nil || raw == "" {
+		return "", false
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		return raw, parsed.Host != ""
+	case "mailto":
+		return raw, !image
+	case "":
+		return raw, !image && parsed.Host == ""
+	}
+
+	return "", false
+}
+
+// plainText returns the text of rendered HTML, without tags and unescaped.
+func plainText(rendered string) string {
+	var b strings.Builder
+	inTag := false
+	for _, r := range rendered {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+		case !inTag:
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSpace(html.UnescapeString(b.String()))
+}
+
+func escape(text string) string {
+	return html.EscapeString(text)
+}
+
+func delimiterRun(text string, start int) int {
+	end := start
+	for end < len(text) && text[end] == text[start] {
+		end++
+	}
+
+	return end - start
+}
+
+func isPunctuation(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
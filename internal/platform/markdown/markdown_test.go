@@ -0,0 +1,194 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	tt := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "paragraphs",
+			source:   "Garantiza una\núnica instancia.  \nSiempre.\n\nOtro párrafo",
+			expected: "<p>Garantiza una\núnica instancia.<br>\nSiempre.</p>\n<p>Otro párrafo</p>",
+		},
+		{
+			name:     "emphasis and code",
+			source:   "Usá *sync.Once*, **no** `init()` ni ***globales*** o snake_case_names",
+			expected: "<p>Usá <em>sync.Once</em>, <strong>no</strong> <code>init()</code> ni <em><strong>globales</strong></em> o snake_case_names</p>",
+		},
+		{
+			name:     "nested emphasis",
+			source:   "*una **única** instancia*",
+			expected: "<p><em>una <strong>única</strong> instancia</em></p>",
+		},
+		{
+			name:     "headings",
+			source:   "# Uso\n## Ejemplo en *Go* ##\n#hashtag",
+			expected: "<h1 id=\"uso\">Uso</h1>\n<h2 id=\"ejemplo-en-go\">Ejemplo en <em>Go</em></h2>\n<p>#hashtag</p>",
+		},
+		{
+			name:     "fenced code",
+			source:   "```go\nif a < b {\n\treturn\n}\n```",
+			expected: "<pre><code class=\"language-go\">if a &lt; b {\n\treturn\n}\n</code></pre>",
+		},
+		{
+			name:     "fenced code with unsafe language",
+			source:   "~~~\"><script>\nx\n~~~",
+			expected: "<pre><code>x\n</code></pre>",
+		},
+		{
+			name:     "tight list",
+			source:   "- Uno\n- Dos\n  sigue\n  - Anidado",
+			expected: "<ul>\n<li>Uno</li>\n<li>Dos\nsigue\n<ul>\n<li>Anidado</li>\n</ul></li>\n</ul>",
+		},
+		{
+			name:     "loose ordered list",
+			source:   "3. Uno\n\n4. Dos",
+			expected: "<ol start=\"3\">\n<li><p>Uno</p>\n</li>\n<li><p>Dos</p>\n</li>\n</ol>",
+		},
+		{
+			name:     "blockquote and rule",
+			source:   "> Cita\n> **fuerte**\n\n---",
+			expected: "<blockquote>\n<p>Cita\n<strong>fuerte</strong></p>\n</blockquote>\n<hr>",
+		},
+		{
+			name:     "links and images",
+			source:   "[Go](https://go.dev \"Go\") [arriba](#uso) <https://waydevs.com> ![diagrama *UML*](https://waydevs.com/a.png)",
+			expected: "<p><a href=\"https://go.dev\" title=\"Go\" rel=\"nofollow noopener\">Go</a> <a href=\"#uso\" rel=\"nofollow noopener\">arriba</a> <a href=\"https://waydevs.com\" rel=\"nofollow noopener\">https://waydevs.com</a> <img src=\"https://waydevs.com/a.png\" alt=\"diagrama UML\"></p>",
+		},
+		{
+			name:     "raw html is escaped",
+			source:   "<script>alert(1)</script> <img src=x onerror=alert(1)> & \"quotes\"",
+			expected: "<p>&lt;script&gt;alert(1)&lt;/script&gt; &lt;img src=x onerror=alert(1)&gt; &amp; &#34;quotes&#34;</p>",
+		},
+		{
+			name:     "unsafe urls are dropped",
+			source:   "[click](javascript:alert(1)) [data](data:text/html;base64,PHNjcmlwdD4=) ![x](javascript:alert(1)) <javascript:alert(1)> [ok](JavaScript:alert(1))",
+			expected: "<p>click) data ) &lt;javascript:alert(1)&gt; ok)</p>",
+		},
+		{
+			name:     "escaped attribute values",
+			source:   "[x](https://waydevs.com/\"onmouseover=\"alert(1))",
+			expected: "<p><a href=\"https://waydevs.com/&#34;onmouseover=&#34;alert(1\" rel=\"nofollow noopener\">x</a>)</p>",
+		},
+		{
+			name:     "backslash escapes",
+			source:   "\\*no\\* \\<b\\>",
+			expected: "<p>*no* &lt;b&gt;</p>",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, _ := Render(tc.source, NewAnchors())
+
+			require.Equal(t, tc.expected, rendered)
+		})
+	}
+}
+
+func TestRender_Headings(t *testing.T) {
+	anchors := NewAnchors()
+
+	_, first := Render("# Uso\n\n## Ejemplo\n\n## Ejemplo", anchors)
+	_, second := Render("## Uso\n\n### ¿?", anchors)
+
+	require.Equal(t, []Heading{
+		{Level: 1, ID: "uso", Text: "Uso"},
+		{Level: 2, ID: "ejemplo", Text: "Ejemplo"},
+		{Level: 2, ID: "ejemplo-2", Text: "Ejemplo"},
+	}, first)
+	require.Equal(t, []Heading{
+		{Level: 2, ID: "uso-2", Text: "Uso"},
+		{Level: 3, ID: "section", Text: "¿?"},
+	}, second)
+}
+
+func TestAnchors_ID(t *testing.T) {
+	tt := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "words", text: "Patrón Método Fábrica", expected: "patrón-método-fábrica"},
+		{name: "punctuation", text: "¿Cuándo usarlo? (y cuándo no)", expected: "cuándo-usarlo-y-cuándo-no"},
+		{name: "dashes and underscores", text: "thread_safe -- lazy", expected: "thread-safe-lazy"},
+		{name: "no letters", text: "!!!", expected: "section"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NewAnchors().ID(tc.text))
+		})
+	}
+}
+
+var (
+	tagPattern       = regexp.MustCompile(`<[^>]*>?`)
+	safeTagPattern   = regexp.MustCompile(`^</?[a-z][a-z0-9]*((?: [a-z-]+="[^"<>]*")*)>$`)
+	attributePattern = regexp.MustCompile(` ([a-z-]+)="([^"]*)"`)
+)
+
+// FuzzRender checks that no source renders to HTML that runs scripts: every tag is well
+// formed, none is a script, and there are no event handlers nor javascript: URLs.
+func FuzzRender(f *testing.F) {
+	for _, seed := range []string{
+		"# Uso\n\nGarantiza una *única* instancia con **sync.Once** y `init()`",
+		"```go\nif a < b {\n\treturn\n}\n```",
+		"~~~\"><script>\nx\n~~~",
+		"- Uno\n- Dos\n  - Anidado\n\n3. Tres\n\n> Cita\n\n---",
+		"<script>alert(1)</script> <img src=x onerror=alert(1)>",
+		"[click](javascript:alert(1)) ![x](JavaScript:alert(1)) <javascript:alert(1)>",
+		"[x](java\tscript:alert(1)) [y](&#106;avascript:alert(1)) [z]( javascript:alert(1))",
+		"[x](https://waydevs.com/\"onmouseover=\"alert(1)) [y](#a \"t\" onclick=\"x\")",
+		"![a *b* ](https://waydevs.com/a.png \"t\") <https://waydevs.com>",
+		"\\*no\\* \\<b\\> [\\]](x)",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, source string) {
+		rendered, _ := Render(source, NewAnchors())
+		lowered := strings.ToLower(rendered)
+
+		if strings.Contains(lowered, "<script") {
+			t.Fatalf("rendered a script tag: %q", rendered)
+		}
+
+		for _, tag := range tagPattern.FindAllString(lowered, -1) {
+			match := safeTagPattern.FindStringSubmatch(tag)
+			if match == nil {
+				t.Fatalf("rendered a malformed tag %q: %q", tag, rendered)
+			}
+
+			for _, attribute := range attributePattern.FindAllStringSubmatch(match[1], -1) {
+				name, value := attribute[1], html.UnescapeString(attribute[2])
+				if strings.HasPrefix(name, "on") {
+					t.Fatalf("rendered an event handler %q: %q", name, rendered)
+				}
+				if (name == "href" || name == "src") && unsafeURL(value) {
+					t.Fatalf("rendered an unsafe %s %q: %q", name, value, rendered)
+				}
+			}
+		}
+	})
+}
+
+// unsafeURL reports whether a browser would read value as a javascript: URL, which ignores
+// leading spaces and control characters and tabs or newlines anywhere.
+func unsafeURL(value string) bool {
+	value = strings.TrimLeft(value, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f"+
+		"\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f\x20")
+	value = strings.NewReplacer("\t", "", "\n", "", "\r", "").Replace(value)
+
+	return strings.HasPrefix(strings.ToLower(value), "javascript:")
+}
@@ -0,0 +1,48 @@
+// Package paging normalizes the pages and sorts lists are requested with.
+package paging
+
+import (
+	"strings"
+
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+const (
+	// DefaultLimit is the page size used when none is given.
+	DefaultLimit = 20
+
+	// MaxLimit is the largest page size allowed.
+	MaxLimit = 100
+)
+
+var sortFields = map[string]repository.SortField{
+	"title":     repository.SortByTitle,
+	"createdAt": repository.SortByCreation,
+}
+
+// Normalize defaults the page and limit when unset and caps the limit to MaxLimit.
+func Normalize(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	return page, limit
+}
+
+// Sort returns the field and direction of sort, title or createdAt optionally prefixed with
+// - for descending order. It returns false for other fields.
+func Sort(sort string) (repository.SortField, bool, bool) {
+	field, ok := sortFields[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return "", false, false
+	}
+
+	return field, strings.HasPrefix(sort, "-"), true
+}
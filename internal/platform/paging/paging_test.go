@@ -0,0 +1,55 @@
+package paging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+func TestNormalize(t *testing.T) {
+	tt := []struct {
+		name          string
+		page          int
+		limit         int
+		expectedPage  int
+		expectedLimit int
+	}{
+		{name: "defaults", page: 0, limit: 0, expectedPage: 1, expectedLimit: DefaultLimit},
+		{name: "given", page: 3, limit: 50, expectedPage: 3, expectedLimit: 50},
+		{name: "capped limit", page: 1, limit: 1000, expectedPage: 1, expectedLimit: MaxLimit},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			page, limit := Normalize(tc.page, tc.limit)
+
+			require.Equal(t, tc.expectedPage, page)
+			require.Equal(t, tc.expectedLimit, limit)
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	tt := []struct {
+		name               string
+		sort               string
+		expectedField      repository.SortField
+		expectedDescending bool
+		expectedOk         bool
+	}{
+		{name: "ascending", sort: "title", expectedField: repository.SortByTitle, expectedOk: true},
+		{name: "descending", sort: "-createdAt", expectedField: repository.SortByCreation, expectedDescending: true, expectedOk: true},
+		{name: "unknown", sort: "-votes"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			field, descending, ok := Sort(tc.sort)
+
+			require.Equal(t, tc.expectedField, field)
+			require.Equal(t, tc.expectedDescending, descending)
+			require.Equal(t, tc.expectedOk, ok)
+		})
+	}
+}
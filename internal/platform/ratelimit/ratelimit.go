@@ -0,0 +1,134 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limit is how many requests a client can make in a Period. Clients can make all of them at
+// once, and make more as the Period goes by.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Decision tells whether a request is allowed, along with the state of the bucket of its
+// client once the request is counted.
+type Decision struct {
+	Allowed bool
+	// Limit is the number of requests of the Limit.
+	Limit int
+	// Remaining is how many more requests the client can make right away.
+	Remaining int
+	// Reset is how long until the client can make Limit requests again.
+	Reset time.Duration
+	// RetryAfter is how long until the client can make another request, when it isn't
+	// Allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter limits the requests of each client with a token bucket: buckets hold up to as
+// many tokens as requests in the Limit, every request takes a token and tokens are added
+// back at a steady rate. Buckets of clients that are back to full are dropped, so memory is
+// only held by clients that were limited recently.
+type Limiter struct {
+	mu        sync.Mutex
+	limit     Limit
+	rate      float64 // tokens per second
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter creates a Limiter allowing limit to every client. The limit must have positive
+// Requests and Period.
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		rate:    float64(limit.Requests) / limit.Period.Seconds(),
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Allow counts a request of the client identified by key, unless it is over the limit.
+func (l *Limiter) Allow(key string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Requests), updated: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.rate, float64(l.limit.Requests))
+
+	decision := Decision{Allowed: b.tokens >= 1, Limit: l.limit.Requests}
+	if decision.Allowed {
+		b.tokens--
+	} else {
+		decision.RetryAfter = l.duration(1 - b.tokens)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.Reset = l.duration(float64(l.limit.Requests) - b.tokens)
+
+	return decision
+}
+
+// Peek returns the Decision Allow would make for a request of the client identified by key,
+// without counting it.
+func (l *Limiter) Peek(key string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		return Decision{Allowed: true, Limit: l.limit.Requests, Remaining: l.limit.Requests}
+	}
+
+	tokens := min(float64(l.limit.Requests), b.tokens+l.now().Sub(b.updated).Seconds()*l.rate)
+	decision := Decision{
+		Allowed:   tokens >= 1,
+		Limit:     l.limit.Requests,
+		Remaining: int(tokens),
+		Reset:     l.duration(float64(l.limit.Requests) - tokens),
+	}
+	if !decision.Allowed {
+		decision.RetryAfter = l.duration(1 - tokens)
+	}
+
+	return decision
+}
+
+// sweep drops the buckets that are full again, at most once per Period. A full bucket is
+// the same as a new one.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.limit.Period {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= float64(l.limit.Requests) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// duration returns how long refilling tokens takes.
+func (l *Limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+func (b *bucket) refill(now time.Time, rate, capacity float64) {
+	b.tokens = min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	start := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tt := []struct {
+		name     string
+		key      string
+		elapsed  time.Duration
+		expected Decision
+	}{
+		{
+			name:     "first request",
+			key:      "ip:10.0.0.1",
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 2, Reset: 20 * time.Second},
+		},
+		{
+			name:     "burst",
+			key:      "ip:10.0.0.1",
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 1, Reset: 40 * time.Second},
+		},
+		{
+			name:     "last token",
+			key:      "ip:10.0.0.1",
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 0, Reset: time.Minute},
+		},
+		{
+			name:     "over the limit",
+			key:      "ip:10.0.0.1",
+			elapsed:  5 * time.Second,
+			expected: Decision{Allowed: false, Limit: 3, Remaining: 0, Reset: 55 * time.Second, RetryAfter: 15 * time.Second},
+		},
+		{
+			name:     "other client",
+			key:      "principal:ada",
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 2, Reset: 20 * time.Second},
+		},
+		{
+			name:     "refilled",
+			key:      "ip:10.0.0.1",
+			elapsed:  15 * time.Second,
+			expected: Decision{Allowed: true, Limit: 3, Remaining: 0, Reset: time.Minute},
+		},
+	}
+
+	limiter := NewLimiter(Limit{Requests: 3, Period: time.Minute})
+	now := start
+	limiter.now = func() time.Time { return now }
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			now = now.Add(tc.elapsed)
+
+			require.Equal(t, tc.expected, limiter.Allow(tc.key))
+		})
+	}
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	limiter := NewLimiter(Limit{Requests: 2, Period: time.Minute})
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("ip:10.0.0.1")
+	now = now.Add(30 * time.Second)
+	limiter.Allow("ip:10.0.0.2")
+	limiter.Allow("ip:10.0.0.2")
+	require.Len(t, limiter.buckets, 2)
+
+	// The first bucket is full again, the second one is still refilling.
+	now = now.Add(time.Minute - time.Second)
+	limiter.Allow("ip:10.0.0.3")
+	require.Len(t, limiter.buckets, 2)
+	require.NotContains(t, limiter.buckets, "ip:10.0.0.1")
+}
+
+func TestLimiter_Peek(t *testing.T) {
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	limiter := NewLimiter(Limit{Requests: 2, Period: time.Minute})
+	limiter.now = func() time.Time { return now }
+
+	require.Equal(t, Decision{Allowed: true, Limit: 2, Remaining: 2}, limiter.Peek("ip:10.0.0.1"))
+	require.Empty(t, limiter.buckets)
+
+	limiter.Allow("ip:10.0.0.1")
+	limiter.Allow("ip:10.0.0.1")
+	now = now.Add(15 * time.Second)
+
+	// Peeking doesn't take a token, so the bucket is still empty after it.
+	expected := Decision{Allowed: false, Limit: 2, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 15 * time.Second}
+	require.Equal(t, expected, limiter.Peek("ip:10.0.0.1"))
+	require.Equal(t, expected, limiter.Allow("ip:10.0.0.1"))
+}
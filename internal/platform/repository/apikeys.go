@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	apiKeysCollectionName = "api_keys"
+	apiKeysHashIndexName  = "api_keys_hash"
+)
+
+// APIKeys is a repository for the APIKeys of machine clients.
+type APIKeys struct {
+	db     DatabaseHelper
+	logger *slog.Logger
+}
+
+// NewAPIKeys creates a new APIKeys repository.
+func NewAPIKeys(db DatabaseHelper, logger *slog.Logger) *APIKeys {
+	return &APIKeys{db: db, logger: logger}
+}
+
+func (a *APIKeys) collection() CollectionHelper {
+	return newLoggedCollection(a.db, apiKeysCollectionName, a.logger)
+}
+
+// EnsureIndexes creates the indexes the APIKeys queries rely on. It is idempotent, so it can
+// run on every startup.
+func (a *APIKeys) EnsureIndexes(ctx context.Context) error {
+	// Every request authenticated with a key looks it up by hash.
+	hashIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetName(apiKeysHashIndexName).SetUnique(true),
+	}
+
+	_, err := a.collection().CreateIndexes(ctx, []mongo.IndexModel{hashIndex})
+	return err
+}
+
+// List returns every APIKey, revoked and expired ones included, oldest first.
+func (a *APIKeys) List(ctx context.Context) ([]APIKey, error) {
+	cursor, err := a.collection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []APIKey{}
+	for cursor.Next(ctx) {
+		var key APIKey
+		if err := cursor.Decode(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, cursor.Err()
+}
+
+// GetByID returns an APIKey by its ID.
+func (a *APIKeys) GetByID(ctx context.Context, id string) (APIKey, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return APIKey{}, ErrInvalidID
+	}
+
+	return a.findOne(ctx, bson.M{"_id": primitiveID})
+}
+
+// GetByHash returns the APIKey whose secret has the given SHA-256 hash.
+func (a *APIKeys) GetByHash(ctx context.Context, hash string) (APIKey, error) {
+	return a.findOne(ctx, bson.M{"hash": hash})
+}
+
+func (a *APIKeys) findOne(ctx context.Context, filter bson.M) (APIKey, error) {
+	var key APIKey
+	err := a.collection().FindOne(ctx, filter).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return APIKey{}, ErrNotFound
+		}
+		return APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// Create stores a new APIKey.
+func (a *APIKeys) Create(ctx context.Context, key APIKey) (APIKey, error) {
+	result, err := a.collection().InsertOne(ctx, key)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	key.MongoID = result.(primitive.ObjectID)
+	return key, nil
+}
+
+// Revoke revokes an APIKey at the given time. Revoking it again keeps the first time.
+func (a *APIKeys) Revoke(ctx context.Context, id string, at time.Time) error {
+	return a.update(ctx, id, bson.M{"$min": bson.M{"revokedat": at}})
+}
+
+// Expire makes an APIKey expire at the given time, unless it expires earlier.
+func (a *APIKeys) Expire(ctx context.Context, id string, at time.Time) error {
+	return a.update(ctx, id, bson.M{"$min": bson.M{"expiresat": at}})
+}
+
+// Touch records that an APIKey was used at the given time, unless it was used later.
+func (a *APIKeys) Touch(ctx context.Context, id string, at time.Time) error {
+	return a.update(ctx, id, bson.M{"$max": bson.M{"lastusedat": at}})
+}
+
+func (a *APIKeys) update(ctx context.Context, id string, update bson.M) error {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrInvalidID
+	}
+
+	matched, err := a.collection().UpdateOne(ctx, bson.M{"_id": primitiveID}, update)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAPIKeys_EnsureIndexes(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - EnsureIndexes",
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - EnsureIndexes",
+			database:      &databaseHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := NewAPIKeys(tc.database, logging.Discard())
+
+			err := keys.EnsureIndexes(context.Background())
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestAPIKeys_List(t *testing.T) {
+	tt := []struct {
+		name           string
+		database       DatabaseHelper
+		expectedResult []APIKey
+		expectedError  error
+	}{
+		{
+			name:           "Ok - List",
+			database:       &databaseHelperMock{},
+			expectedResult: []APIKey{{Name: "Some Design Pattern"}, {Name: "Another Design Pattern"}},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - List",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := NewAPIKeys(tc.database, logging.Discard())
+
+			result, err := keys.List(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestAPIKeys_GetByID(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		database       DatabaseHelper
+		expectedResult APIKey
+		expectedError  error
+	}{
+		{
+			name:           "Ok - GetByID",
+			id:             someId,
+			database:       &databaseHelperMock{},
+			expectedResult: APIKey{Name: "Some Design Pattern"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Not found",
+			id:             missingId,
+			database:       &databaseHelperMock{},
+			expectedResult: APIKey{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: APIKey{},
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - GetByID",
+			id:             someId,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: APIKey{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := NewAPIKeys(tc.database, logging.Discard())
+
+			result, err := keys.GetByID(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestAPIKeys_GetByHash(t *testing.T) {
+	tt := []struct {
+		name           string
+		hash           string
+		database       DatabaseHelper
+		expectedResult APIKey
+		expectedError  error
+	}{
+		{
+			name:           "Ok - GetByHash",
+			hash:           "some-hash",
+			database:       &databaseHelperMock{},
+			expectedResult: APIKey{Name: "Some Design Pattern"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Not found",
+			hash:           missingHash,
+			database:       &databaseHelperMock{},
+			expectedResult: APIKey{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - GetByHash",
+			hash:           "some-hash",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: APIKey{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := NewAPIKeys(tc.database, logging.Discard())
+
+			result, err := keys.GetByHash(context.Background(), tc.hash)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestAPIKeys_Create(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+
+	tt := []struct {
+		name           string
+		key            APIKey
+		database       DatabaseHelper
+		expectedResult APIKey
+		expectedError  error
+	}{
+		{
+			name:           "Ok - Create",
+			key:            APIKey{Name: "ci", Hash: "some-hash", Scopes: []string{"read:drafts"}},
+			database:       &databaseHelperMock{},
+			expectedResult: APIKey{MongoID: id, Name: "ci", Hash: "some-hash", Scopes: []string{"read:drafts"}},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Create",
+			key:            APIKey{Name: "ci", Hash: "some-hash", Scopes: []string{"read:drafts"}},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: APIKey{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := NewAPIKeys(tc.database, logging.Discard())
+
+			result, err := keys.Create(context.Background(), tc.key)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestAPIKeys_Updates(t *testing.T) {
+	keys := func(db DatabaseHelper) *APIKeys {
+		return NewAPIKeys(db, logging.Discard())
+	}
+	at := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tt := []struct {
+		name          string
+		update        func(ctx context.Context, id string) error
+		id            string
+		expectedError error
+	}{
+		{
+			name:          "Ok - Revoke",
+			update:        func(ctx context.Context, id string) error { return keys(&databaseHelperMock{}).Revoke(ctx, id, at) },
+			id:            someId,
+			expectedError: nil,
+		},
+		{
+			name:          "Ok - Expire",
+			update:        func(ctx context.Context, id string) error { return keys(&databaseHelperMock{}).Expire(ctx, id, at) },
+			id:            someId,
+			expectedError: nil,
+		},
+		{
+			name:          "Ok - Touch",
+			update:        func(ctx context.Context, id string) error { return keys(&databaseHelperMock{}).Touch(ctx, id, at) },
+			id:            someId,
+			expectedError: nil,
+		},
+		{
+			name:          "Error - Not found",
+			update:        func(ctx context.Context, id string) error { return keys(&databaseHelperMock{}).Revoke(ctx, id, at) },
+			id:            missingId,
+			expectedError: ErrNotFound,
+		},
+		{
+			name:          "Error - Erroneous ID",
+			update:        func(ctx context.Context, id string) error { return keys(&databaseHelperMock{}).Expire(ctx, id, at) },
+			id:            "aaaa",
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "Error - Touch",
+			update:        func(ctx context.Context, id string) error { return keys(&databaseHelperErrorMock{}).Touch(ctx, id, at) },
+			id:            someId,
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.update(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Blocks is a list of Content blocks. Blocks stored before typed blocks are upgraded when
+// they are decoded, so they can be read before MigrateBlocks rewrites them.
+type Blocks []Content
+
+// legacyBlocksFilter matches the documents with blocks stored before typed blocks, which
+// have no type.
+var legacyBlocksFilter = bson.M{"contentdata": bson.M{"$elemMatch": bson.M{"type": bson.M{"$exists": false}}}}
+
+// storedBlock is a block as it may be stored: a typed block, or an untyped one with the
+// fields blocks had before typed blocks.
+type storedBlock struct {
+	Content     `bson:",inline"`
+	Description string
+	Image       []string
+}
+
+// UnmarshalBSONValue decodes blocks, upgrading the ones stored before typed blocks.
+func (b *Blocks) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.Null || t == bsontype.Undefined {
+		*b = nil
+		return nil
+	}
+
+	var stored []storedBlock
+	if err := (bson.RawValue{Type: t, Value: data}).Unmarshal(&stored); err != nil {
+		return err
+	}
+
+	blocks := make(Blocks, 0, len(stored))
+	for _, block := range stored {
+		if block.Type != "" {
+			blocks = append(blocks, block.Content)
+			continue
+		}
+		blocks = append(blocks, upgradeBlock(block.Title, block.Description, block.Image)...)
+	}
+	*b = blocks
+
+	return nil
+}
+
+// upgradeBlock turns a block stored before typed blocks into a markdown block with its title
+// and description, followed by an image block for each of its images. Blocks with images and
+// no description become image blocks only, the first one with the title. Markdown blocks need
+// text, so blocks with only a title keep it as their text, and empty blocks are dropped.
+func upgradeBlock(title, description string, images []string) Blocks {
+	blocks := Blocks{}
+	switch {
+	case description != "":
+		blocks = append(blocks, Content{Type: BlockMarkdown, Title: title, Text: description})
+		title = ""
+	case len(images) == 0 && title != "":
+		blocks = append(blocks, Content{Type: BlockMarkdown, Text: title})
+		title = ""
+	}
+
+	for _, image := range images {
+		blocks = append(blocks, Content{Type: BlockImage, Title: title, URL: image})
+		title = ""
+	}
+
+	return blocks
+}
+
+// migrateBlocks rewrites the blocks of every document in collection stored before typed
+// blocks, using set to build the $set operand from each decoded document, and returns how
+// many documents were migrated.
+func migrateBlocks[T any](ctx context.Context, collection CollectionHelper, id func(T) primitive.ObjectID, set func(T) bson.M) (int64, error) {
+	cursor, err := collection.Find(ctx, legacyBlocksFilter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var migrated int64
+	for cursor.Next(ctx) {
+		var document T
+		if err := cursor.Decode(&document); err != nil {
+			return migrated, err
+		}
+
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": id(document)}, bson.M{"$set": set(document)}); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, cursor.Err()
+}
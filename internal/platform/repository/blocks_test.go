@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBlocks_UnmarshalBSONValue(t *testing.T) {
+	tt := []struct {
+		name     string
+		stored   bson.M
+		expected Blocks
+	}{
+		{
+			name: "Ok - typed blocks",
+			stored: bson.M{"contentdata": bson.A{
+				bson.M{"type": "markdown", "title": "Uso", "text": "Una instancia"},
+				bson.M{"type": "code", "language": "go", "code": "var once sync.Once"},
+			}},
+			expected: Blocks{
+				{Type: BlockMarkdown, Title: "Uso", Text: "Una instancia"},
+				{Type: BlockCode, Language: "go", Code: "var once sync.Once"},
+			},
+		},
+		{
+			name: "Ok - legacy blocks",
+			stored: bson.M{"contentdata": bson.A{
+				bson.M{"title": "Uso", "description": "Una instancia", "image": bson.A{"https://waydevs.com/a.png", "https://waydevs.com/b.png"}},
+				bson.M{"title": "Diagrama", "description": "", "image": bson.A{"https://waydevs.com/c.png"}},
+				bson.M{"title": "Ejemplo", "description": "", "image": nil},
+				bson.M{"title": "", "description": "", "image": bson.A{}},
+				bson.M{"type": "callout", "severity": "tip", "text": "Ya migrado"},
+			}},
+			expected: Blocks{
+				{Type: BlockMarkdown, Title: "Uso", Text: "Una instancia"},
+				{Type: BlockImage, URL: "https://waydevs.com/a.png"},
+				{Type: BlockImage, URL: "https://waydevs.com/b.png"},
+				{Type: BlockImage, Title: "Diagrama", URL: "https://waydevs.com/c.png"},
+				{Type: BlockMarkdown, Text: "Ejemplo"},
+				{Type: BlockCallout, Severity: "tip", Text: "Ya migrado"},
+			},
+		},
+		{
+			name:     "Ok - no blocks",
+			stored:   bson.M{"contentdata": nil},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := bson.Marshal(tc.stored)
+			assert.NoError(t, err)
+
+			var designPattern DesignPattern
+			err = bson.Unmarshal(data, &designPattern)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, designPattern.ContentData)
+		})
+	}
+}
+
+func TestBlocks_RoundTrip(t *testing.T) {
+	blocks := Blocks{
+		{Type: BlockImage, Title: "Diagrama", URL: "https://waydevs.com/a.png", Alt: "Clases", Caption: "Singleton"},
+		{Type: BlockDiagram, Format: "mermaid", Source: "classDiagram"},
+	}
+
+	data, err := bson.Marshal(DesignPattern{ContentData: blocks})
+	assert.NoError(t, err)
+
+	var stored bson.M
+	assert.NoError(t, bson.Unmarshal(data, &stored))
+	assert.Equal(t, "image", stored["contentdata"].(bson.A)[0].(bson.M)["type"])
+
+	var designPattern DesignPattern
+	assert.NoError(t, bson.Unmarshal(data, &designPattern))
+	assert.Equal(t, blocks, designPattern.ContentData)
+}
+
+func TestDesignPatterns_MigrateBlocks(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Ok - MigrateBlocks",
+			database:      &databaseHelperMock{},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name:          "Error - MigrateBlocks",
+			database:      &databaseHelperErrorMock{},
+			expectedCount: 0,
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			count, err := designPatterns.MigrateBlocks(context.Background())
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestSections_MigrateBlocks(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Ok - MigrateBlocks",
+			database:      &databaseHelperMock{},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name:          "Error - MigrateBlocks",
+			database:      &databaseHelperErrorMock{},
+			expectedCount: 0,
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sections := NewSections(tc.database, "sections", logging.Discard())
+
+			count, err := sections.MigrateBlocks(context.Background())
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -2,33 +2,57 @@ package repository
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
-	designPatternsCollectionName = "design_patterns"
+	designPatternsCollectionName    = "design_patterns"
+	designPatternsTextIndexName     = "design_patterns_text_blocks"
+	designPatternsDeletedIndexName  = "design_patterns_deleted"
+	designPatternsStatusIndexName   = "design_patterns_status"
+	designPatternsSlugIndexName     = "design_patterns_slug"
+	designPatternsAliasIndexName    = "design_patterns_slug_aliases"
+	designPatternsTagsIndexName     = "design_patterns_tags"
+	designPatternsCategoryIndexName = "design_patterns_category"
 )
 
+// legacyTextIndexName is the text index over the content blocks stored before they were typed.
+// A collection can only have one text index, so it is dropped before creating the current one.
+const legacyTextIndexName = "design_patterns_text"
+
 // DesignPatterns is a repository for DesignPattern.
 type DesignPatterns struct {
-	db DatabaseHelper
+	db     DatabaseHelper
+	logger *slog.Logger
 }
 
 // NewDesignPatterns creates a new DesignPatterns repository.
-func NewDesignPatterns(db DatabaseHelper) *DesignPatterns {
-	return &DesignPatterns{db: db}
+func NewDesignPatterns(db DatabaseHelper, logger *slog.Logger) *DesignPatterns {
+	return &DesignPatterns{db: db, logger: logger}
+}
+
+func (s *DesignPatterns) collection() CollectionHelper {
+	return newLoggedCollection(s.db, designPatternsCollectionName, s.logger)
 }
 
-// GetByID returns a DesignPattern by its ID.
+// GetByID returns a DesignPattern by its ID. DesignPatterns in the trash are not found.
 func (s *DesignPatterns) GetByID(ctx context.Context, id string) (DesignPattern, error) {
 	primitiveID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return DesignPattern{}, err
+		return DesignPattern{}, ErrInvalidID
 	}
 
-	result := s.db.Collection(designPatternsCollectionName).FindOne(ctx, map[string]primitive.ObjectID{"_id": primitiveID})
+	result := s.collection().FindOne(ctx, notDeleted(bson.M{"_id": primitiveID}))
 
 	var designPattern DesignPattern
 	err = result.Decode(&designPattern)
@@ -42,9 +66,261 @@ func (s *DesignPatterns) GetByID(ctx context.Context, id string) (DesignPattern,
 	return designPattern, nil
 }
 
-// Create creates a new DesignPattern.
+// GetBySlug returns the DesignPattern whose current slug or one of its previous slugs is
+// slug. DesignPatterns in the trash are not found.
+func (s *DesignPatterns) GetBySlug(ctx context.Context, slug string) (DesignPattern, error) {
+	filter := notDeleted(bson.M{"$or": bson.A{bson.M{"slug": slug}, bson.M{"slugaliases": slug}}})
+
+	var designPattern DesignPattern
+	err := s.collection().FindOne(ctx, filter).Decode(&designPattern)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return DesignPattern{}, ErrNotFound
+		}
+		return DesignPattern{}, err
+	}
+
+	return designPattern, nil
+}
+
+// TakenSlugs returns the slugs and previous slugs that are base or base followed by a dash
+// and a number, including the ones of DesignPatterns in the trash, as slugs are unique
+// across all of them.
+func (s *DesignPatterns) TakenSlugs(ctx context.Context, base string) ([]string, error) {
+	pattern := "^" + regexp.QuoteMeta(base) + "(-[0-9]+)?$"
+	filter := bson.M{"$or": bson.A{
+		bson.M{"slug": bson.M{"$regex": pattern}},
+		bson.M{"slugaliases": bson.M{"$regex": pattern}},
+	}}
+
+	cursor, err := s.collection().Find(ctx, filter, options.Find().SetProjection(bson.M{"slug": 1, "slugaliases": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	matcher := regexp.MustCompile(pattern)
+	taken := []string{}
+	for cursor.Next(ctx) {
+		var designPattern DesignPattern
+		if err := cursor.Decode(&designPattern); err != nil {
+			return nil, err
+		}
+		for _, slug := range append([]string{designPattern.Slug}, designPattern.SlugAliases...) {
+			if matcher.MatchString(slug) {
+				taken = append(taken, slug)
+			}
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return taken, nil
+}
+
+// List returns the DesignPatterns matching the given options, along with the total number
+// of matches ignoring pagination. DesignPatterns in the trash are left out.
+func (s *DesignPatterns) List(ctx context.Context, opts ListOptions) ([]DesignPattern, int64, error) {
+	return s.find(ctx, notDeleted(listFilter(opts.Filter)), listFindOptions(opts))
+}
+
+// ListDeleted returns the DesignPatterns in the trash, most recently deleted first, along
+// with the total number of them ignoring pagination.
+func (s *DesignPatterns) ListDeleted(ctx context.Context, skip, limit int64) ([]DesignPattern, int64, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "deletedat", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	return s.find(ctx, deleted(bson.M{}), findOptions)
+}
+
+// TagCounts returns the tags of the DesignPatterns matching filter along with how many of
+// them are tagged with each, most used first. DesignPatterns in the trash are left out.
+func (s *DesignPatterns) TagCounts(ctx context.Context, filter ListFilter) ([]TagCount, error) {
+	pipeline := bson.A{
+		bson.M{"$match": notDeleted(listFilter(filter))},
+		bson.M{"$unwind": "$tags"},
+		bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+		bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+
+	cursor, err := s.collection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := []TagCount{}
+	for cursor.Next(ctx) {
+		var count TagCount
+		if err := cursor.Decode(&count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// find returns a page of the DesignPatterns matching filter, along with the total number of
+// matches.
+func (s *DesignPatterns) find(ctx context.Context, filter bson.M, findOptions *options.FindOptions) ([]DesignPattern, int64, error) {
+	collection := s.collection()
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	designPatterns := []DesignPattern{}
+	for cursor.Next(ctx) {
+		var designPattern DesignPattern
+		if err := cursor.Decode(&designPattern); err != nil {
+			return nil, 0, err
+		}
+		designPatterns = append(designPatterns, designPattern)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return designPatterns, total, nil
+}
+
+// Search returns the DesignPatterns matching a full-text query sorted by relevance, along
+// with the total number of matches ignoring pagination. It relies on the text index created
+// by EnsureIndexes. Only published DesignPatterns out of the trash are searched.
+func (s *DesignPatterns) Search(ctx context.Context, query string, skip, limit int64) ([]SearchResult, int64, error) {
+	collection := s.collection()
+	filter := published(notDeleted(bson.M{"$text": bson.M{"$search": query}}))
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []SearchResult{}
+	for cursor.Next(ctx) {
+		var result SearchResult
+		if err := cursor.Decode(&result); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, result)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// EnsureIndexes creates the indexes the DesignPatterns queries rely on. It is idempotent,
+// so it can run on every startup.
+func (s *DesignPatterns) EnsureIndexes(ctx context.Context) error {
+	textIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "subtitle", Value: "text"},
+			{Key: "contentdata.title", Value: "text"},
+			{Key: "contentdata.text", Value: "text"},
+			{Key: "contentdata.caption", Value: "text"},
+		},
+		Options: options.Index().
+			SetName(designPatternsTextIndexName).
+			SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "subtitle", Value: 5},
+				{Key: "contentdata.title", Value: 3},
+				{Key: "contentdata.text", Value: 1},
+				{Key: "contentdata.caption", Value: 1},
+			}).
+			// Content is written in both Spanish and English, so language specific
+			// stemming and stop words would hurt one of them.
+			SetDefaultLanguage("none"),
+	}
+
+	// Listing the trash and purging it look DesignPatterns up by deletion time.
+	deletedIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "deletedat", Value: 1}},
+		Options: options.Index().SetName(designPatternsDeletedIndexName),
+	}
+
+	// Public reads filter by status and the scheduler looks up DesignPatterns in review by
+	// publication time.
+	statusIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "publishat", Value: 1}},
+		Options: options.Index().SetName(designPatternsStatusIndexName),
+	}
+
+	// Slugs identify DesignPatterns in URLs. DesignPatterns stored before slugs don't have
+	// one, so they are left out of the unique indexes.
+	slugIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().
+			SetName(designPatternsSlugIndexName).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"slug": bson.M{"$type": "string"}}),
+	}
+	aliasIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "slugaliases", Value: 1}},
+		Options: options.Index().
+			SetName(designPatternsAliasIndexName).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"slugaliases": bson.M{"$type": "string"}}),
+	}
+
+	// Browsing filters DesignPatterns by category and tags, and counts the tags in use.
+	tagsIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "tags", Value: 1}},
+		Options: options.Index().SetName(designPatternsTagsIndexName),
+	}
+	categoryIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "category", Value: 1}},
+		Options: options.Index().SetName(designPatternsCategoryIndexName),
+	}
+
+	collection := s.collection()
+	if err := collection.DropIndex(ctx, legacyTextIndexName); err != nil {
+		return err
+	}
+
+	_, err := collection.CreateIndexes(ctx, []mongo.IndexModel{textIndex, deletedIndex, statusIndex, slugIndex, aliasIndex, tagsIndex, categoryIndex})
+	return err
+}
+
+// Create creates a new DesignPattern with version 1.
 func (s *DesignPatterns) Create(ctx context.Context, designPattern DesignPattern) (DesignPattern, error) {
-	result, err := s.db.Collection(designPatternsCollectionName).InsertOne(ctx, designPattern)
+	designPattern.Version = 1
+
+	result, err := s.collection().InsertOne(ctx, designPattern)
 	if err != nil {
 		return DesignPattern{}, err
 	}
@@ -53,23 +329,369 @@ func (s *DesignPatterns) Create(ctx context.Context, designPattern DesignPattern
 	return designPattern, nil
 }
 
-// Delete deletes a DesignPattern by its ID.
-func (d *DesignPatterns) Delete(ctx context.Context, id string) error {
+// Delete moves a DesignPattern to the trash by its ID and increments its version. When
+// version is not zero, the DesignPattern is only deleted if it is still at that version. It
+// returns ErrNotFound when there is no DesignPattern with that ID out of the trash and
+// ErrVersionConflict when it is at another version.
+func (d *DesignPatterns) Delete(ctx context.Context, id string, version int64) error {
 	primitiveID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return err
+		return ErrInvalidID
 	}
 
-	_, err = d.db.Collection(designPatternsCollectionName).DeleteOne(ctx, map[string]primitive.ObjectID{"_id": primitiveID})
+	_, err = d.updateVersion(ctx, primitiveID, version, bson.M{"deletedat": time.Now().UTC()})
 	return err
 }
 
-// Update updates a DesignPattern.
+// Restore takes a DesignPattern out of the trash by its ID and increments its version. It
+// returns the restored DesignPattern, or ErrNotFound when there is no DesignPattern with
+// that ID in the trash.
+func (d *DesignPatterns) Restore(ctx context.Context, id string) (DesignPattern, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return DesignPattern{}, ErrInvalidID
+	}
+
+	update := bson.M{
+		"$set": bson.M{"deletedat": nil},
+		"$inc": bson.M{"version": 1},
+	}
+
+	var designPattern DesignPattern
+	err = d.collection().FindOneAndUpdate(ctx, deleted(bson.M{"_id": primitiveID}), update).Decode(&designPattern)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return DesignPattern{}, ErrNotFound
+		}
+		return DesignPattern{}, err
+	}
+
+	return designPattern, nil
+}
+
+// Purge permanently removes a DesignPattern in the trash by its ID. It returns ErrNotFound
+// when there is no DesignPattern with that ID in the trash, so a DesignPattern has to be
+// deleted before it can be purged.
+func (d *DesignPatterns) Purge(ctx context.Context, id string) error {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrInvalidID
+	}
+
+	purged, err := d.collection().DeleteOne(ctx, deleted(bson.M{"_id": primitiveID}))
+	if err != nil {
+		return err
+	}
+	if purged == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeletedBefore permanently removes the DesignPatterns moved to the trash before
+// cutoff and returns how many were removed.
+func (d *DesignPatterns) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return d.collection().DeleteMany(ctx, bson.M{"deletedat": bson.M{"$lt": cutoff}})
+}
+
+// Update updates a DesignPattern and increments its version. When designPattern.Version is
+// not zero, the DesignPattern is only updated if it is still at that version. It returns the
+// updated DesignPattern, ErrNotFound when there is no DesignPattern with its ID and
+// ErrVersionConflict when it is at another version.
 func (d *DesignPatterns) Update(ctx context.Context, designPattern DesignPattern) (DesignPattern, error) {
-	_, err := d.db.Collection(designPatternsCollectionName).ReplaceOne(ctx, map[string]primitive.ObjectID{"_id": designPattern.MongoID}, designPattern)
+	return d.updateVersion(ctx, designPattern.MongoID, designPattern.Version, bson.M{
+		"title":       designPattern.Title,
+		"subtitle":    designPattern.Subtitle,
+		"contentdata": designPattern.ContentData,
+		"category":    designPattern.Category,
+		"tags":        designPattern.Tags,
+	})
+}
+
+// UpdateStatus stores the Status, PublishAt and PublishedAt of designPattern and increments
+// its version. When designPattern.Version is not zero, the DesignPattern is only updated if
+// it is still at that version. It returns the updated DesignPattern, ErrNotFound when there
+// is no DesignPattern with its ID and ErrVersionConflict when it is at another version.
+func (d *DesignPatterns) UpdateStatus(ctx context.Context, designPattern DesignPattern) (DesignPattern, error) {
+	return d.updateVersion(ctx, designPattern.MongoID, designPattern.Version, bson.M{
+		"status":      designPattern.Status,
+		"publishat":   designPattern.PublishAt,
+		"publishedat": designPattern.PublishedAt,
+	})
+}
+
+// UpdateSlug stores the Slug and SlugAliases of designPattern and increments its version.
+// When designPattern.Version is not zero, the DesignPattern is only updated if it is still at
+// that version. It returns the updated DesignPattern, ErrNotFound when there is no
+// DesignPattern with its ID and ErrVersionConflict when it is at another version.
+func (d *DesignPatterns) UpdateSlug(ctx context.Context, designPattern DesignPattern) (DesignPattern, error) {
+	return d.updateVersion(ctx, designPattern.MongoID, designPattern.Version, bson.M{
+		"slug":        designPattern.Slug,
+		"slugaliases": designPattern.SlugAliases,
+	})
+}
+
+// SetTranslation stores the translation of a DesignPattern to locale, replacing the previous
+// one, and increments its version. When version is not zero, the DesignPattern is only
+// updated if it is still at that version. It returns the updated DesignPattern, ErrNotFound
+// when there is no DesignPattern with the given ID and ErrVersionConflict when it is at
+// another version.
+func (d *DesignPatterns) SetTranslation(ctx context.Context, id string, version int64, locale string, translation Translation) (DesignPattern, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return DesignPattern{}, ErrInvalidID
+	}
+
+	return d.updateVersion(ctx, primitiveID, version, bson.M{"translations." + locale: translation})
+}
+
+// DeleteTranslation removes the translation of a DesignPattern to locale and increments its
+// version, with the same version check and errors as SetTranslation.
+func (d *DesignPatterns) DeleteTranslation(ctx context.Context, id string, version int64, locale string) (DesignPattern, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
+		return DesignPattern{}, ErrInvalidID
+	}
+
+	return d.update(ctx, primitiveID, version, bson.M{
+		"$unset": bson.M{"translations." + locale: ""},
+		"$inc":   bson.M{"version": 1},
+	})
+}
+
+// MigrateBlocks rewrites the blocks of the DesignPatterns stored before typed blocks, along
+// with the ones of their translations, and returns how many DesignPatterns were migrated.
+// Their version is kept, as their content doesn't change.
+func (d *DesignPatterns) MigrateBlocks(ctx context.Context) (int64, error) {
+	return migrateBlocks(ctx, d.collection(),
+		func(designPattern DesignPattern) primitive.ObjectID { return designPattern.MongoID },
+		func(designPattern DesignPattern) bson.M {
+			set := bson.M{"contentdata": designPattern.ContentData}
+			for locale, translation := range designPattern.Translations {
+				set["translations."+locale+".contentdata"] = translation.ContentData
+			}
+			return set
+		},
+	)
+}
+
+// BackfillVersions sets version 1 on the DesignPatterns stored before versioning, whose
+// version 0 would otherwise be taken for any version by conditional writes, and returns how
+// many were backfilled.
+func (d *DesignPatterns) BackfillVersions(ctx context.Context) (int64, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"version": 0},
+	}}
+
+	return d.collection().UpdateMany(ctx, filter, bson.M{"$set": bson.M{"version": 1}})
+}
+
+// PublishDue publishes the DesignPatterns in review scheduled to be published at or before
+// now, incrementing their version, and returns how many were published.
+func (d *DesignPatterns) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	filter := notDeleted(bson.M{
+		"status":    StatusInReview,
+		"publishat": bson.M{"$lte": now},
+	})
+	update := bson.M{
+		"$set": bson.M{"status": StatusPublished, "publishat": nil, "publishedat": now},
+		"$inc": bson.M{"version": 1},
+	}
+
+	return d.collection().UpdateMany(ctx, filter, update)
+}
+
+// Patch stores the changes from original to patched as a targeted update, so fields that
+// didn't change are not rewritten. The DesignPattern is only patched if it is still at the
+// version of original. It returns the patched DesignPattern, ErrNotFound when there is no
+// DesignPattern with the ID of original and ErrVersionConflict when it is at another version.
+func (d *DesignPatterns) Patch(ctx context.Context, original, patched DesignPattern) (DesignPattern, error) {
+	set := patchSet(original, patched)
+	if len(set) == 0 {
+		return original, nil
+	}
+
+	return d.updateVersion(ctx, original.MongoID, original.Version, set)
+}
+
+// updateVersion sets the given fields and increments the version of the DesignPattern with
+// the given ID, if it is out of the trash and at version or version is zero.
+func (d *DesignPatterns) updateVersion(ctx context.Context, id primitive.ObjectID, version int64, set bson.M) (DesignPattern, error) {
+	return d.update(ctx, id, version, bson.M{
+		"$set": set,
+		"$inc": bson.M{"version": 1},
+	})
+}
+
+// update applies update to the DesignPattern with the given ID, if it is out of the trash and
+// at version or version is zero, and returns it updated.
+func (d *DesignPatterns) update(ctx context.Context, id primitive.ObjectID, version int64, update bson.M) (DesignPattern, error) {
+	var designPattern DesignPattern
+	err := d.collection().FindOneAndUpdate(ctx, versionFilter(id, version), update).Decode(&designPattern)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return DesignPattern{}, d.unmatchedWriteError(ctx, id, version)
+		}
 		return DesignPattern{}, err
 	}
 
 	return designPattern, nil
 }
+
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	filter := notDeleted(bson.M{"_id": id})
+	if version != 0 {
+		filter["version"] = version
+	}
+
+	return filter
+}
+
+// notDeleted narrows filter down to the DesignPatterns out of the trash. A null deletedat
+// also matches documents stored before soft deletion, which don't have the field.
+func notDeleted(filter bson.M) bson.M {
+	filter["deletedat"] = nil
+	return filter
+}
+
+// published narrows filter down to the published DesignPatterns. A null status also matches
+// documents stored before the lifecycle, which were all published.
+func published(filter bson.M) bson.M {
+	filter["status"] = bson.M{"$in": bson.A{StatusPublished, nil}}
+	return filter
+}
+
+// deleted narrows filter down to the DesignPatterns in the trash.
+func deleted(filter bson.M) bson.M {
+	filter["deletedat"] = bson.M{"$ne": nil}
+	return filter
+}
+
+// unmatchedWriteError tells whether a write filtered by versionFilter matched nothing
+// because the DesignPattern doesn't exist or because it is at another version.
+func (d *DesignPatterns) unmatchedWriteError(ctx context.Context, id primitive.ObjectID, version int64) error {
+	if version == 0 {
+		return ErrNotFound
+	}
+
+	count, err := d.collection().CountDocuments(ctx, notDeleted(bson.M{"_id": id}))
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+
+	return ErrVersionConflict
+}
+
+// patchSet returns the $set operand with the fields that differ between original and
+// patched. Blocks are set one by one when their number didn't change, so the ones that
+// didn't change are not rewritten.
+func patchSet(original, patched DesignPattern) bson.M {
+	set := bson.M{}
+
+	if original.Title != patched.Title {
+		set["title"] = patched.Title
+	}
+	if original.Subtitle != patched.Subtitle {
+		set["subtitle"] = patched.Subtitle
+	}
+	if original.Category != patched.Category {
+		set["category"] = patched.Category
+	}
+	if !reflect.DeepEqual(original.Tags, patched.Tags) {
+		set["tags"] = patched.Tags
+	}
+
+	if len(original.ContentData) != len(patched.ContentData) {
+		set["contentdata"] = patched.ContentData
+		return set
+	}
+	for i := range patched.ContentData {
+		if !reflect.DeepEqual(original.ContentData[i], patched.ContentData[i]) {
+			set[fmt.Sprintf("contentdata.%d", i)] = patched.ContentData[i]
+		}
+	}
+
+	return set
+}
+
+func listFilter(filter ListFilter) bson.M {
+	query := bson.M{}
+
+	if filter.Title != "" {
+		query["title"] = bson.M{"$regex": regexp.QuoteMeta(filter.Title), "$options": "i"}
+	}
+
+	if filter.Subtitle != "" {
+		query["subtitle"] = bson.M{"$regex": regexp.QuoteMeta(filter.Subtitle), "$options": "i"}
+	}
+
+	// ObjectIDs start with their creation timestamp, so a creation range is an _id range.
+	createdRange := bson.M{}
+	if !filter.CreatedAfter.IsZero() {
+		createdRange["$gte"] = boundaryObjectID(filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		createdRange["$lt"] = boundaryObjectID(filter.CreatedBefore)
+	}
+	if len(createdRange) > 0 {
+		query["_id"] = createdRange
+	}
+
+	if filter.Category != "" {
+		query["category"] = filter.Category
+	}
+
+	tags := bson.M{}
+	if len(filter.AnyTags) > 0 {
+		tags["$in"] = filter.AnyTags
+	}
+	if len(filter.AllTags) > 0 {
+		tags["$all"] = filter.AllTags
+	}
+	if len(tags) > 0 {
+		query["tags"] = tags
+	}
+
+	if filter.PublishedOnly {
+		query = published(query)
+	}
+
+	return query
+}
+
+// boundaryObjectID returns the smallest ObjectID created at the given time, so it can be
+// used as a range boundary without excluding documents created within the same second.
+func boundaryObjectID(t time.Time) primitive.ObjectID {
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[0:4], uint32(t.Unix()))
+
+	return id
+}
+
+func listFindOptions(opts ListOptions) *options.FindOptions {
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = SortByCreation
+	}
+
+	direction := 1
+	if opts.Descending {
+		direction = -1
+	}
+
+	// Sorting by _id as a tie-breaker keeps pages stable when titles repeat.
+	sort := bson.D{{Key: string(sortBy), Value: direction}}
+	if sortBy != SortByCreation {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+
+	return options.Find().
+		SetSort(sort).
+		SetSkip(opts.Skip).
+		SetLimit(opts.Limit)
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestNewDesignPatterns(t *testing.T) {
 	db := &databaseHelperMock{}
-	designPatterns := NewDesignPatterns(db)
+	designPatterns := NewDesignPatterns(db, logging.Discard())
 
 	assert.NotNil(t, designPatterns)
 }
@@ -45,13 +48,20 @@ func TestDesignPatterns_GetByID(t *testing.T) {
 			id:             "aaaa",
 			database:       &databaseHelperMock{},
 			expectedResult: DesignPattern{},
-			expectedError:  errors.New("the provided hex string is not a valid ObjectID"),
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - Not Found",
+			id:             missingId,
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			designPatterns := NewDesignPatterns(tc.database)
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
 
 			result, err := designPatterns.GetByID(context.Background(), tc.id)
 
@@ -61,6 +71,202 @@ func TestDesignPatterns_GetByID(t *testing.T) {
 	}
 }
 
+func TestDesignPatterns_List(t *testing.T) {
+	tt := []struct {
+		name           string
+		database       DatabaseHelper
+		expectedResult []DesignPattern
+		expectedTotal  int64
+		expectedError  error
+	}{
+		{
+			name:     "Ok - List",
+			database: &databaseHelperMock{},
+			expectedResult: []DesignPattern{
+				{Title: "Some Design Pattern"},
+				{Title: "Another Design Pattern"},
+			},
+			expectedTotal: 2,
+			expectedError: nil,
+		},
+		{
+			name:           "Error - List",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedTotal:  0,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, total, err := designPatterns.List(context.Background(), ListOptions{Limit: 10})
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedTotal, total)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_Search(t *testing.T) {
+	tt := []struct {
+		name           string
+		database       DatabaseHelper
+		expectedResult []SearchResult
+		expectedTotal  int64
+		expectedError  error
+	}{
+		{
+			name:     "Ok - Search",
+			database: &databaseHelperMock{},
+			expectedResult: []SearchResult{
+				{DesignPattern: DesignPattern{Title: "Some Design Pattern"}, Score: 1},
+				{DesignPattern: DesignPattern{Title: "Another Design Pattern"}, Score: 1},
+			},
+			expectedTotal: 2,
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Search",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedTotal:  0,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, total, err := designPatterns.Search(context.Background(), "design", 0, 10)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedTotal, total)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_EnsureIndexes(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - EnsureIndexes",
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - EnsureIndexes",
+			database:      &databaseHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			err := designPatterns.EnsureIndexes(context.Background())
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_TagCounts(t *testing.T) {
+	tt := []struct {
+		name           string
+		database       DatabaseHelper
+		expectedResult []TagCount
+		expectedError  error
+	}{
+		{
+			name:     "Ok - TagCounts",
+			database: &databaseHelperMock{},
+			expectedResult: []TagCount{
+				{Tag: "concurrency", Count: 2},
+				{Tag: "go idioms", Count: 1},
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - TagCounts",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.TagCounts(context.Background(), ListFilter{Category: "behavioral", PublishedOnly: true})
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	createdAfter := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name           string
+		filter         ListFilter
+		expectedResult bson.M
+	}{
+		{
+			name:           "Empty filter",
+			filter:         ListFilter{},
+			expectedResult: bson.M{},
+		},
+		{
+			name:   "Title is escaped",
+			filter: ListFilter{Title: "a.b"},
+			expectedResult: bson.M{
+				"title": bson.M{"$regex": `a\.b`, "$options": "i"},
+			},
+		},
+		{
+			name:   "Published only",
+			filter: ListFilter{PublishedOnly: true},
+			expectedResult: bson.M{
+				"status": bson.M{"$in": bson.A{StatusPublished, nil}},
+			},
+		},
+		{
+			name:   "Category and tags",
+			filter: ListFilter{Category: "creational", AnyTags: []string{"go idioms", "concurrency"}, AllTags: []string{"gof"}},
+			expectedResult: bson.M{
+				"category": "creational",
+				"tags":     bson.M{"$in": []string{"go idioms", "concurrency"}, "$all": []string{"gof"}},
+			},
+		},
+		{
+			name:   "Creation range",
+			filter: ListFilter{CreatedAfter: createdAfter},
+			expectedResult: bson.M{
+				"_id": bson.M{"$gte": primitive.ObjectID{0x63, 0x87, 0xee, 0x80}},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedResult, listFilter(tc.filter))
+		})
+	}
+}
+
 func TestDesignPatterns_Create(t *testing.T) {
 	id, _ := primitive.ObjectIDFromHex(someId)
 
@@ -80,6 +286,7 @@ func TestDesignPatterns_Create(t *testing.T) {
 			expectedResult: DesignPattern{
 				MongoID: id,
 				Title:   "Some Design Pattern",
+				Version: 1,
 			},
 			expectedError: nil,
 		},
@@ -96,7 +303,7 @@ func TestDesignPatterns_Create(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			designPatterns := NewDesignPatterns(tc.database)
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
 
 			result, err := designPatterns.Create(context.Background(), tc.designPattern)
 
@@ -107,6 +314,9 @@ func TestDesignPatterns_Create(t *testing.T) {
 }
 
 func TestDesignPatterns_Update(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+	missingID, _ := primitive.ObjectIDFromHex(missingId)
+
 	tt := []struct {
 		name           string
 		designPattern  DesignPattern
@@ -117,14 +327,51 @@ func TestDesignPatterns_Update(t *testing.T) {
 		{
 			name: "Ok - Update",
 			designPattern: DesignPattern{
-				Title: "Some Design Pattern",
+				MongoID: id,
+				Title:   "Some Design Pattern",
 			},
 			database: &databaseHelperMock{},
 			expectedResult: DesignPattern{
-				Title: "Some Design Pattern",
+				Title:   "Some Design Pattern",
+				Version: 2,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Ok - Update at version",
+			designPattern: DesignPattern{
+				MongoID: id,
+				Title:   "Some Design Pattern",
+				Version: 1,
+			},
+			database: &databaseHelperMock{},
+			expectedResult: DesignPattern{
+				Title:   "Some Design Pattern",
+				Version: 2,
 			},
 			expectedError: nil,
 		},
+		{
+			name: "Error - Version Conflict",
+			designPattern: DesignPattern{
+				MongoID: id,
+				Title:   "Some Design Pattern",
+				Version: 3,
+			},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrVersionConflict,
+		},
+		{
+			name: "Error - Not Found",
+			designPattern: DesignPattern{
+				MongoID: missingID,
+				Title:   "Some Design Pattern",
+			},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
 		{
 			name: "Error - Update",
 			designPattern: DesignPattern{
@@ -138,7 +385,7 @@ func TestDesignPatterns_Update(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			designPatterns := NewDesignPatterns(tc.database)
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
 
 			result, err := designPatterns.Update(context.Background(), tc.designPattern)
 
@@ -152,6 +399,7 @@ func TestDesignPatterns_Delete(t *testing.T) {
 	tt := []struct {
 		name          string
 		id            string
+		version       int64
 		database      DatabaseHelper
 		expectedError error
 	}{
@@ -161,6 +409,20 @@ func TestDesignPatterns_Delete(t *testing.T) {
 			database:      &databaseHelperMock{},
 			expectedError: nil,
 		},
+		{
+			name:          "Ok - Delete at version",
+			id:            "5f9f1c5b9b9b9b9b9b9b9b9b",
+			version:       1,
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - Version Conflict",
+			id:            "5f9f1c5b9b9b9b9b9b9b9b9b",
+			version:       3,
+			database:      &databaseHelperMock{},
+			expectedError: ErrVersionConflict,
+		},
 		{
 			name:          "Error - Delete",
 			id:            "5f9f1c5b9b9b9b9b9b9b9b9b",
@@ -171,17 +433,726 @@ func TestDesignPatterns_Delete(t *testing.T) {
 			name:          "Error - Erroneous ID",
 			id:            "aaaa",
 			database:      &databaseHelperMock{},
-			expectedError: errors.New("the provided hex string is not a valid ObjectID"),
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "Error - Not Found",
+			id:            missingId,
+			database:      &databaseHelperMock{},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			err := designPatterns.Delete(context.Background(), tc.id, tc.version)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_UpdateStatus(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+	missingID, _ := primitive.ObjectIDFromHex(missingId)
+
+	tt := []struct {
+		name           string
+		designPattern  DesignPattern
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:          "Ok - UpdateStatus",
+			designPattern: DesignPattern{MongoID: id, Status: StatusPublished, Version: storedVersion},
+			database:      &databaseHelperMock{},
+			expectedResult: DesignPattern{
+				Title:   "Some Design Pattern",
+				Version: storedVersion + 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Version Conflict",
+			designPattern:  DesignPattern{MongoID: id, Status: StatusPublished, Version: 3},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrVersionConflict,
+		},
+		{
+			name:           "Error - Not Found",
+			designPattern:  DesignPattern{MongoID: missingID, Status: StatusPublished},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - UpdateStatus",
+			designPattern:  DesignPattern{MongoID: id, Status: StatusPublished},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.UpdateStatus(context.Background(), tc.designPattern)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_GetBySlug(t *testing.T) {
+	found := DesignPattern{
+		Slug:        "some-design-pattern",
+		SlugAliases: []string{"old-design-pattern"},
+		Title:       "Some Design Pattern",
+	}
+
+	tt := []struct {
+		name           string
+		slug           string
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:           "Ok - GetBySlug",
+			slug:           "some-design-pattern",
+			database:       &databaseHelperMock{},
+			expectedResult: found,
+			expectedError:  nil,
+		},
+		{
+			name:           "Ok - GetBySlug by alias",
+			slug:           "old-design-pattern",
+			database:       &databaseHelperMock{},
+			expectedResult: found,
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Not Found",
+			slug:           "missing",
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - GetBySlug",
+			slug:           "some-design-pattern",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			designPatterns := NewDesignPatterns(tc.database)
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
 
-			err := designPatterns.Delete(context.Background(), tc.id)
+			result, err := designPatterns.GetBySlug(context.Background(), tc.slug)
 
+			assert.Equal(t, tc.expectedResult, result)
 			assert.Equal(t, tc.expectedError, err)
 		})
 	}
 }
+
+func TestDesignPatterns_TakenSlugs(t *testing.T) {
+	tt := []struct {
+		name           string
+		base           string
+		database       DatabaseHelper
+		expectedResult []string
+		expectedError  error
+	}{
+		{
+			name:           "Ok - TakenSlugs",
+			base:           "some-design-pattern",
+			database:       &databaseHelperMock{},
+			expectedResult: []string{"some-design-pattern", "some-design-pattern-2", "some-design-pattern-3"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - TakenSlugs",
+			base:           "some-design-pattern",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.TakenSlugs(context.Background(), tc.base)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_UpdateSlug(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+	missingID, _ := primitive.ObjectIDFromHex(missingId)
+
+	tt := []struct {
+		name           string
+		designPattern  DesignPattern
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:          "Ok - UpdateSlug",
+			designPattern: DesignPattern{MongoID: id, Slug: "singleton", Version: storedVersion},
+			database:      &databaseHelperMock{},
+			expectedResult: DesignPattern{
+				Title:   "Some Design Pattern",
+				Version: storedVersion + 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Version Conflict",
+			designPattern:  DesignPattern{MongoID: id, Slug: "singleton", Version: 3},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrVersionConflict,
+		},
+		{
+			name:           "Error - Not Found",
+			designPattern:  DesignPattern{MongoID: missingID, Slug: "singleton"},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - UpdateSlug",
+			designPattern:  DesignPattern{MongoID: id, Slug: "singleton"},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.UpdateSlug(context.Background(), tc.designPattern)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_SetTranslation(t *testing.T) {
+	translation := Translation{Title: "Some Design Pattern", SourceHash: "abc"}
+
+	tt := []struct {
+		name           string
+		id             string
+		version        int64
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:     "Ok - SetTranslation",
+			id:       someId,
+			version:  storedVersion,
+			database: &databaseHelperMock{},
+			expectedResult: DesignPattern{
+				Title:   "Some Design Pattern",
+				Version: storedVersion + 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Version Conflict",
+			id:             someId,
+			version:        3,
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrVersionConflict,
+		},
+		{
+			name:           "Error - Not Found",
+			id:             missingId,
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - SetTranslation",
+			id:             someId,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.SetTranslation(context.Background(), tc.id, tc.version, "en", translation)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_DeleteTranslation(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		version        int64
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:     "Ok - DeleteTranslation",
+			id:       someId,
+			database: &databaseHelperMock{},
+			expectedResult: DesignPattern{
+				Title:   "Some Design Pattern",
+				Version: storedVersion + 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Version Conflict",
+			id:             someId,
+			version:        3,
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrVersionConflict,
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - DeleteTranslation",
+			id:             someId,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.DeleteTranslation(context.Background(), tc.id, tc.version, "en")
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_PublishDue(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Ok - PublishDue",
+			database:      &databaseHelperMock{},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name:          "Error - PublishDue",
+			database:      &databaseHelperErrorMock{},
+			expectedCount: 0,
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			count, err := designPatterns.PublishDue(context.Background(), time.Now())
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_BackfillVersions(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Ok - BackfillVersions",
+			database:      &databaseHelperMock{},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name:          "Error - BackfillVersions",
+			database:      &databaseHelperErrorMock{},
+			expectedCount: 0,
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			count, err := designPatterns.BackfillVersions(context.Background())
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_ListDeleted(t *testing.T) {
+	tt := []struct {
+		name           string
+		database       DatabaseHelper
+		expectedResult []DesignPattern
+		expectedTotal  int64
+		expectedError  error
+	}{
+		{
+			name:     "Ok - ListDeleted",
+			database: &databaseHelperMock{},
+			expectedResult: []DesignPattern{
+				{Title: "Some Design Pattern"},
+				{Title: "Another Design Pattern"},
+			},
+			expectedTotal: 2,
+			expectedError: nil,
+		},
+		{
+			name:           "Error - ListDeleted",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedTotal:  0,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, total, err := designPatterns.ListDeleted(context.Background(), 0, 10)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedTotal, total)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_Restore(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:     "Ok - Restore",
+			id:       someId,
+			database: &databaseHelperMock{},
+			expectedResult: DesignPattern{
+				Title:   "Some Design Pattern",
+				Version: 2,
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Restore",
+			id:             someId,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - Not In Trash",
+			id:             missingId,
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.Restore(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_Purge(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		database      DatabaseHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - Purge",
+			id:            someId,
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - Purge",
+			id:            someId,
+			database:      &databaseHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+		{
+			name:          "Error - Erroneous ID",
+			id:            "aaaa",
+			database:      &databaseHelperMock{},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "Error - Not In Trash",
+			id:            missingId,
+			database:      &databaseHelperMock{},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			err := designPatterns.Purge(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestDesignPatterns_PurgeDeletedBefore(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name:          "Ok - PurgeDeletedBefore",
+			database:      &databaseHelperMock{},
+			expectedCount: 2,
+			expectedError: nil,
+		},
+		{
+			name:          "Error - PurgeDeletedBefore",
+			database:      &databaseHelperErrorMock{},
+			expectedCount: 0,
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			count, err := designPatterns.PurgeDeletedBefore(context.Background(), time.Now())
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestVersionFilter(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+
+	assert.Equal(t, bson.M{"_id": id, "deletedat": nil}, versionFilter(id, 0))
+	assert.Equal(t, bson.M{"_id": id, "deletedat": nil, "version": int64(3)}, versionFilter(id, 3))
+}
+
+func TestDesignPatterns_Patch(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+	missingID, _ := primitive.ObjectIDFromHex(missingId)
+
+	tt := []struct {
+		name           string
+		original       DesignPattern
+		patched        DesignPattern
+		database       DatabaseHelper
+		expectedResult DesignPattern
+		expectedError  error
+	}{
+		{
+			name:           "Ok - Patch",
+			original:       DesignPattern{MongoID: id, Title: "Some Design Pattern", Version: 1},
+			patched:        DesignPattern{MongoID: id, Title: "Another Design Pattern", Version: 1},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{Title: "Some Design Pattern", Version: 2},
+			expectedError:  nil,
+		},
+		{
+			name:           "Ok - Nothing to patch",
+			original:       DesignPattern{MongoID: id, Title: "Some Design Pattern", Version: 1},
+			patched:        DesignPattern{MongoID: id, Title: "Some Design Pattern", Version: 1},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{MongoID: id, Title: "Some Design Pattern", Version: 1},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Version Conflict",
+			original:       DesignPattern{MongoID: id, Title: "Some Design Pattern", Version: 3},
+			patched:        DesignPattern{MongoID: id, Title: "Another Design Pattern", Version: 3},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrVersionConflict,
+		},
+		{
+			name:           "Error - Not Found",
+			original:       DesignPattern{MongoID: missingID, Title: "Some Design Pattern"},
+			patched:        DesignPattern{MongoID: missingID, Title: "Another Design Pattern"},
+			database:       &databaseHelperMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - Patch",
+			original:       DesignPattern{MongoID: id, Title: "Some Design Pattern", Version: 1},
+			patched:        DesignPattern{MongoID: id, Title: "Another Design Pattern", Version: 1},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: DesignPattern{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			designPatterns := NewDesignPatterns(tc.database, logging.Discard())
+
+			result, err := designPatterns.Patch(context.Background(), tc.original, tc.patched)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestPatchSet(t *testing.T) {
+	original := DesignPattern{
+		Title:    "Singleton",
+		Subtitle: "Creational",
+		ContentData: []Content{
+			{Type: BlockMarkdown, Title: "Intent", Text: "One instance"},
+			{Type: BlockMarkdown, Title: "Usage", Text: "Loggers"},
+		},
+	}
+
+	tt := []struct {
+		name     string
+		patched  DesignPattern
+		expected bson.M
+	}{
+		{
+			name:     "No changes",
+			patched:  original,
+			expected: bson.M{},
+		},
+		{
+			name: "Changed subtitle",
+			patched: DesignPattern{
+				Title:       "Singleton",
+				Subtitle:    "Creational pattern",
+				ContentData: original.ContentData,
+			},
+			expected: bson.M{"subtitle": "Creational pattern"},
+		},
+		{
+			name: "Changed block",
+			patched: DesignPattern{
+				Title:    "Singleton",
+				Subtitle: "Creational",
+				ContentData: []Content{
+					{Type: BlockMarkdown, Title: "Intent", Text: "One instance"},
+					{Type: BlockMarkdown, Title: "Usage", Text: "Configuration"},
+				},
+			},
+			expected: bson.M{"contentdata.1": Content{Type: BlockMarkdown, Title: "Usage", Text: "Configuration"}},
+		},
+		{
+			name: "Removed block",
+			patched: DesignPattern{
+				Title:       "Singleton",
+				Subtitle:    "Creational",
+				ContentData: original.ContentData[:1],
+			},
+			expected: bson.M{"contentdata": original.ContentData[:1]},
+		},
+		{
+			name: "Changed category and tags",
+			patched: DesignPattern{
+				Title:       "Singleton",
+				Subtitle:    "Creational",
+				ContentData: original.ContentData,
+				Category:    "creational",
+				Tags:        []string{"gof"},
+			},
+			expected: bson.M{"category": "creational", "tags": []string{"gof"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, patchSet(original, tc.patched))
+		})
+	}
+}
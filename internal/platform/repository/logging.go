@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// loggedCollection decorates a CollectionHelper logging every operation at debug level,
+// with its duration and error.
+type loggedCollection struct {
+	CollectionHelper
+	name   string
+	logger *slog.Logger
+}
+
+func newLoggedCollection(db DatabaseHelper, name string, logger *slog.Logger) CollectionHelper {
+	return &loggedCollection{
+		CollectionHelper: db.Collection(name),
+		name:             name,
+		logger:           logger,
+	}
+}
+
+func (l *loggedCollection) log(ctx context.Context, operation string, start time.Time, err error) {
+	attrs := []any{
+		"collection", l.name,
+		"operation", operation,
+		"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+
+	l.logger.DebugContext(ctx, "mongo operation", attrs...)
+}
+
+func (l *loggedCollection) FindOne(ctx context.Context, filter interface{}) SingleResultHelper {
+	start := time.Now()
+	result := l.CollectionHelper.FindOne(ctx, filter)
+	l.log(ctx, "findOne", start, nil)
+
+	return result
+}
+
+func (l *loggedCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorHelper, error) {
+	start := time.Now()
+	cursor, err := l.CollectionHelper.Find(ctx, filter, opts...)
+	l.log(ctx, "find", start, err)
+
+	return cursor, err
+}
+
+func (l *loggedCollection) CountDocuments(ctx context.Context, filter interface{}) (int64, error) {
+	start := time.Now()
+	count, err := l.CollectionHelper.CountDocuments(ctx, filter)
+	l.log(ctx, "countDocuments", start, err)
+
+	return count, err
+}
+
+func (l *loggedCollection) InsertOne(ctx context.Context, document interface{}) (interface{}, error) {
+	start := time.Now()
+	id, err := l.CollectionHelper.InsertOne(ctx, document)
+	l.log(ctx, "insertOne", start, err)
+
+	return id, err
+}
+
+func (l *loggedCollection) DeleteOne(ctx context.Context, filter interface{}) (int64, error) {
+	start := time.Now()
+	count, err := l.CollectionHelper.DeleteOne(ctx, filter)
+	l.log(ctx, "deleteOne", start, err)
+
+	return count, err
+}
+
+func (l *loggedCollection) DeleteMany(ctx context.Context, filter interface{}) (int64, error) {
+	start := time.Now()
+	count, err := l.CollectionHelper.DeleteMany(ctx, filter)
+	l.log(ctx, "deleteMany", start, err)
+
+	return count, err
+}
+
+func (l *loggedCollection) ReplaceOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	start := time.Now()
+	count, err := l.CollectionHelper.ReplaceOne(ctx, filter, update)
+	l.log(ctx, "replaceOne", start, err)
+
+	return count, err
+}
+
+func (l *loggedCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	start := time.Now()
+	count, err := l.CollectionHelper.UpdateOne(ctx, filter, update)
+	l.log(ctx, "updateOne", start, err)
+
+	return count, err
+}
+
+func (l *loggedCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	start := time.Now()
+	count, err := l.CollectionHelper.UpdateMany(ctx, filter, update)
+	l.log(ctx, "updateMany", start, err)
+
+	return count, err
+}
+
+func (l *loggedCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}) SingleResultHelper {
+	start := time.Now()
+	result := l.CollectionHelper.FindOneAndUpdate(ctx, filter, update)
+	l.log(ctx, "findOneAndUpdate", start, nil)
+
+	return result
+}
+
+func (l *loggedCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	start := time.Now()
+	names, err := l.CollectionHelper.CreateIndexes(ctx, models)
+	l.log(ctx, "createIndexes", start, err)
+
+	return names, err
+}
+
+func (l *loggedCollection) DropIndex(ctx context.Context, name string) error {
+	start := time.Now()
+	err := l.CollectionHelper.DropIndex(ctx, name)
+	l.log(ctx, "dropIndex", start, err)
+
+	return err
+}
+
+func (l *loggedCollection) Aggregate(ctx context.Context, pipeline interface{}) (CursorHelper, error) {
+	start := time.Now()
+	cursor, err := l.CollectionHelper.Aggregate(ctx, pipeline)
+	l.log(ctx, "aggregate", start, err)
+
+	return cursor, err
+}
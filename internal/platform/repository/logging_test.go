@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+)
+
+func TestLoggedCollection(t *testing.T) {
+	var buffer bytes.Buffer
+	designPatterns := NewDesignPatterns(&databaseHelperErrorMock{}, logging.New("debug", &buffer))
+
+	_, _, err := designPatterns.List(context.Background(), ListOptions{})
+
+	assert.Error(t, err)
+	assert.Contains(t, buffer.String(), `"msg":"mongo operation","collection":"design_patterns","operation":"countDocuments"`)
+	assert.Contains(t, buffer.String(), `"error":"some-error"`)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	mediaCollectionName = "media"
+	mediaHashIndexName  = "media_hash"
+)
+
+// MediaLibrary is a repository for the metadata of uploaded Media. The files themselves are
+// kept by a storage backend under the Key of each Media.
+type MediaLibrary struct {
+	db     DatabaseHelper
+	logger *slog.Logger
+}
+
+// NewMediaLibrary creates a new MediaLibrary repository.
+func NewMediaLibrary(db DatabaseHelper, logger *slog.Logger) *MediaLibrary {
+	return &MediaLibrary{db: db, logger: logger}
+}
+
+func (m *MediaLibrary) collection() CollectionHelper {
+	return newLoggedCollection(m.db, mediaCollectionName, m.logger)
+}
+
+// EnsureIndexes creates the indexes the MediaLibrary queries rely on. It is idempotent, so
+// it can run on every startup.
+func (m *MediaLibrary) EnsureIndexes(ctx context.Context) error {
+	// Unique, so the same content is stored once even when uploaded concurrently.
+	hashIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetName(mediaHashIndexName).SetUnique(true),
+	}
+
+	_, err := m.collection().CreateIndexes(ctx, []mongo.IndexModel{hashIndex})
+	return err
+}
+
+// GetByID returns a Media by its ID.
+func (m *MediaLibrary) GetByID(ctx context.Context, id string) (Media, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Media{}, ErrInvalidID
+	}
+
+	return m.findOne(ctx, bson.M{"_id": primitiveID})
+}
+
+// GetByHash returns the Media whose content has the given SHA-256 hash.
+func (m *MediaLibrary) GetByHash(ctx context.Context, hash string) (Media, error) {
+	return m.findOne(ctx, bson.M{"hash": hash})
+}
+
+func (m *MediaLibrary) findOne(ctx context.Context, filter bson.M) (Media, error) {
+	var media Media
+	err := m.collection().FindOne(ctx, filter).Decode(&media)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Media{}, ErrNotFound
+		}
+		return Media{}, err
+	}
+
+	return media, nil
+}
+
+// Create stores a new Media. It fails with a duplicate key error when a Media with the same
+// hash is already stored.
+func (m *MediaLibrary) Create(ctx context.Context, media Media) (Media, error) {
+	result, err := m.collection().InsertOne(ctx, media)
+	if err != nil {
+		return Media{}, err
+	}
+
+	media.MongoID = result.(primitive.ObjectID)
+	return media, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMediaLibrary_EnsureIndexes(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - EnsureIndexes",
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - EnsureIndexes",
+			database:      &databaseHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			library := NewMediaLibrary(tc.database, logging.Discard())
+
+			err := library.EnsureIndexes(context.Background())
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestMediaLibrary_GetByID(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		database       DatabaseHelper
+		expectedResult Media
+		expectedError  error
+	}{
+		{
+			name:           "Ok - GetByID",
+			id:             someId,
+			database:       &databaseHelperMock{},
+			expectedResult: Media{Filename: "Some Design Pattern"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Not found",
+			id:             missingId,
+			database:       &databaseHelperMock{},
+			expectedResult: Media{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: Media{},
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - GetByID",
+			id:             someId,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Media{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			library := NewMediaLibrary(tc.database, logging.Discard())
+
+			result, err := library.GetByID(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestMediaLibrary_GetByHash(t *testing.T) {
+	tt := []struct {
+		name           string
+		hash           string
+		database       DatabaseHelper
+		expectedResult Media
+		expectedError  error
+	}{
+		{
+			name:           "Ok - GetByHash",
+			hash:           "some-hash",
+			database:       &databaseHelperMock{},
+			expectedResult: Media{Filename: "Some Design Pattern"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Not found",
+			hash:           missingHash,
+			database:       &databaseHelperMock{},
+			expectedResult: Media{},
+			expectedError:  ErrNotFound,
+		},
+		{
+			name:           "Error - GetByHash",
+			hash:           "some-hash",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Media{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			library := NewMediaLibrary(tc.database, logging.Discard())
+
+			result, err := library.GetByHash(context.Background(), tc.hash)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestMediaLibrary_Create(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+
+	tt := []struct {
+		name           string
+		media          Media
+		database       DatabaseHelper
+		expectedResult Media
+		expectedError  error
+	}{
+		{
+			name:           "Ok - Create",
+			media:          Media{Hash: "some-hash", ContentType: "image/png"},
+			database:       &databaseHelperMock{},
+			expectedResult: Media{MongoID: id, Hash: "some-hash", ContentType: "image/png"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Create",
+			media:          Media{Hash: "some-hash", ContentType: "image/png"},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Media{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			library := NewMediaLibrary(tc.database, logging.Discard())
+
+			result, err := library.Create(context.Background(), tc.media)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
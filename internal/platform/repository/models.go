@@ -1,18 +1,183 @@
 package repository
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Statuses of a DesignPattern the repository queries on. The lifecycle itself is up to the
+// services.
+const (
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+)
+
+// SortField is a field DesignPatterns can be sorted by.
+type SortField string
+
+const (
+	// SortByTitle sorts DesignPatterns alphabetically by title.
+	SortByTitle SortField = "title"
+
+	// SortByCreation sorts DesignPatterns by creation time. ObjectIDs embed their
+	// creation timestamp, so sorting by _id is equivalent.
+	SortByCreation SortField = "_id"
+)
+
 type DesignPattern struct {
+	MongoID primitive.ObjectID `bson:"_id,omitempty"`
+	// Slug is empty for DesignPatterns stored before slugs.
+	Slug string `json:"slug"`
+	// SlugAliases are the previous slugs of the DesignPattern.
+	SlugAliases []string `json:"slugAliases,omitempty"`
+	Title       string   `json:"title"`
+	Subtitle    string   `json:"subtitle"`
+	ContentData Blocks   `json:"contentData"`
+	// Version starts at 1 and is incremented by every write.
+	Version int64 `json:"version"`
+	// DeletedAt is set while the DesignPattern is in the trash.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Status is empty for DesignPatterns stored before the lifecycle, which are published.
+	Status string `json:"status"`
+	// PublishAt is when a DesignPattern in review is scheduled to be published.
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+	// PublishedAt is when the DesignPattern was last published.
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Translations holds the content of the DesignPattern in other locales, by locale.
+	Translations map[string]Translation `json:"translations,omitempty"`
+	// Category is empty for DesignPatterns that are not categorized.
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Translation is the content of a DesignPattern in another locale than the one it was
+// written in.
+type Translation struct {
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	ContentData Blocks `json:"contentData"`
+	// SourceHash identifies the content the translation was made from, so translations of
+	// content that changed since can be told apart.
+	SourceHash string    `json:"sourceHash"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// BlockType tells which kind of content a Content block holds.
+type BlockType string
+
+const (
+	BlockMarkdown BlockType = "markdown"
+	BlockCode     BlockType = "code"
+	BlockImage    BlockType = "image"
+	BlockCallout  BlockType = "callout"
+	BlockDiagram  BlockType = "diagram"
+)
+
+// Content is a typed block of content. Type tells which of the other fields the block uses:
+// Text for markdown, Language and Code for code, URL or MediaID, Alt and Caption for images,
+// Severity and Text for callouts and Format and Source for diagrams. Any block can have a
+// Title.
+// HTML is never stored, it is only set on reads asking for the rendered markdown.
+type Content struct {
+	Type     BlockType `json:"type"`
+	Title    string    `json:"title,omitempty"`
+	Text     string    `json:"text,omitempty"`
+	Language string    `json:"language,omitempty"`
+	Code     string    `json:"code,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Alt      string    `json:"alt,omitempty"`
+	MediaID  string    `json:"mediaId,omitempty"`
+	Caption  string    `json:"caption,omitempty"`
+	Severity string    `json:"severity,omitempty"`
+	Format   string    `json:"format,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	HTML     string    `json:"html,omitempty" bson:"-"`
+}
+
+// Revision is an immutable snapshot of a DesignPattern, stored on every change of its
+// content. Number is the version of the DesignPattern the snapshot holds.
+type Revision struct {
+	MongoID         primitive.ObjectID `bson:"_id,omitempty"`
+	DesignPatternID primitive.ObjectID
+	Number          int64
+	Snapshot        DesignPattern
+	Author          string
+	Note            string
+	CreatedAt       time.Time
+}
+
+// Media is the metadata of an uploaded file. Hash is the hex SHA-256 of its content, so
+// the same content is only stored once, and Key is where the storage backend keeps it.
+type Media struct {
+	MongoID     primitive.ObjectID `bson:"_id,omitempty"`
+	Hash        string
+	Key         string
+	ContentType string
+	Size        int64
+	Filename    string
+	CreatedAt   time.Time
+}
+
+// SearchResult is a DesignPattern matching a full-text search along with its relevance score.
+type SearchResult struct {
+	DesignPattern `bson:",inline"`
+	Score         float64 `bson:"score"`
+}
+
+// ListOptions holds the pagination, sorting and filtering options used to list DesignPatterns.
+type ListOptions struct {
+	Skip       int64
+	Limit      int64
+	SortBy     SortField
+	Descending bool
+	Filter     ListFilter
+}
+
+// ListFilter narrows down the DesignPatterns returned by a list. Zero values are ignored.
+type ListFilter struct {
+	// Title and Subtitle match case-insensitively anywhere in the field.
+	Title         string
+	Subtitle      string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// PublishedOnly leaves out the DesignPatterns that are not published.
+	PublishedOnly bool
+	Category      string
+	// AnyTags matches DesignPatterns with at least one of the tags and AllTags those with
+	// every one of them.
+	AnyTags []string
+	AllTags []string
+}
+
+// TagCount is a tag along with the number of DesignPatterns tagged with it.
+type TagCount struct {
+	Tag   string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+// Section is a generic piece of content, such as an algorithm or a SOLID principle. Each
+// kind of section is stored in its own collection.
+type Section struct {
 	MongoID     primitive.ObjectID `bson:"_id,omitempty"`
 	Title       string             `json:"title"`
 	Subtitle    string             `json:"subtitle"`
-	ContentData []Content          `json:"contentData"`
+	ContentData Blocks             `json:"contentData"`
 }
 
-type Content struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Image       []string `json:"image"`
+// APIKey is a long-lived credential of a machine client. Only the hex SHA-256 Hash of its
+// secret is stored, so keys can't be recovered from the database, along with the Prefix of
+// the secret that tells keys apart. The times are left out until they are set, so updates
+// can keep the earliest or latest of them.
+type APIKey struct {
+	MongoID    primitive.ObjectID `bson:"_id,omitempty"`
+	Name       string
+	Hash       string
+	Prefix     string
+	Scopes     []string
+	CreatedBy  string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time `bson:",omitempty"`
+	LastUsedAt *time.Time `bson:",omitempty"`
+	RevokedAt  *time.Time `bson:",omitempty"`
 }
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	revisionsCollectionName  = "design_pattern_revisions"
+	revisionsNumberIndexName = "design_pattern_revisions_number"
+)
+
+// Revisions is a repository for the Revisions of DesignPatterns. Revisions are immutable,
+// so they can only be created and read.
+type Revisions struct {
+	db     DatabaseHelper
+	logger *slog.Logger
+}
+
+// NewRevisions creates a new Revisions repository.
+func NewRevisions(db DatabaseHelper, logger *slog.Logger) *Revisions {
+	return &Revisions{db: db, logger: logger}
+}
+
+func (r *Revisions) collection() CollectionHelper {
+	return newLoggedCollection(r.db, revisionsCollectionName, r.logger)
+}
+
+// EnsureIndexes creates the indexes the Revisions queries rely on. It is idempotent, so it
+// can run on every startup.
+func (r *Revisions) EnsureIndexes(ctx context.Context) error {
+	// Unique, so a version of a DesignPattern can't be recorded twice.
+	numberIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "designpatternid", Value: 1}, {Key: "number", Value: -1}},
+		Options: options.Index().SetName(revisionsNumberIndexName).SetUnique(true),
+	}
+
+	_, err := r.collection().CreateIndexes(ctx, []mongo.IndexModel{numberIndex})
+	return err
+}
+
+// Create stores a new Revision.
+func (r *Revisions) Create(ctx context.Context, revision Revision) (Revision, error) {
+	result, err := r.collection().InsertOne(ctx, revision)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	revision.MongoID = result.(primitive.ObjectID)
+	return revision, nil
+}
+
+// List returns the Revisions of a DesignPattern, newest first, along with the total number
+// of them ignoring pagination.
+func (r *Revisions) List(ctx context.Context, designPatternID string, skip, limit int64) ([]Revision, int64, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(designPatternID)
+	if err != nil {
+		return nil, 0, ErrInvalidID
+	}
+
+	collection := r.collection()
+	filter := bson.M{"designpatternid": primitiveID}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "number", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []Revision{}
+	for cursor.Next(ctx) {
+		var revision Revision
+		if err := cursor.Decode(&revision); err != nil {
+			return nil, 0, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+// Get returns a Revision of a DesignPattern by its number.
+func (r *Revisions) Get(ctx context.Context, designPatternID string, number int64) (Revision, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(designPatternID)
+	if err != nil {
+		return Revision{}, ErrInvalidID
+	}
+
+	result := r.collection().FindOne(ctx, bson.M{"designpatternid": primitiveID, "number": number})
+
+	var revision Revision
+	err = result.Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Revision{}, ErrNotFound
+		}
+		return Revision{}, err
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewRevisions(t *testing.T) {
+	revisions := NewRevisions(&databaseHelperMock{}, logging.Discard())
+
+	assert.NotNil(t, revisions)
+}
+
+func TestRevisions_EnsureIndexes(t *testing.T) {
+	tt := []struct {
+		name          string
+		database      DatabaseHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - EnsureIndexes",
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - EnsureIndexes",
+			database:      &databaseHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			revisions := NewRevisions(tc.database, logging.Discard())
+
+			err := revisions.EnsureIndexes(context.Background())
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRevisions_Create(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+
+	tt := []struct {
+		name           string
+		revision       Revision
+		database       DatabaseHelper
+		expectedResult Revision
+		expectedError  error
+	}{
+		{
+			name:     "Ok - Create",
+			revision: Revision{Number: 1, Author: "someone"},
+			database: &databaseHelperMock{},
+			expectedResult: Revision{
+				MongoID: id,
+				Number:  1,
+				Author:  "someone",
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Create",
+			revision:       Revision{Number: 1, Author: "someone"},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Revision{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			revisions := NewRevisions(tc.database, logging.Discard())
+
+			result, err := revisions.Create(context.Background(), tc.revision)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRevisions_List(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		database       DatabaseHelper
+		expectedResult []Revision
+		expectedTotal  int64
+		expectedError  error
+	}{
+		{
+			name:     "Ok - List",
+			id:       someId,
+			database: &databaseHelperMock{},
+			expectedResult: []Revision{
+				{Number: 1, Snapshot: DesignPattern{Title: "Some Design Pattern"}},
+				{Number: 2, Snapshot: DesignPattern{Title: "Another Design Pattern"}},
+			},
+			expectedTotal: 2,
+			expectedError: nil,
+		},
+		{
+			name:           "Error - List",
+			id:             someId,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedTotal:  0,
+			expectedError:  errors.New("some-error"),
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: nil,
+			expectedTotal:  0,
+			expectedError:  ErrInvalidID,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			revisions := NewRevisions(tc.database, logging.Discard())
+
+			result, total, err := revisions.List(context.Background(), tc.id, 0, 10)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedTotal, total)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRevisions_Get(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		number         int64
+		database       DatabaseHelper
+		expectedResult Revision
+		expectedError  error
+	}{
+		{
+			name:     "Ok - Get",
+			id:       someId,
+			number:   1,
+			database: &databaseHelperMock{},
+			expectedResult: Revision{
+				Number:   1,
+				Snapshot: DesignPattern{Title: "Some Design Pattern", Version: 1},
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Get",
+			id:             someId,
+			number:         1,
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Revision{},
+			expectedError:  errors.New("some-error"),
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			number:         1,
+			database:       &databaseHelperMock{},
+			expectedResult: Revision{},
+			expectedError:  ErrInvalidID,
+		},
+		{
+			name:           "Error - Not Found",
+			id:             someId,
+			number:         storedVersion + 1,
+			database:       &databaseHelperMock{},
+			expectedResult: Revision{},
+			expectedError:  ErrNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			revisions := NewRevisions(tc.database, logging.Discard())
+
+			result, err := revisions.Get(context.Background(), tc.id, tc.number)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Sections is a repository for the Sections of a single kind.
+type Sections struct {
+	db         DatabaseHelper
+	collection string
+	logger     *slog.Logger
+}
+
+// NewSections creates a new Sections repository backed by the given collection.
+func NewSections(db DatabaseHelper, collection string, logger *slog.Logger) *Sections {
+	return &Sections{db: db, collection: collection, logger: logger}
+}
+
+func (s *Sections) collectionHelper() CollectionHelper {
+	return newLoggedCollection(s.db, s.collection, s.logger)
+}
+
+// GetByID returns a Section by its ID. It returns ErrInvalidID when the ID is not an ObjectID.
+func (s *Sections) GetByID(ctx context.Context, id string) (Section, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Section{}, ErrInvalidID
+	}
+
+	result := s.collectionHelper().FindOne(ctx, map[string]primitive.ObjectID{"_id": primitiveID})
+
+	var section Section
+	err = result.Decode(&section)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return Section{}, ErrNotFound
+		}
+		return Section{}, err
+	}
+
+	return section, nil
+}
+
+// List returns the Sections matching the given options, along with the total number of
+// matches ignoring pagination.
+func (s *Sections) List(ctx context.Context, opts ListOptions) ([]Section, int64, error) {
+	collection := s.collectionHelper()
+	filter := listFilter(opts.Filter)
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := collection.Find(ctx, filter, listFindOptions(opts))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	sections := []Section{}
+	for cursor.Next(ctx) {
+		var section Section
+		if err := cursor.Decode(&section); err != nil {
+			return nil, 0, err
+		}
+		sections = append(sections, section)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return sections, total, nil
+}
+
+// Create creates a new Section.
+func (s *Sections) Create(ctx context.Context, section Section) (Section, error) {
+	result, err := s.collectionHelper().InsertOne(ctx, section)
+	if err != nil {
+		return Section{}, err
+	}
+
+	section.MongoID = result.(primitive.ObjectID)
+	return section, nil
+}
+
+// Delete deletes a Section by its ID. It returns ErrInvalidID when the ID is not an
+// ObjectID and ErrNotFound when there is no Section with that ID.
+func (s *Sections) Delete(ctx context.Context, id string) error {
+	primitiveID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrInvalidID
+	}
+
+	deleted, err := s.collectionHelper().DeleteOne(ctx, map[string]primitive.ObjectID{"_id": primitiveID})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Update updates a Section. It returns ErrNotFound when there is no Section with its ID.
+func (s *Sections) Update(ctx context.Context, section Section) (Section, error) {
+	matched, err := s.collectionHelper().ReplaceOne(ctx, map[string]primitive.ObjectID{"_id": section.MongoID}, section)
+	if err != nil {
+		return Section{}, err
+	}
+	if matched == 0 {
+		return Section{}, ErrNotFound
+	}
+
+	return section, nil
+}
+
+// MigrateBlocks rewrites the blocks of the Sections stored before typed blocks and returns how
+// many Sections were migrated.
+func (s *Sections) MigrateBlocks(ctx context.Context) (int64, error) {
+	return migrateBlocks(ctx, s.collectionHelper(),
+		func(section Section) primitive.ObjectID { return section.MongoID },
+		func(section Section) bson.M { return bson.M{"contentdata": section.ContentData} },
+	)
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const someCollection = "algorithms"
+
+func TestNewSections(t *testing.T) {
+	db := &databaseHelperMock{}
+	sections := NewSections(db, someCollection, logging.Discard())
+
+	assert.NotNil(t, sections)
+}
+
+func TestSections_GetByID(t *testing.T) {
+	tt := []struct {
+		name           string
+		id             string
+		database       DatabaseHelper
+		expectedResult Section
+		expectedError  error
+	}{
+		{
+			name:     "Ok - GetByID",
+			id:       "5f9f1c5b9b9b9b9b9b9b9b9b",
+			database: &databaseHelperMock{},
+			expectedResult: Section{
+				Title: "Some Design Pattern",
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - GetByID",
+			id:             "5f9f1c5b9b9b9b9b9b9b9b9b",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Section{},
+			expectedError:  errors.New("some-error"),
+		},
+		{
+			name:           "Error - Erroneous ID",
+			id:             "aaaa",
+			database:       &databaseHelperMock{},
+			expectedResult: Section{},
+			expectedError:  ErrInvalidID,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sections := NewSections(tc.database, someCollection, logging.Discard())
+
+			result, err := sections.GetByID(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestSections_List(t *testing.T) {
+	tt := []struct {
+		name           string
+		database       DatabaseHelper
+		expectedResult []Section
+		expectedTotal  int64
+		expectedError  error
+	}{
+		{
+			name:     "Ok - List",
+			database: &databaseHelperMock{},
+			expectedResult: []Section{
+				{Title: "Some Design Pattern"},
+				{Title: "Another Design Pattern"},
+			},
+			expectedTotal: 2,
+			expectedError: nil,
+		},
+		{
+			name:           "Error - List",
+			database:       &databaseHelperErrorMock{},
+			expectedResult: nil,
+			expectedTotal:  0,
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sections := NewSections(tc.database, someCollection, logging.Discard())
+
+			result, total, err := sections.List(context.Background(), ListOptions{Limit: 10})
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedTotal, total)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestSections_Create(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(someId)
+
+	tt := []struct {
+		name           string
+		section        Section
+		database       DatabaseHelper
+		expectedResult Section
+		expectedError  error
+	}{
+		{
+			name:     "Ok - Create",
+			section:  Section{Title: "Quicksort"},
+			database: &databaseHelperMock{},
+			expectedResult: Section{
+				MongoID: id,
+				Title:   "Quicksort",
+			},
+			expectedError: nil,
+		},
+		{
+			name:           "Error - Create",
+			section:        Section{Title: "Quicksort"},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Section{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sections := NewSections(tc.database, someCollection, logging.Discard())
+
+			result, err := sections.Create(context.Background(), tc.section)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestSections_Update(t *testing.T) {
+	tt := []struct {
+		name           string
+		section        Section
+		database       DatabaseHelper
+		expectedResult Section
+		expectedError  error
+	}{
+		{
+			name:           "Ok - Update",
+			section:        Section{Title: "Quicksort"},
+			database:       &databaseHelperMock{},
+			expectedResult: Section{Title: "Quicksort"},
+			expectedError:  nil,
+		},
+		{
+			name:           "Error - Update",
+			section:        Section{Title: "Quicksort"},
+			database:       &databaseHelperErrorMock{},
+			expectedResult: Section{},
+			expectedError:  errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sections := NewSections(tc.database, someCollection, logging.Discard())
+
+			result, err := sections.Update(context.Background(), tc.section)
+
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestSections_Delete(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		database      DatabaseHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - Delete",
+			id:            "5f9f1c5b9b9b9b9b9b9b9b9b",
+			database:      &databaseHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - Delete",
+			id:            "5f9f1c5b9b9b9b9b9b9b9b9b",
+			database:      &databaseHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+		{
+			name:          "Error - Erroneous ID",
+			id:            "aaaa",
+			database:      &databaseHelperMock{},
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "Error - Not Found",
+			id:            missingId,
+			database:      &databaseHelperMock{},
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sections := NewSections(tc.database, someCollection, logging.Discard())
+
+			err := sections.Delete(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
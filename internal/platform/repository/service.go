@@ -2,19 +2,41 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-)
-
-const (
-	database   = "sections-db"
-	ctxTimeout = 10 * time.Second
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 var (
 	ErrNotFound = mongo.ErrNoDocuments
+
+	// ErrInvalidID is returned when an id is not a valid ObjectID.
+	ErrInvalidID = errors.New("invalid id")
+
+	// ErrVersionConflict is returned when a write expects a version of a document that is
+	// no longer the stored one.
+	ErrVersionConflict = errors.New("version conflict")
+)
+
+// IsDuplicateKey reports whether err was caused by a unique index violation.
+func IsDuplicateKey(err error) bool {
+	return mongo.IsDuplicateKeyError(err)
+}
+
+// IsUnavailable reports whether err was caused by MongoDB being unreachable or too slow
+// to answer, so the operation may succeed if retried later.
+func IsUnavailable(err error) bool {
+	return mongo.IsTimeout(err) || mongo.IsNetworkError(err) || errors.Is(err, mongo.ErrClientDisconnected)
+}
+
+// Codes of the MongoDB errors returned when dropping an index that doesn't exist, or an index
+// of a collection that doesn't exist.
+const (
+	namespaceNotFoundCode = 26
+	indexNotFoundCode     = 27
 )
 
 type DatabaseHelper interface {
@@ -24,19 +46,48 @@ type DatabaseHelper interface {
 
 type CollectionHelper interface {
 	FindOne(context.Context, interface{}) SingleResultHelper
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorHelper, error)
+	CountDocuments(ctx context.Context, filter interface{}) (int64, error)
 	InsertOne(context.Context, interface{}) (interface{}, error)
 	DeleteOne(ctx context.Context, filter interface{}) (int64, error)
+	// DeleteMany returns the number of documents deleted.
+	DeleteMany(ctx context.Context, filter interface{}) (int64, error)
+	// ReplaceOne returns the number of documents matched by filter, which are replaced
+	// even when the replacement is identical.
 	ReplaceOne(ctx context.Context, filter interface{}, update interface{}) (int64, error)
+	// UpdateOne applies update operators to the first document matched by filter and
+	// returns the number of documents matched.
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}) (int64, error)
+	// UpdateMany applies update operators to every document matched by filter and returns
+	// the number of documents matched.
+	UpdateMany(ctx context.Context, filter interface{}, update interface{}) (int64, error)
+	// FindOneAndUpdate applies update operators to the first document matched by filter
+	// and returns the document as it is after the update.
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}) SingleResultHelper
+	CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error)
+	// DropIndex drops the index with the given name. Dropping an index that doesn't exist
+	// is not an error.
+	DropIndex(ctx context.Context, name string) error
+	// Aggregate runs an aggregation pipeline and returns a cursor over its results.
+	Aggregate(ctx context.Context, pipeline interface{}) (CursorHelper, error)
 }
 
 type SingleResultHelper interface {
 	Decode(v interface{}) error
 }
 
+type CursorHelper interface {
+	Next(ctx context.Context) bool
+	Decode(v interface{}) error
+	Err() error
+	Close(ctx context.Context) error
+}
+
 type ClientHelper interface {
 	Database(string) DatabaseHelper
 	Connect() error
 	Close() error
+	Ping(ctx context.Context) error
 }
 
 type mongoClient struct {
@@ -55,17 +106,29 @@ type mongoSingleResult struct {
 	sr *mongo.SingleResult
 }
 
-// NewClient returns a new mongo client
-func NewClient(mongoDBURI string) (ClientHelper, error) {
-	c, err := mongo.NewClient(options.Client().ApplyURI(mongoDBURI))
+type mongoCursor struct {
+	cur *mongo.Cursor
+}
+
+// NewClient returns a new mongo client. The timeout bounds connecting and selecting a server.
+func NewClient(mongoDBURI string, timeout time.Duration) (ClientHelper, error) {
+	opts := options.Client().
+		ApplyURI(mongoDBURI).
+		SetConnectTimeout(timeout).
+		SetServerSelectionTimeout(timeout)
+
+	c, err := mongo.NewClient(opts)
 
 	return &mongoClient{cl: c}, err
 }
 
-// NewDatabase returns a new mongo database
-func NewDatabase(client ClientHelper) DatabaseHelper {
-	client.Connect()
-	return client.Database(database)
+// NewDatabase connects the client and returns the mongo database with the given name
+func NewDatabase(client ClientHelper, name string) (DatabaseHelper, error) {
+	if err := client.Connect(); err != nil {
+		return nil, err
+	}
+
+	return client.Database(name), nil
 }
 
 func (mc *mongoClient) Database(dbName string) DatabaseHelper {
@@ -81,6 +144,11 @@ func (mc *mongoClient) Connect() error {
 	return mc.cl.Connect(context.Background())
 }
 
+// Ping checks the primary is reachable.
+func (mc *mongoClient) Ping(ctx context.Context) error {
+	return mc.cl.Ping(ctx, readpref.Primary())
+}
+
 func (md *mongoDatabase) Collection(colName string) CollectionHelper {
 	collection := md.db.Collection(colName)
 	return &mongoCollection{coll: collection}
@@ -96,21 +164,118 @@ func (mc *mongoCollection) FindOne(ctx context.Context, filter interface{}) Sing
 	return &mongoSingleResult{sr: singleResult}
 }
 
+func (mc *mongoCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorHelper, error) {
+	cursor, err := mc.coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoCursor{cur: cursor}, nil
+}
+
+func (mc *mongoCollection) CountDocuments(ctx context.Context, filter interface{}) (int64, error) {
+	return mc.coll.CountDocuments(ctx, filter)
+}
+
 func (mc *mongoCollection) InsertOne(ctx context.Context, document interface{}) (interface{}, error) {
 	id, err := mc.coll.InsertOne(ctx, document)
-	return id.InsertedID, err
+	if err != nil {
+		return nil, err
+	}
+
+	return id.InsertedID, nil
 }
 
 func (mc *mongoCollection) DeleteOne(ctx context.Context, filter interface{}) (int64, error) {
 	count, err := mc.coll.DeleteOne(ctx, filter)
-	return count.DeletedCount, err
+	if err != nil {
+		return 0, err
+	}
+
+	return count.DeletedCount, nil
+}
+
+func (mc *mongoCollection) DeleteMany(ctx context.Context, filter interface{}) (int64, error) {
+	count, err := mc.coll.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return count.DeletedCount, nil
 }
 
 func (mc *mongoCollection) ReplaceOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
 	count, err := mc.coll.ReplaceOne(ctx, filter, update)
-	return count.ModifiedCount, err
+	if err != nil {
+		return 0, err
+	}
+
+	return count.MatchedCount, nil
+}
+
+func (mc *mongoCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	count, err := mc.coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return count.MatchedCount, nil
+}
+
+func (mc *mongoCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	count, err := mc.coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return count.MatchedCount, nil
+}
+
+func (mc *mongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}) SingleResultHelper {
+	singleResult := mc.coll.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	return &mongoSingleResult{sr: singleResult}
+}
+
+func (mc *mongoCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	return mc.coll.Indexes().CreateMany(ctx, models)
+}
+
+func (mc *mongoCollection) DropIndex(ctx context.Context, name string) error {
+	_, err := mc.coll.Indexes().DropOne(ctx, name)
+
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && (commandErr.Code == indexNotFoundCode || commandErr.Code == namespaceNotFoundCode) {
+		return nil
+	}
+
+	return err
+}
+
+func (mc *mongoCollection) Aggregate(ctx context.Context, pipeline interface{}) (CursorHelper, error) {
+	cursor, err := mc.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mongoCursor{cur: cursor}, nil
 }
 
 func (sr *mongoSingleResult) Decode(v interface{}) error {
 	return sr.sr.Decode(v)
 }
+
+func (c *mongoCursor) Next(ctx context.Context) bool {
+	return c.cur.Next(ctx)
+}
+
+func (c *mongoCursor) Decode(v interface{}) error {
+	return c.cur.Decode(v)
+}
+
+func (c *mongoCursor) Err() error {
+	return c.cur.Err()
+}
+
+func (c *mongoCursor) Close(ctx context.Context) error {
+	return c.cur.Close(ctx)
+}
@@ -4,11 +4,23 @@ import (
 	"context"
 	"errors"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
 	someId = "5f9f1c5b9b9b9b9b9b9b9b9b"
+
+	// missingId is the only id that writes of collectionHelperMock don't match.
+	missingId = "5f9f1c5b9b9b9b9b9b9b9b00"
+
+	// missingHash is the only Media or APIKey hash that collectionHelperMock doesn't find.
+	missingHash = "missing-hash"
+
+	// storedVersion is the version of every document in collectionHelperMock.
+	storedVersion = 1
 )
 
 type databaseHelperMock struct {
@@ -26,8 +38,19 @@ type collectionHelperMock struct {
 }
 
 func (c *collectionHelperMock) FindOne(ctx context.Context, filter interface{}) SingleResultHelper {
-	id := filter.(map[string]primitive.ObjectID)["_id"]
-	switch id.Hex() {
+	if filter, ok := filter.(bson.M); ok {
+		if number, ok := filter["number"]; ok {
+			return revisionResult(number.(int64))
+		}
+		if _, ok := filter["$or"]; ok {
+			return slugResult(filter)
+		}
+		if hash, ok := filter["hash"]; ok {
+			return mediaResult(hash.(string))
+		}
+	}
+
+	switch filterID(filter).Hex() {
 	case "5f9f1c5b9b9b9b9b9b9b9b9b":
 		return &singleResultHelperMock{
 			designPattern: DesignPattern{
@@ -35,36 +58,237 @@ func (c *collectionHelperMock) FindOne(ctx context.Context, filter interface{})
 			},
 		}
 
+	case missingId:
+		return &singleResultHelperMock{err: mongo.ErrNoDocuments}
+
 	default:
 		return &singleResultHelperMock{}
 
 	}
 }
 
+func (c *collectionHelperMock) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorHelper, error) {
+	if filter, ok := filter.(bson.M); ok {
+		if _, ok := filter["$or"]; ok {
+			return &cursorHelperMock{
+				designPatterns: []DesignPattern{
+					{Slug: "some-design-pattern", SlugAliases: []string{"some-design-pattern-2", "another-design-pattern"}},
+					{Slug: "some-design-pattern-3"},
+				},
+			}, nil
+		}
+	}
+
+	return &cursorHelperMock{
+		designPatterns: []DesignPattern{
+			{Title: "Some Design Pattern"},
+			{Title: "Another Design Pattern"},
+		},
+	}, nil
+}
+
+func (c *collectionHelperMock) CountDocuments(ctx context.Context, filter interface{}) (int64, error) {
+	return 2, nil
+}
+
 func (c *collectionHelperMock) InsertOne(ctx context.Context, field interface{}) (interface{}, error) {
 	id, _ := primitive.ObjectIDFromHex(someId)
 	return id, nil
 }
 
 func (c *collectionHelperMock) DeleteOne(ctx context.Context, filter interface{}) (int64, error) {
-	return 0, nil
+	return matchedCount(filter), nil
+}
+
+func (c *collectionHelperMock) DeleteMany(ctx context.Context, filter interface{}) (int64, error) {
+	return 2, nil
 }
 
 func (c *collectionHelperMock) ReplaceOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
-	return 0, nil
+	return matchedCount(filter), nil
+}
+
+func (c *collectionHelperMock) UpdateOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	return matchedCount(filter), nil
+}
+
+func (c *collectionHelperMock) UpdateMany(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	return 2, nil
+}
+
+func (c *collectionHelperMock) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}) SingleResultHelper {
+	if matchedCount(filter) == 0 {
+		return &singleResultHelperMock{err: mongo.ErrNoDocuments}
+	}
+
+	return &singleResultHelperMock{
+		designPattern: DesignPattern{
+			Title:   "Some Design Pattern",
+			Version: storedVersion + 1,
+		},
+	}
+}
+
+// revisionResult pretends every DesignPattern has storedVersion revisions.
+func revisionResult(number int64) SingleResultHelper {
+	if number > storedVersion {
+		return &singleResultHelperMock{err: mongo.ErrNoDocuments}
+	}
+
+	return &singleResultHelperMock{
+		designPattern: DesignPattern{
+			Title:   "Some Design Pattern",
+			Version: number,
+		},
+	}
+}
+
+// slugResult finds a DesignPattern by slug. It has the slug some-design-pattern and the alias
+// old-design-pattern.
+func slugResult(filter bson.M) SingleResultHelper {
+	slug := filter["$or"].(bson.A)[0].(bson.M)["slug"]
+	if slug != "some-design-pattern" && slug != "old-design-pattern" {
+		return &singleResultHelperMock{err: mongo.ErrNoDocuments}
+	}
+
+	return &singleResultHelperMock{
+		designPattern: DesignPattern{
+			Slug:        "some-design-pattern",
+			SlugAliases: []string{"old-design-pattern"},
+			Title:       "Some Design Pattern",
+		},
+	}
+}
+
+// mediaResult finds a Media or an APIKey by hash. Every hash is stored except missingHash.
+func mediaResult(hash string) SingleResultHelper {
+	if hash == missingHash {
+		return &singleResultHelperMock{err: mongo.ErrNoDocuments}
+	}
+
+	return &singleResultHelperMock{
+		designPattern: DesignPattern{
+			Title: "Some Design Pattern",
+		},
+	}
+}
+
+// matchedCount pretends every document exists at storedVersion, except the one with missingId.
+func matchedCount(filter interface{}) int64 {
+	if filterID(filter).Hex() == missingId {
+		return 0
+	}
+	if filter, ok := filter.(bson.M); ok {
+		if version, ok := filter["version"]; ok && version != int64(storedVersion) {
+			return 0
+		}
+	}
+
+	return 1
+}
+
+func filterID(filter interface{}) primitive.ObjectID {
+	switch filter := filter.(type) {
+	case map[string]primitive.ObjectID:
+		return filter["_id"]
+	case bson.M:
+		id, _ := filter["_id"].(primitive.ObjectID)
+		return id
+	}
+
+	return primitive.NilObjectID
+}
+
+func (c *collectionHelperMock) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		names = append(names, *model.Options.Name)
+	}
+
+	return names, nil
+}
+
+func (c *collectionHelperMock) DropIndex(ctx context.Context, name string) error {
+	return nil
+}
+
+func (c *collectionHelperMock) Aggregate(ctx context.Context, pipeline interface{}) (CursorHelper, error) {
+	return &cursorHelperMock{
+		tagCounts: []TagCount{
+			{Tag: "concurrency", Count: 2},
+			{Tag: "go idioms", Count: 1},
+		},
+	}, nil
 }
 
 type singleResultHelperMock struct {
 	designPattern DesignPattern
+	err           error
 }
 
 func (s *singleResultHelperMock) Decode(v interface{}) error {
-	designPattern := v.(*DesignPattern)
-	*designPattern = s.designPattern
+	if s.err != nil {
+		return s.err
+	}
+
+	switch result := v.(type) {
+	case *DesignPattern:
+		*result = s.designPattern
+	case *Section:
+		*result = Section{Title: s.designPattern.Title}
+	case *Revision:
+		*result = Revision{Number: s.designPattern.Version, Snapshot: s.designPattern}
+	case *Media:
+		*result = Media{Filename: s.designPattern.Title}
+	case *APIKey:
+		*result = APIKey{Name: s.designPattern.Title}
+	}
 
 	return nil
 }
 
+type cursorHelperMock struct {
+	designPatterns []DesignPattern
+	tagCounts      []TagCount
+	position       int
+}
+
+func (c *cursorHelperMock) Next(ctx context.Context) bool {
+	if c.position >= len(c.designPatterns)+len(c.tagCounts) {
+		return false
+	}
+
+	c.position++
+	return true
+}
+
+func (c *cursorHelperMock) Decode(v interface{}) error {
+	switch result := v.(type) {
+	case *DesignPattern:
+		*result = c.designPatterns[c.position-1]
+	case *SearchResult:
+		*result = SearchResult{DesignPattern: c.designPatterns[c.position-1], Score: 1}
+	case *Section:
+		*result = Section{Title: c.designPatterns[c.position-1].Title}
+	case *Revision:
+		*result = Revision{Number: int64(c.position), Snapshot: c.designPatterns[c.position-1]}
+	case *TagCount:
+		*result = c.tagCounts[c.position-1]
+	case *APIKey:
+		*result = APIKey{Name: c.designPatterns[c.position-1].Title}
+	}
+
+	return nil
+}
+
+func (c *cursorHelperMock) Err() error {
+	return nil
+}
+
+func (c *cursorHelperMock) Close(ctx context.Context) error {
+	return nil
+}
+
 type clientHelperMock struct {
 }
 
@@ -80,6 +304,10 @@ func (c *clientHelperMock) Close() error {
 	return nil
 }
 
+func (c *clientHelperMock) Ping(ctx context.Context) error {
+	return nil
+}
+
 // Generate mocks with errors
 
 type databaseHelperErrorMock struct {
@@ -100,6 +328,14 @@ func (c *collectionHelperErrorMock) FindOne(ctx context.Context, filter interfac
 	return &singleResultHelperErrorMock{}
 }
 
+func (c *collectionHelperErrorMock) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (CursorHelper, error) {
+	return nil, errors.New("some-error")
+}
+
+func (c *collectionHelperErrorMock) CountDocuments(ctx context.Context, filter interface{}) (int64, error) {
+	return 0, errors.New("some-error")
+}
+
 func (c *collectionHelperErrorMock) InsertOne(ctx context.Context, designPattern interface{}) (interface{}, error) {
 	return nil, errors.New("some-error")
 }
@@ -108,10 +344,38 @@ func (c *collectionHelperErrorMock) DeleteOne(ctx context.Context, filter interf
 	return 0, errors.New("some-error")
 }
 
+func (c *collectionHelperErrorMock) DeleteMany(ctx context.Context, filter interface{}) (int64, error) {
+	return 0, errors.New("some-error")
+}
+
 func (c *collectionHelperErrorMock) ReplaceOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
 	return 0, errors.New("some-error")
 }
 
+func (c *collectionHelperErrorMock) UpdateOne(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	return 0, errors.New("some-error")
+}
+
+func (c *collectionHelperErrorMock) UpdateMany(ctx context.Context, filter interface{}, update interface{}) (int64, error) {
+	return 0, errors.New("some-error")
+}
+
+func (c *collectionHelperErrorMock) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}) SingleResultHelper {
+	return &singleResultHelperErrorMock{}
+}
+
+func (c *collectionHelperErrorMock) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	return nil, errors.New("some-error")
+}
+
+func (c *collectionHelperErrorMock) DropIndex(ctx context.Context, name string) error {
+	return errors.New("some-error")
+}
+
+func (c *collectionHelperErrorMock) Aggregate(ctx context.Context, pipeline interface{}) (CursorHelper, error) {
+	return nil, errors.New("some-error")
+}
+
 type singleResultHelperErrorMock struct {
 }
 
@@ -133,3 +397,7 @@ func (c *clientHelperErrorMock) Connect() error {
 func (c *clientHelperErrorMock) Close() error {
 	return errors.New("some-error")
 }
+
+func (c *clientHelperErrorMock) Ping(ctx context.Context) error {
+	return errors.New("some-error")
+}
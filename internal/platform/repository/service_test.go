@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDatabase(t *testing.T) {
+	tt := []struct {
+		name          string
+		client        ClientHelper
+		expectedError error
+	}{
+		{
+			name:          "Ok - NewDatabase",
+			client:        &clientHelperMock{},
+			expectedError: nil,
+		},
+		{
+			name:          "Error - Connect",
+			client:        &clientHelperErrorMock{},
+			expectedError: errors.New("some-error"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := NewDatabase(tc.client, "sections-test")
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedError == nil, db != nil)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrNotFound is returned when there is no file stored under a key.
+	ErrNotFound = errors.New("file not found")
+
+	// ErrInvalidKey is returned when a key is not a plain file name, so it could point
+	// outside of the storage.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// Local stores files in a directory of the local filesystem, each one in a file named after
+// its key.
+type Local struct {
+	dir string
+}
+
+// NewLocal creates a Local storage in dir, creating the directory when it doesn't exist.
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating storage directory: %w", err)
+	}
+
+	return &Local{dir: dir}, nil
+}
+
+// Put stores content under key, replacing whatever was stored under it. The content is
+// written to a temporary file that is renamed once complete, so readers never see part of it.
+func (l *Local) Put(ctx context.Context, key string, content io.Reader) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(l.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := io.Copy(temp, content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
+
+// Open returns the file stored under key.
+func (l *Local) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+	if key == "" || key[0] == '.' || filepath.Base(key) != key {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.dir, key), nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "media")
+	local, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, local.Put(ctx, "abc.png", strings.NewReader("some-image")))
+
+	file, err := local.Open(ctx, "abc.png")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, "some-image", string(content))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+
+	_, err = local.Open(ctx, "abc.gif")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLocal_InvalidKey(t *testing.T) {
+	local, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", ".", "..", "../abc.png", "a/b.png", ".upload-1"} {
+		t.Run(key, func(t *testing.T) {
+			ctx := context.Background()
+
+			assert.Equal(t, ErrInvalidKey, local.Put(ctx, key, strings.NewReader("some-image")))
+			_, err := local.Open(ctx, key)
+			assert.Equal(t, ErrInvalidKey, err)
+		})
+	}
+}
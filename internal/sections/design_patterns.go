@@ -1 +0,0 @@
-package sections
\ No newline at end of file
@@ -0,0 +1,26 @@
+package sections
+
+// Kind is a type of section served by the API. Every kind gets its own collection and its
+// own set of routes.
+type Kind struct {
+	// Name identifies the kind in the routes, e.g. /algorithms.
+	Name string
+	// Collection is the Mongo collection the sections of this kind are stored in.
+	Collection string
+}
+
+var (
+	Algorithms      = Kind{Name: "algorithms", Collection: "algorithms"}
+	DataStructures  = Kind{Name: "data-structures", Collection: "data_structures"}
+	SolidPrinciples = Kind{Name: "solid-principles", Collection: "solid_principles"}
+	AntiPatterns    = Kind{Name: "anti-patterns", Collection: "anti_patterns"}
+)
+
+// Kinds are all the section kinds served by the API. Adding a new topic only requires a
+// new entry here.
+var Kinds = []Kind{
+	Algorithms,
+	DataStructures,
+	SolidPrinciples,
+	AntiPatterns,
+}
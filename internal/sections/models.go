@@ -0,0 +1,30 @@
+package sections
+
+import "github.com/waydevs/sections-api/internal/platform/repository"
+
+type Section struct {
+	ID          string               `json:"id"`
+	Kind        string               `json:"kind"`
+	Title       string               `json:"title"`
+	Subtitle    string               `json:"subtitle"`
+	ContentData []repository.Content `json:"contentData"`
+}
+
+// ListParams are the pagination, sorting and filtering parameters to list Sections.
+type ListParams struct {
+	// Page is 1-based. Zero means the first page.
+	Page int
+	// Limit is the page size. Zero means DefaultListLimit.
+	Limit int
+	// Sort is one of "title", "createdAt", optionally prefixed with "-" for descending order.
+	Sort  string
+	Title string
+}
+
+// ListResult is a page of Sections.
+type ListResult struct {
+	Items []Section
+	Page  int
+	Limit int
+	Total int64
+}
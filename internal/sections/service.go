@@ -0,0 +1,182 @@
+package sections
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/waydevs/sections-api/internal/platform/paging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ErrSomethingWentWrong is returned when something went wrong.
+	ErrSomethingWentWrong = errors.New("Something went wrong")
+
+	// ErrSectionNotFound is returned when a Section is not found.
+	ErrSectionNotFound = errors.New("Section not found")
+
+	// ErrInvalidID is returned when an id is not a valid Section id.
+	ErrInvalidID = errors.New("Invalid section id")
+
+	// ErrInvalidSort is returned when a list is requested with an unknown sort.
+	ErrInvalidSort = errors.New("Invalid sort, use title or createdAt optionally prefixed with -")
+)
+
+const (
+	// DefaultListLimit is the page size used when none is given.
+	DefaultListLimit = paging.DefaultLimit
+
+	// MaxListLimit is the largest page size allowed.
+	MaxListLimit = paging.MaxLimit
+)
+
+// SectionRepository is a repository for the Sections of a single kind.
+type SectionRepository interface {
+	GetByID(ctx context.Context, id string) (repository.Section, error)
+	List(ctx context.Context, opts repository.ListOptions) ([]repository.Section, int64, error)
+	Create(ctx context.Context, section repository.Section) (repository.Section, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, section repository.Section) (repository.Section, error)
+}
+
+// Service handles the business logic and use cases for the Sections of a single kind.
+type Service struct {
+	kind   Kind
+	db     SectionRepository
+	logger *slog.Logger
+}
+
+// NewService creates a new Section service for the given kind.
+func NewService(kind Kind, db SectionRepository, logger *slog.Logger) *Service {
+	return &Service{kind: kind, db: db, logger: logger}
+}
+
+// Kind returns the kind of Sections handled by the service.
+func (s *Service) Kind() Kind {
+	return s.kind
+}
+
+// GetByID returns a Section by its ID.
+func (s *Service) GetByID(ctx context.Context, id string) (Section, error) {
+	section, err := s.db.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return Section{}, ErrSectionNotFound
+		}
+		if errors.Is(err, repository.ErrInvalidID) {
+			return Section{}, ErrInvalidID
+		}
+
+		s.logger.ErrorContext(ctx, "getting section", "kind", s.kind.Name, "id", id, "error", err)
+		return Section{}, ErrSomethingWentWrong
+	}
+
+	return s.repositoryModelToServiceModel(section), nil
+}
+
+// List returns a page of Sections. Page and limit are normalized to valid values.
+func (s *Service) List(ctx context.Context, params ListParams) (ListResult, error) {
+	params.Page, params.Limit = paging.Normalize(params.Page, params.Limit)
+
+	opts := repository.ListOptions{
+		Skip:   int64((params.Page - 1) * params.Limit),
+		Limit:  int64(params.Limit),
+		Filter: repository.ListFilter{Title: params.Title},
+	}
+
+	if params.Sort != "" {
+		var ok bool
+		opts.SortBy, opts.Descending, ok = paging.Sort(params.Sort)
+		if !ok {
+			return ListResult{}, ErrInvalidSort
+		}
+	}
+
+	sections, total, err := s.db.List(ctx, opts)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "listing sections", "kind", s.kind.Name, "error", err)
+		return ListResult{}, ErrSomethingWentWrong
+	}
+
+	items := make([]Section, 0, len(sections))
+	for _, section := range sections {
+		items = append(items, s.repositoryModelToServiceModel(section))
+	}
+
+	return ListResult{
+		Items: items,
+		Page:  params.Page,
+		Limit: params.Limit,
+		Total: total,
+	}, nil
+}
+
+// Create creates a new Section.
+func (s *Service) Create(ctx context.Context, section Section) (Section, error) {
+	sectionCreated, err := s.db.Create(ctx, repository.Section{
+		Title:       section.Title,
+		Subtitle:    section.Subtitle,
+		ContentData: section.ContentData,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "creating section", "kind", s.kind.Name, "error", err)
+		return Section{}, ErrSomethingWentWrong
+	}
+
+	return s.repositoryModelToServiceModel(sectionCreated), nil
+}
+
+// Delete deletes a Section by its ID.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	err := s.db.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSectionNotFound
+		}
+		if errors.Is(err, repository.ErrInvalidID) {
+			return ErrInvalidID
+		}
+
+		s.logger.ErrorContext(ctx, "deleting section", "kind", s.kind.Name, "id", id, "error", err)
+		return ErrSomethingWentWrong
+	}
+
+	return nil
+}
+
+// Update updates a Section.
+func (s *Service) Update(ctx context.Context, section Section) (Section, error) {
+	primitiveID, err := primitive.ObjectIDFromHex(section.ID)
+	if err != nil {
+		return Section{}, ErrInvalidID
+	}
+
+	sectionUpdated, err := s.db.Update(ctx, repository.Section{
+		MongoID:     primitiveID,
+		Title:       section.Title,
+		Subtitle:    section.Subtitle,
+		ContentData: section.ContentData,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return Section{}, ErrSectionNotFound
+		}
+
+		s.logger.ErrorContext(ctx, "updating section", "kind", s.kind.Name, "id", section.ID, "error", err)
+		return Section{}, ErrSomethingWentWrong
+	}
+
+	return s.repositoryModelToServiceModel(sectionUpdated), nil
+}
+
+func (s *Service) repositoryModelToServiceModel(section repository.Section) Section {
+	return Section{
+		ID:          section.MongoID.Hex(),
+		Kind:        s.kind.Name,
+		Title:       section.Title,
+		Subtitle:    section.Subtitle,
+		ContentData: section.ContentData,
+	}
+}
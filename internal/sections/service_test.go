@@ -0,0 +1,319 @@
+package sections
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/waydevs/sections-api/internal/platform/logging"
+	"github.com/waydevs/sections-api/internal/platform/repository"
+)
+
+type sectionRepositoryMock struct{}
+
+func (d sectionRepositoryMock) GetByID(_ context.Context, id string) (repository.Section, error) {
+	switch id {
+	case "ok":
+		return repository.Section{
+			Title: "ok",
+		}, nil
+
+	case "not-found":
+		return repository.Section{}, repository.ErrNotFound
+
+	case "invalid-id":
+		return repository.Section{}, repository.ErrInvalidID
+
+	default:
+		return repository.Section{}, errors.New("some-error")
+	}
+}
+
+func (d sectionRepositoryMock) List(_ context.Context, opts repository.ListOptions) ([]repository.Section, int64, error) {
+	switch opts.Filter.Title {
+	case "error":
+		return nil, 0, errors.New("some-error")
+
+	default:
+		return []repository.Section{
+			{Title: "ok"},
+		}, 1, nil
+	}
+}
+
+func (d sectionRepositoryMock) Create(_ context.Context, section repository.Section) (repository.Section, error) {
+	switch section.Title {
+	case "ok":
+		return section, nil
+
+	default:
+		return repository.Section{}, errors.New("some-error")
+	}
+}
+
+func (d sectionRepositoryMock) Delete(_ context.Context, id string) error {
+	switch id {
+	case "ok":
+		return nil
+	case "not_found":
+		return repository.ErrNotFound
+	case "invalid_id":
+		return repository.ErrInvalidID
+
+	default:
+		return errors.New("some-error")
+	}
+}
+
+func (d sectionRepositoryMock) Update(_ context.Context, section repository.Section) (repository.Section, error) {
+	switch section.Title {
+	case "ok":
+		return section, nil
+	case "not_found":
+		return repository.Section{}, repository.ErrNotFound
+
+	default:
+		return repository.Section{}, errors.New("some-error")
+	}
+}
+
+func TestNewService(t *testing.T) {
+	service := NewService(Algorithms, sectionRepositoryMock{}, logging.Discard())
+
+	require.NotNil(t, service)
+	require.Equal(t, Algorithms, service.Kind())
+}
+
+func TestService_GetByID(t *testing.T) {
+	tt := []struct {
+		name             string
+		id               string
+		expectedResponse Section
+		expectedError    error
+	}{
+		{
+			name: "ok",
+			id:   "ok",
+			expectedResponse: Section{
+				// Is an empty ObjectID
+				ID:    "000000000000000000000000",
+				Kind:  "algorithms",
+				Title: "ok",
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error not found",
+			id:               "not-found",
+			expectedResponse: Section{},
+			expectedError:    ErrSectionNotFound,
+		},
+		{
+			name:             "error invalid id",
+			id:               "invalid-id",
+			expectedResponse: Section{},
+			expectedError:    ErrInvalidID,
+		},
+		{
+			name:             "error",
+			id:               "error",
+			expectedResponse: Section{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(Algorithms, sectionRepositoryMock{}, logging.Discard())
+
+			response, err := service.GetByID(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	tt := []struct {
+		name             string
+		params           ListParams
+		expectedResponse ListResult
+		expectedError    error
+	}{
+		{
+			name:   "ok",
+			params: ListParams{Sort: "-createdAt"},
+			expectedResponse: ListResult{
+				Items: []Section{
+					{ID: "000000000000000000000000", Kind: "algorithms", Title: "ok"},
+				},
+				Page:  1,
+				Limit: DefaultListLimit,
+				Total: 1,
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error invalid sort",
+			params:           ListParams{Sort: "kind"},
+			expectedResponse: ListResult{},
+			expectedError:    ErrInvalidSort,
+		},
+		{
+			name:             "error",
+			params:           ListParams{Title: "error"},
+			expectedResponse: ListResult{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(Algorithms, sectionRepositoryMock{}, logging.Discard())
+
+			response, err := service.List(context.Background(), tc.params)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	tt := []struct {
+		name             string
+		section          Section
+		expectedResponse Section
+		expectedError    error
+	}{
+		{
+			name:    "ok",
+			section: Section{Title: "ok"},
+			expectedResponse: Section{
+				ID:    "000000000000000000000000",
+				Kind:  "algorithms",
+				Title: "ok",
+			},
+			expectedError: nil,
+		},
+		{
+			name:             "error",
+			section:          Section{},
+			expectedResponse: Section{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(Algorithms, sectionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Create(context.Background(), tc.section)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tt := []struct {
+		name          string
+		id            string
+		expectedError error
+	}{
+		{
+			name:          "ok",
+			id:            "ok",
+			expectedError: nil,
+		},
+		{
+			name:          "not found",
+			id:            "not_found",
+			expectedError: ErrSectionNotFound,
+		},
+		{
+			name:          "invalid id",
+			id:            "invalid_id",
+			expectedError: ErrInvalidID,
+		},
+		{
+			name:          "error",
+			id:            "error",
+			expectedError: ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(Algorithms, sectionRepositoryMock{}, logging.Discard())
+
+			err := service.Delete(context.Background(), tc.id)
+
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	tt := []struct {
+		name             string
+		section          Section
+		expectedResponse Section
+		expectedError    error
+	}{
+		{
+			name: "ok",
+			section: Section{
+				ID:    "638d568a507b6e07cd39de82",
+				Title: "ok",
+			},
+			expectedResponse: Section{
+				ID:    "638d568a507b6e07cd39de82",
+				Kind:  "algorithms",
+				Title: "ok",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "not found",
+			section: Section{
+				ID:    "638d568a507b6e07cd39de82",
+				Title: "not_found",
+			},
+			expectedResponse: Section{},
+			expectedError:    ErrSectionNotFound,
+		},
+		{
+			name: "invalid id",
+			section: Section{
+				ID:    "aaaa",
+				Title: "ok",
+			},
+			expectedResponse: Section{},
+			expectedError:    ErrInvalidID,
+		},
+		{
+			name: "error",
+			section: Section{
+				ID:    "638d568a507b6e07cd39de82",
+				Title: "error",
+			},
+			expectedResponse: Section{},
+			expectedError:    ErrSomethingWentWrong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewService(Algorithms, sectionRepositoryMock{}, logging.Discard())
+
+			response, err := service.Update(context.Background(), tc.section)
+
+			require.Equal(t, tc.expectedResponse, response)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}